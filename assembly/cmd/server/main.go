@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/config"
+	orderconsumer "github.com/nimbodex/microservices-factory/assembly/internal/consumer/order"
+	assemblyservice "github.com/nimbodex/microservices-factory/assembly/internal/service/assembly"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker/kafka"
+)
+
+func main() {
+	cfg := config.Default()
+	app.Run("assembly", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
+
+func setup(_ context.Context, a *app.App, cfg *config.Config) error {
+	publisher, subscriber, closePublisher := newBroker(a.Logger(), cfg.Events)
+	// Consumers stop before the publisher is closed
+	a.OnShutdown("event publisher", func(context.Context) error {
+		return closePublisher()
+	})
+
+	assemblyService := assemblyservice.NewAssemblyService(publisher, cfg.Events.Topic, cfg.Assembly.BuildDuration, cfg.Assembly.Workers, cfg.Assembly.AssembledTTL)
+
+	// An OrderPaid event is acknowledged once its ship is built, so Kafka
+	// partitions are spread over one consumer per worker. Every in-memory
+	// subscription reads the whole topic, so a single one is used there.
+	consumers := cfg.Assembly.Workers
+	if len(cfg.Events.KafkaBrokers) == 0 {
+		consumers = 1
+	}
+
+	consumer := orderconsumer.NewConsumer(assemblyService)
+	for i := range consumers {
+		a.Go(fmt.Sprintf("order consumer %d", i+1), func(ctx context.Context) error {
+			return subscriber.Subscribe(ctx, cfg.Events.OrderTopic, consumer.Handle)
+		})
+	}
+
+	a.Logger().Info("Assembly Service consuming paid orders",
+		"topic", cfg.Events.OrderTopic,
+		"workers", cfg.Assembly.Workers,
+		"build_duration", cfg.Assembly.BuildDuration,
+	)
+
+	return nil
+}
+
+// newBroker creates the Kafka publisher and subscriber for the configured
+// brokers, or an in-memory broker when there are none. The returned function
+// closes the publisher.
+func newBroker(log *slog.Logger, cfg config.Events) (broker.Publisher, broker.Subscriber, func() error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		log.Info("Kafka brokers are not set, events are kept in memory")
+		memoryBroker := broker.NewMemoryBroker()
+		return memoryBroker, memoryBroker, func() error { return nil }
+	}
+
+	log.Info("Using Kafka", "brokers", cfg.KafkaBrokers)
+	publisher := kafka.NewPublisher(cfg.KafkaBrokers)
+	subscriber := kafka.NewSubscriber(cfg.KafkaBrokers, cfg.ConsumerGroup)
+	return publisher, subscriber, publisher.Close
+}
@@ -0,0 +1,31 @@
+module github.com/nimbodex/microservices-factory/assembly
+
+go 1.24
+
+replace (
+	github.com/nimbodex/microservices-factory/platform => ../platform
+	github.com/nimbodex/microservices-factory/shared => ../shared
+)
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/nimbodex/microservices-factory/platform v0.0.0-00010101000000-000000000000
+	github.com/nimbodex/microservices-factory/shared v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/protobuf v1.36.8
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/segmentio/kafka-go v0.4.49 // indirect
+	github.com/stretchr/objx v0.5.2 // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/grpc v1.73.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+)
+
+// Config is the configuration of the assembly service
+type Config struct {
+	Assembly Assembly           `yaml:"assembly"`
+	Events   Events             `yaml:"events"`
+	Log      platformconfig.Log `yaml:"log"`
+}
+
+// Assembly configures building of ships
+type Assembly struct {
+	// BuildDuration is how long assembling a single ship takes
+	BuildDuration time.Duration `yaml:"build_duration" env:"ASSEMBLY_BUILD_DURATION"`
+	// Workers is how many ships are assembled at the same time; with Kafka,
+	// as many consumers share the order topic, which needs at least as many
+	// partitions for all of them to get work
+	Workers int `yaml:"workers" env:"ASSEMBLY_WORKERS"`
+	// AssembledTTL is how long built orders are remembered to skip
+	// redelivered OrderPaid events
+	AssembledTTL time.Duration `yaml:"assembled_ttl" env:"ASSEMBLY_ASSEMBLED_TTL"`
+}
+
+// Events configures consuming of order events and publishing of assembly events
+type Events struct {
+	// KafkaBrokers lists Kafka bootstrap brokers; without them events go
+	// through an in-memory broker and never leave the process
+	KafkaBrokers  []string `yaml:"kafka_brokers" env:"ASSEMBLY_KAFKA_BROKERS"`
+	OrderTopic    string   `yaml:"order_topic" env:"ASSEMBLY_ORDER_EVENTS_TOPIC"`
+	Topic         string   `yaml:"topic" env:"ASSEMBLY_EVENTS_TOPIC"`
+	ConsumerGroup string   `yaml:"consumer_group" env:"ASSEMBLY_CONSUMER_GROUP"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Assembly: Assembly{
+			BuildDuration: 10 * time.Second,
+			Workers:       4,
+			AssembledTTL:  24 * time.Hour,
+		},
+		Events: Events{
+			OrderTopic:    "order.events",
+			Topic:         "assembly.events",
+			ConsumerGroup: "assembly",
+		},
+		Log: platformconfig.Log{Level: "info"},
+	}
+}
+
+// Validate checks that every setting can be used
+func (c *Config) Validate() error {
+	var buildDurationErr error
+	if c.Assembly.BuildDuration < 0 {
+		buildDurationErr = fmt.Errorf("assembly.build_duration must not be negative, got %s", c.Assembly.BuildDuration)
+	}
+
+	return errors.Join(
+		buildDurationErr,
+		platformconfig.Positive("assembly.workers", c.Assembly.Workers),
+		platformconfig.Positive("assembly.assembled_ttl", c.Assembly.AssembledTTL),
+		platformconfig.Required("events.order_topic", c.Events.OrderTopic),
+		platformconfig.Required("events.topic", c.Events.Topic),
+		platformconfig.Required("events.consumer_group", c.Events.ConsumerGroup),
+		c.Log.Validate(),
+	)
+}
+
+// LogLevel returns the configured log level
+func (c *Config) LogLevel() string {
+	return c.Log.Level
+}
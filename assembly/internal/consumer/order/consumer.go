@@ -0,0 +1,53 @@
+package order
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/converter"
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+	"github.com/nimbodex/microservices-factory/assembly/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// Consumer starts the assembly of every paid order
+type Consumer struct {
+	assemblyService service.AssemblyService
+}
+
+// NewConsumer creates a new order events consumer
+func NewConsumer(assemblyService service.AssemblyService) *Consumer {
+	return &Consumer{
+		assemblyService: assemblyService,
+	}
+}
+
+// Handle processes a single order event. Events other than OrderPaid are
+// skipped and malformed ones are logged and dropped, so they do not block
+// the events after them.
+func (c *Consumer) Handle(ctx context.Context, msg broker.Message) error {
+	if msg.Headers[broker.HeaderEventType] != model.OrderEventTypePaid {
+		return nil
+	}
+
+	log := logger.FromContext(ctx).With(
+		slog.String("event_type", model.OrderEventTypePaid),
+		slog.String("event_id", msg.Headers[broker.HeaderEventID]),
+	)
+
+	if version := msg.Headers[broker.HeaderEventVersion]; version != strconv.Itoa(model.OrderEventVersion) {
+		log.Warn("Skipping event of unsupported version", slog.String("version", version))
+		return nil
+	}
+
+	order, err := converter.ToPaidOrder(msg.Value)
+	if err != nil {
+		log.Warn("Dropping malformed event", logger.Err(err))
+		return nil
+	}
+
+	// The assembly logs name the event it was started by
+	return c.assemblyService.Assemble(logger.WithContext(ctx, log), order)
+}
@@ -0,0 +1,80 @@
+package order
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+	servicemocks "github.com/nimbodex/microservices-factory/assembly/internal/service/mocks"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+func newOrderPaidMessage(t *testing.T, event *eventsv1.OrderPaid) broker.Message {
+	t.Helper()
+
+	value, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	return broker.Message{
+		Topic: "order.events",
+		Value: value,
+		Headers: map[string]string{
+			broker.HeaderEventID:      uuid.NewString(),
+			broker.HeaderEventType:    model.OrderEventTypePaid,
+			broker.HeaderEventVersion: "1",
+		},
+	}
+}
+
+func TestConsumer_AssemblesPaidOrders(t *testing.T) {
+	orderUUID := uuid.New()
+	userUUID := uuid.New()
+
+	assemblyService := servicemocks.NewAssemblyService(t)
+	assemblyService.On("Assemble", mock.Anything, &model.PaidOrder{OrderUUID: orderUUID, UserUUID: userUUID}).
+		Return(nil).Once()
+
+	msg := newOrderPaidMessage(t, &eventsv1.OrderPaid{OrderUuid: orderUUID.String(), UserUuid: userUUID.String()})
+
+	require.NoError(t, NewConsumer(assemblyService).Handle(context.Background(), msg))
+}
+
+func TestConsumer_SkipsOtherAndMalformedEvents(t *testing.T) {
+	assemblyService := servicemocks.NewAssemblyService(t)
+	consumer := NewConsumer(assemblyService)
+
+	paid := &eventsv1.OrderPaid{OrderUuid: uuid.NewString(), UserUuid: uuid.NewString()}
+
+	created := newOrderPaidMessage(t, paid)
+	created.Headers[broker.HeaderEventType] = "OrderCreated"
+	require.NoError(t, consumer.Handle(context.Background(), created))
+
+	newer := newOrderPaidMessage(t, paid)
+	newer.Headers[broker.HeaderEventVersion] = "2"
+	require.NoError(t, consumer.Handle(context.Background(), newer))
+
+	badUUID := newOrderPaidMessage(t, &eventsv1.OrderPaid{OrderUuid: "not-a-uuid", UserUuid: uuid.NewString()})
+	require.NoError(t, consumer.Handle(context.Background(), badUUID))
+
+	badPayload := newOrderPaidMessage(t, paid)
+	badPayload.Value = []byte{0xff}
+	require.NoError(t, consumer.Handle(context.Background(), badPayload))
+}
+
+func TestConsumer_ReturnsAssembleErrors(t *testing.T) {
+	assemblyService := servicemocks.NewAssemblyService(t)
+	assemblyService.On("Assemble", mock.Anything, mock.Anything).Return(context.Canceled).Once()
+
+	msg := newOrderPaidMessage(t, &eventsv1.OrderPaid{OrderUuid: uuid.NewString(), UserUuid: uuid.NewString()})
+
+	err := NewConsumer(assemblyService).Handle(context.Background(), msg)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,83 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+// ToPaidOrder decodes the payload of an OrderPaid event
+func ToPaidOrder(payload []byte) (*model.PaidOrder, error) {
+	var event eventsv1.OrderPaid
+	if err := proto.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode OrderPaid event: %w", err)
+	}
+
+	orderUUID, err := uuid.Parse(event.GetOrderUuid())
+	if err != nil {
+		return nil, fmt.Errorf("parse order UUID %q: %w", event.GetOrderUuid(), err)
+	}
+
+	userUUID, err := uuid.Parse(event.GetUserUuid())
+	if err != nil {
+		return nil, fmt.Errorf("parse user UUID %q: %w", event.GetUserUuid(), err)
+	}
+
+	return &model.PaidOrder{
+		OrderUUID: orderUUID,
+		UserUUID:  userUUID,
+	}, nil
+}
+
+// ToShipAssemblyStartedMessage builds the ShipAssemblyStarted event of an assembly
+func ToShipAssemblyStartedMessage(topic string, assembly *model.Assembly) (broker.Message, error) {
+	return newMessage(topic, model.EventTypeShipAssemblyStarted, assembly.OrderUUID, &eventsv1.ShipAssemblyStarted{
+		AssemblyUuid: assembly.UUID.String(),
+		OrderUuid:    assembly.OrderUUID.String(),
+		UserUuid:     assembly.UserUUID.String(),
+		StartedAt:    timestamppb.New(assembly.StartedAt),
+	})
+}
+
+// ToShipAssembledMessage builds the ShipAssembled event of a finished assembly
+func ToShipAssembledMessage(topic string, assembly *model.Assembly) (broker.Message, error) {
+	if assembly.AssembledAt == nil {
+		return broker.Message{}, fmt.Errorf("assembly %s is not finished", assembly.UUID)
+	}
+
+	return newMessage(topic, model.EventTypeShipAssembled, assembly.OrderUUID, &eventsv1.ShipAssembled{
+		AssemblyUuid: assembly.UUID.String(),
+		OrderUuid:    assembly.OrderUUID.String(),
+		UserUuid:     assembly.UserUUID.String(),
+		BuildTime:    durationpb.New(assembly.BuildTime()),
+		AssembledAt:  timestamppb.New(*assembly.AssembledAt),
+	})
+}
+
+// newMessage encodes payload into a message keyed by the order UUID, so
+// events of an order are consumed in order
+func newMessage(topic string, eventType model.EventType, orderUUID uuid.UUID, payload proto.Message) (broker.Message, error) {
+	encoded, err := proto.Marshal(payload)
+	if err != nil {
+		return broker.Message{}, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	return broker.Message{
+		Topic: topic,
+		Key:   []byte(orderUUID.String()),
+		Value: encoded,
+		Headers: map[string]string{
+			broker.HeaderEventID:      uuid.NewString(),
+			broker.HeaderEventType:    string(eventType),
+			broker.HeaderEventVersion: strconv.Itoa(model.EventVersion),
+		},
+	}, nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaidOrder is an order that has been paid and is waiting for its ship
+type PaidOrder struct {
+	OrderUUID uuid.UUID `json:"order_uuid"`
+	UserUUID  uuid.UUID `json:"user_uuid"`
+}
+
+// Assembly represents building the ship of a paid order
+type Assembly struct {
+	UUID      uuid.UUID `json:"uuid"`
+	OrderUUID uuid.UUID `json:"order_uuid"`
+	UserUUID  uuid.UUID `json:"user_uuid"`
+	StartedAt time.Time `json:"started_at"`
+	// AssembledAt is set once the ship is built
+	AssembledAt *time.Time `json:"assembled_at,omitempty"`
+}
+
+// BuildTime returns how long the assembly took, or zero while it is in progress
+func (a *Assembly) BuildTime() time.Duration {
+	if a.AssembledAt == nil {
+		return 0
+	}
+	return a.AssembledAt.Sub(a.StartedAt)
+}
@@ -0,0 +1,20 @@
+package model
+
+// EventType names a domain event published by the assembly service
+type EventType string
+
+const (
+	EventTypeShipAssemblyStarted EventType = "ShipAssemblyStarted"
+	EventTypeShipAssembled       EventType = "ShipAssembled"
+)
+
+// EventVersion is the schema version of event payloads. It is bumped on
+// changes consumers cannot read with the previous schema.
+const EventVersion = 1
+
+// Order events the assembly service consumes
+const (
+	OrderEventTypePaid = "OrderPaid"
+	// OrderEventVersion is the order event schema version the service reads
+	OrderEventVersion = 1
+)
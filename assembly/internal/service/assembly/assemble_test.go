@@ -0,0 +1,165 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+const testTopic = "assembly.events"
+
+// failingPublisher fails the first failures publishes and then stores messages in a memory broker
+type failingPublisher struct {
+	*broker.MemoryBroker
+	mu       sync.Mutex
+	failures int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, msgs ...broker.Message) error {
+	p.mu.Lock()
+	if p.failures > 0 {
+		p.failures--
+		p.mu.Unlock()
+		return errors.New("broker unavailable")
+	}
+	p.mu.Unlock()
+
+	return p.MemoryBroker.Publish(ctx, msgs...)
+}
+
+func newPaidOrder() *model.PaidOrder {
+	return &model.PaidOrder{OrderUUID: uuid.New(), UserUUID: uuid.New()}
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_PublishesStartedAndAssembled() {
+	memoryBroker := broker.NewMemoryBroker()
+	service := NewAssemblyService(memoryBroker, testTopic, 20*time.Millisecond, 2, time.Hour)
+
+	order := newPaidOrder()
+	s.Require().NoError(service.Assemble(context.Background(), order))
+
+	// The event is acknowledged only once the ship is reported
+	msgs := memoryBroker.Messages(testTopic)
+	s.Require().Len(msgs, 2)
+	s.Equal(string(model.EventTypeShipAssemblyStarted), msgs[0].Headers[broker.HeaderEventType])
+	s.Equal(string(model.EventTypeShipAssembled), msgs[1].Headers[broker.HeaderEventType])
+	s.Equal("1", msgs[1].Headers[broker.HeaderEventVersion])
+	s.Equal(order.OrderUUID.String(), string(msgs[1].Key))
+
+	var assembled eventsv1.ShipAssembled
+	s.Require().NoError(proto.Unmarshal(msgs[1].Value, &assembled))
+	s.Equal(order.OrderUUID.String(), assembled.GetOrderUuid())
+	s.Equal(order.UserUUID.String(), assembled.GetUserUuid())
+	s.GreaterOrEqual(assembled.GetBuildTime().AsDuration(), 20*time.Millisecond)
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_BoundedByWorkers() {
+	memoryBroker := broker.NewMemoryBroker()
+	service := NewAssemblyService(memoryBroker, testTopic, 100*time.Millisecond, 1, time.Hour)
+
+	first := make(chan error, 1)
+	go func() { first <- service.Assemble(context.Background(), newPaidOrder()) }()
+	s.Eventually(func() bool { return len(memoryBroker.Messages(testTopic)) == 1 }, time.Second, time.Millisecond)
+
+	// The only worker is busy, so the second order is not started in time
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	second := newPaidOrder()
+	s.ErrorIs(service.Assemble(ctx, second), context.DeadlineExceeded)
+	s.Len(memoryBroker.Messages(testTopic), 1)
+
+	// Once the worker is free the order is accepted again
+	s.Require().NoError(<-first)
+	s.Require().NoError(service.Assemble(context.Background(), second))
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_SkipsOrderInProgress() {
+	memoryBroker := broker.NewMemoryBroker()
+	service := NewAssemblyService(memoryBroker, testTopic, 50*time.Millisecond, 2, time.Hour)
+
+	order := newPaidOrder()
+	first := make(chan error, 1)
+	go func() { first <- service.Assemble(context.Background(), order) }()
+	s.Eventually(func() bool { return len(memoryBroker.Messages(testTopic)) == 1 }, time.Second, time.Millisecond)
+
+	s.Require().NoError(service.Assemble(context.Background(), order))
+	s.Require().NoError(<-first)
+
+	s.Len(memoryBroker.Messages(testTopic), 2)
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_SkipsAssembledOrderUntilTTL() {
+	memoryBroker := broker.NewMemoryBroker()
+	service := NewAssemblyService(memoryBroker, testTopic, time.Millisecond, 2, time.Hour)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	order := newPaidOrder()
+	s.Require().NoError(service.Assemble(context.Background(), order))
+
+	// The OrderPaid event is redelivered after the ship is built
+	s.Require().NoError(service.Assemble(context.Background(), order))
+	s.Len(memoryBroker.Messages(testTopic), 2)
+
+	// Built orders are forgotten after the TTL, so the record stays bounded
+	now = now.Add(time.Hour + time.Second)
+	s.Require().NoError(service.Assemble(context.Background(), newPaidOrder()))
+	s.NotContains(service.assembled, order.OrderUUID)
+	s.Len(service.assembledOrder, 1)
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_StoppedBuildIsNotAcknowledged() {
+	memoryBroker := broker.NewMemoryBroker()
+	service := NewAssemblyService(memoryBroker, testTopic, time.Hour, 1, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	order := newPaidOrder()
+	done := make(chan error, 1)
+	go func() { done <- service.Assemble(ctx, order) }()
+	s.Eventually(func() bool { return len(memoryBroker.Messages(testTopic)) == 1 }, time.Second, time.Millisecond)
+
+	// Shutdown does not wait for the build
+	cancel()
+	select {
+	case err := <-done:
+		s.ErrorIs(err, context.Canceled)
+	case <-time.After(time.Second):
+		s.FailNow("build did not stop")
+	}
+
+	// The redelivered event builds the ship again
+	service.buildDuration = time.Millisecond
+	s.Require().NoError(service.Assemble(context.Background(), order))
+	s.Equal(string(model.EventTypeShipAssembled), memoryBroker.Messages(testTopic)[2].Headers[broker.HeaderEventType])
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_RetriesPublish() {
+	publisher := &failingPublisher{MemoryBroker: broker.NewMemoryBroker(), failures: 2}
+	service := NewAssemblyService(publisher, testTopic, time.Millisecond, 1, time.Hour)
+	service.publishBackoff = time.Millisecond
+
+	s.Require().NoError(service.Assemble(context.Background(), newPaidOrder()))
+
+	s.Len(publisher.Messages(testTopic), 2)
+}
+
+func (s *AssemblyServiceTestSuite) TestAssemble_PublishFailureIsRedelivered() {
+	publisher := &failingPublisher{MemoryBroker: broker.NewMemoryBroker(), failures: publishAttempts}
+	service := NewAssemblyService(publisher, testTopic, time.Millisecond, 1, time.Hour)
+	service.publishBackoff = time.Millisecond
+
+	order := newPaidOrder()
+	s.Error(service.Assemble(context.Background(), order))
+
+	// The order is not remembered as built, so the redelivered event builds it
+	s.Require().NoError(service.Assemble(context.Background(), order))
+	s.Len(publisher.Messages(testTopic), 2)
+}
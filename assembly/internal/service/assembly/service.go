@@ -0,0 +1,193 @@
+package assembly
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/converter"
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+const (
+	// publishAttempts is how many times an assembly event is published before giving up
+	publishAttempts = 5
+	// defaultPublishBackoff is the delay before the first publish retry; it doubles after each attempt
+	defaultPublishBackoff = 200 * time.Millisecond
+)
+
+// AssemblyServiceImpl implements AssemblyService interface. Assemble returns
+// only once the ship is built and reported, so the OrderPaid event is
+// acknowledged after the build and an order being built when the service
+// stops is delivered again. At most workers ships are built at a time.
+type AssemblyServiceImpl struct {
+	publisher     broker.Publisher
+	topic         string
+	buildDuration time.Duration
+	// workers holds a token for every ship being built
+	workers chan struct{}
+	now     func() time.Time
+	// publishBackoff is the delay before the first publish retry
+	publishBackoff time.Duration
+	// assembledTTL is how long a built order is remembered
+	assembledTTL time.Duration
+
+	mu sync.Mutex
+	// inProgress holds orders whose ships are being built
+	inProgress map[uuid.UUID]struct{}
+	// assembled holds orders built within assembledTTL, so that OrderPaid
+	// events redelivered before their offset was committed do not build them
+	// again; assembledOrder keeps them oldest first for pruning
+	assembled      map[uuid.UUID]struct{}
+	assembledOrder []assembledOrder
+}
+
+// assembledOrder is an order whose ship was built at assembledAt
+type assembledOrder struct {
+	orderUUID   uuid.UUID
+	assembledAt time.Time
+}
+
+// NewAssemblyService creates a new assembly service that builds each ship in
+// buildDuration with at most workers ships at a time, publishing assembly
+// events to topic. Built orders are remembered for assembledTTL.
+func NewAssemblyService(publisher broker.Publisher, topic string, buildDuration time.Duration, workers int, assembledTTL time.Duration) *AssemblyServiceImpl {
+	return &AssemblyServiceImpl{
+		publisher:      publisher,
+		topic:          topic,
+		buildDuration:  buildDuration,
+		workers:        make(chan struct{}, workers),
+		now:            time.Now,
+		publishBackoff: defaultPublishBackoff,
+		assembledTTL:   assembledTTL,
+		inProgress:     make(map[uuid.UUID]struct{}),
+		assembled:      make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Assemble builds the ship of a paid order once a worker is free. Orders
+// already assembled or being assembled are skipped, since order events are
+// delivered at least once. A build stopped by ctx returns its error, so the
+// event is not acknowledged and the order is built after a restart.
+func (s *AssemblyServiceImpl) Assemble(ctx context.Context, order *model.PaidOrder) error {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", order.OrderUUID.String()))
+
+	s.mu.Lock()
+	s.pruneAssembled()
+	if _, ok := s.assembled[order.OrderUUID]; ok {
+		s.mu.Unlock()
+		log.Info("Ship is already assembled")
+		return nil
+	}
+	if _, ok := s.inProgress[order.OrderUUID]; ok {
+		s.mu.Unlock()
+		log.Info("Ship is already being assembled")
+		return nil
+	}
+	s.inProgress[order.OrderUUID] = struct{}{}
+	s.mu.Unlock()
+
+	select {
+	case s.workers <- struct{}{}:
+	case <-ctx.Done():
+		s.finish(order.OrderUUID, false)
+		return ctx.Err()
+	}
+	defer func() { <-s.workers }()
+
+	err := s.assemble(logger.WithContext(ctx, log), order)
+	s.finish(order.OrderUUID, err == nil)
+	return err
+}
+
+// assemble builds the ship of a single order, reporting its start and end
+func (s *AssemblyServiceImpl) assemble(ctx context.Context, order *model.PaidOrder) error {
+	assembly := &model.Assembly{
+		UUID:      uuid.New(),
+		OrderUUID: order.OrderUUID,
+		UserUUID:  order.UserUUID,
+		StartedAt: s.now(),
+	}
+
+	log := logger.FromContext(ctx).With(slog.String("assembly_uuid", assembly.UUID.String()))
+	log.Info("Assembling ship")
+	if err := s.publish(ctx, assembly, converter.ToShipAssemblyStartedMessage); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(s.buildDuration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		log.Warn("Assembly stopped before the ship was built")
+		return ctx.Err()
+	}
+
+	assembledAt := s.now()
+	assembly.AssembledAt = &assembledAt
+
+	log.Info("Ship assembled", slog.Duration("build_time", assembly.BuildTime()))
+	// The ship is built, so it is reported even if the service is stopping
+	return s.publish(context.WithoutCancel(ctx), assembly, converter.ToShipAssembledMessage)
+}
+
+// publish sends an assembly event, retrying with exponential backoff. The
+// error after the last attempt is returned, so the order event is delivered
+// again rather than the assembly going unreported.
+func (s *AssemblyServiceImpl) publish(ctx context.Context, assembly *model.Assembly, toMessage func(string, *model.Assembly) (broker.Message, error)) error {
+	msg, err := toMessage(s.topic, assembly)
+	if err != nil {
+		return fmt.Errorf("build event of assembly %s: %w", assembly.UUID, err)
+	}
+
+	backoff := s.publishBackoff
+	for attempt := 1; ; attempt++ {
+		err := s.publisher.Publish(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if attempt == publishAttempts {
+			return fmt.Errorf("publish %s event of assembly %s: %w", msg.Headers[broker.HeaderEventType], assembly.UUID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// finish forgets an order once its assembly is over, remembering it as
+// assembled if its ship was built
+func (s *AssemblyServiceImpl) finish(orderUUID uuid.UUID, assembled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.inProgress, orderUUID)
+	if assembled {
+		s.assembled[orderUUID] = struct{}{}
+		s.assembledOrder = append(s.assembledOrder, assembledOrder{orderUUID: orderUUID, assembledAt: s.now()})
+	}
+}
+
+// pruneAssembled forgets orders built more than assembledTTL ago; s.mu must
+// be held
+func (s *AssemblyServiceImpl) pruneAssembled() {
+	cutoff := s.now().Add(-s.assembledTTL)
+
+	n := 0
+	for n < len(s.assembledOrder) && s.assembledOrder[n].assembledAt.Before(cutoff) {
+		delete(s.assembled, s.assembledOrder[n].orderUUID)
+		n++
+	}
+	s.assembledOrder = s.assembledOrder[n:]
+}
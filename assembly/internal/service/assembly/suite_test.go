@@ -0,0 +1,15 @@
+package assembly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AssemblyServiceTestSuite struct {
+	suite.Suite
+}
+
+func TestAssemblyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AssemblyServiceTestSuite))
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/nimbodex/microservices-factory/assembly/internal/model"
+)
+
+// AssemblyService defines the interface for assembly service operations
+type AssemblyService interface {
+	Assemble(ctx context.Context, order *model.PaidOrder) error
+}
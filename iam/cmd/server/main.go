@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	v1 "github.com/nimbodex/microservices-factory/iam/internal/api/iam/v1"
+	"github.com/nimbodex/microservices-factory/iam/internal/config"
+	"github.com/nimbodex/microservices-factory/iam/internal/repository/session"
+	"github.com/nimbodex/microservices-factory/iam/internal/repository/user"
+	iamservice "github.com/nimbodex/microservices-factory/iam/internal/service/iam"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/metrics"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+func main() {
+	cfg := config.Default()
+	app.Run("iam", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
+
+func setup(ctx context.Context, a *app.App, cfg *config.Config) error {
+	shutdownTracing, err := tracing.Init(ctx, "iam", cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	// Registered first so that spans of the last requests are flushed after everything else is closed
+	a.OnShutdown("tracer provider", shutdownTracing)
+
+	userRepo := user.NewMemoryUserRepository()
+	sessionRepo := session.NewMemorySessionRepository()
+
+	adminUUIDs, err := cfg.Users.AdminUserUUIDs()
+	if err != nil {
+		return err
+	}
+
+	iamService := iamservice.NewIAMService(userRepo, sessionRepo, cfg.Sessions.TTL, adminUUIDs)
+
+	reg := metrics.NewRegistry()
+	grpcServer := grpc.NewServer(interceptor.ServerOptions(a.Logger(), reg)...)
+	iamv1.RegisterIAMServiceServer(grpcServer, v1.NewAPIHandler(iamService))
+	reflection.Register(grpcServer)
+
+	// Probes see NOT_SERVING while in-flight calls finish on shutdown
+	healthServer := health.RegisterGRPC(grpcServer)
+	a.BeforeStop(healthServer.Shutdown)
+
+	a.Go("grpc", app.GRPCServer(cfg.GRPC.Addr, grpcServer))
+	a.Go("admin", app.HTTPServer(metrics.NewAdminServer(cfg.Admin.Addr, reg)))
+
+	a.Logger().Info("IAM Service listening",
+		"addr", cfg.GRPC.Addr,
+		"admin_addr", cfg.Admin.Addr,
+		"session_ttl", cfg.Sessions.TTL,
+		"admins", len(adminUUIDs),
+		"methods", []string{"Register", "Login", "ValidateSession", "GetUser"},
+	)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/converter"
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+	"github.com/nimbodex/microservices-factory/iam/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+// errorMapper sends service errors with the status codes callers can act on
+var errorMapper = grpcerr.NewMapper("iam", map[string]codes.Code{
+	model.ErrCodeValidationError:    codes.InvalidArgument,
+	model.ErrCodeUserAlreadyExists:  codes.AlreadyExists,
+	model.ErrCodeUserNotFound:       codes.NotFound,
+	model.ErrCodeInvalidCredentials: codes.Unauthenticated,
+	model.ErrCodeInvalidSession:     codes.Unauthenticated,
+})
+
+// APIHandler handles gRPC requests for IAM API
+type APIHandler struct {
+	iamv1.UnimplementedIAMServiceServer
+	iamService service.IAMService
+}
+
+// NewAPIHandler creates a new API handler
+func NewAPIHandler(iamService service.IAMService) *APIHandler {
+	return &APIHandler{
+		iamService: iamService,
+	}
+}
+
+// Register handles Register gRPC requests
+func (h *APIHandler) Register(ctx context.Context, req *iamv1.RegisterRequest) (*iamv1.RegisterResponse, error) {
+	user, err := h.iamService.Register(ctx, converter.ToRegisterRequest(req))
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return &iamv1.RegisterResponse{UserUuid: user.UUID.String()}, nil
+}
+
+// Login handles Login gRPC requests
+func (h *APIHandler) Login(ctx context.Context, req *iamv1.LoginRequest) (*iamv1.LoginResponse, error) {
+	session, err := h.iamService.Login(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return converter.ToProtoLoginResponse(session), nil
+}
+
+// ValidateSession handles ValidateSession gRPC requests
+func (h *APIHandler) ValidateSession(ctx context.Context, req *iamv1.ValidateSessionRequest) (*iamv1.ValidateSessionResponse, error) {
+	sessionUUID, err := uuid.Parse(req.GetSessionUuid())
+	if err != nil {
+		// A malformed session is as invalid as an unknown one
+		return nil, status.Error(codes.Unauthenticated, "session is invalid or expired")
+	}
+
+	session, err := h.iamService.ValidateSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return converter.ToProtoValidateSessionResponse(session), nil
+}
+
+// GetUser handles GetUser gRPC requests
+func (h *APIHandler) GetUser(ctx context.Context, req *iamv1.GetUserRequest) (*iamv1.GetUserResponse, error) {
+	userUUID, err := uuid.Parse(req.GetUserUuid())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user UUID format")
+	}
+
+	user, err := h.iamService.GetUser(ctx, userUUID)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return &iamv1.GetUserResponse{User: converter.ToProtoUser(user)}, nil
+}
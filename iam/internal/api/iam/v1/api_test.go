@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+	servicemocks "github.com/nimbodex/microservices-factory/iam/internal/service/mocks"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+func TestAPIHandler_ValidateSession(t *testing.T) {
+	session := &model.Session{UUID: uuid.New(), UserUUID: uuid.New(), UserRole: model.RoleAdmin, ExpiresAt: time.Now().Add(time.Hour)}
+
+	iamService := servicemocks.NewIAMService(t)
+	iamService.On("ValidateSession", context.Background(), session.UUID).Return(session, nil)
+
+	resp, err := NewAPIHandler(iamService).ValidateSession(context.Background(), &iamv1.ValidateSessionRequest{SessionUuid: session.UUID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, session.UserUUID.String(), resp.GetUserUuid())
+	assert.True(t, session.ExpiresAt.Equal(resp.GetExpiresAt().AsTime()))
+	assert.Equal(t, iamv1.Role_ROLE_ADMIN, resp.GetRole())
+}
+
+func TestAPIHandler_ValidateSession_MalformedUUID(t *testing.T) {
+	_, err := NewAPIHandler(servicemocks.NewIAMService(t)).ValidateSession(context.Background(), &iamv1.ValidateSessionRequest{SessionUuid: "not-a-uuid"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAPIHandler_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		err  *model.ServiceError
+		code codes.Code
+	}{
+		{model.NewValidationError("email is invalid"), codes.InvalidArgument},
+		{model.NewUserAlreadyExistsError("gagarin"), codes.AlreadyExists},
+		{model.NewInvalidCredentialsError(), codes.Unauthenticated},
+		{model.NewInternalError(assert.AnError), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			iamService := servicemocks.NewIAMService(t)
+			iamService.On("Login", context.Background(), "gagarin", "vostok-1961").Return(nil, tt.err)
+
+			_, err := NewAPIHandler(iamService).Login(context.Background(), &iamv1.LoginRequest{Login: "gagarin", Password: "vostok-1961"})
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.err.Code, grpcerr.Reason(err))
+		})
+	}
+}
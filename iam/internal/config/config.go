@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+// Config is the configuration of the IAM service
+type Config struct {
+	GRPC     GRPC               `yaml:"grpc"`
+	Admin    Admin              `yaml:"admin"`
+	Sessions Sessions           `yaml:"sessions"`
+	Users    Users              `yaml:"users"`
+	Tracing  tracing.Config     `yaml:"tracing"`
+	Log      platformconfig.Log `yaml:"log"`
+}
+
+// GRPC configures the IAM gRPC server
+type GRPC struct {
+	Addr string `yaml:"addr" env:"IAM_GRPC_ADDR"`
+}
+
+// Admin configures the admin HTTP server exposing metrics
+type Admin struct {
+	Addr string `yaml:"addr" env:"IAM_ADMIN_ADDR"`
+}
+
+// Sessions configures login sessions
+type Sessions struct {
+	// TTL is how long a session stays valid after login
+	TTL time.Duration `yaml:"ttl" env:"IAM_SESSION_TTL"`
+}
+
+// Users configures user roles
+type Users struct {
+	// AdminUUIDs are users granted the admin role. Users register with the
+	// user role; an operator grants admin by adding their UUID here.
+	AdminUUIDs []string `yaml:"admin_uuids" env:"IAM_ADMIN_USER_UUIDS"`
+}
+
+// AdminUserUUIDs returns the parsed UUIDs of the admins
+func (u Users) AdminUserUUIDs() ([]uuid.UUID, error) {
+	admins := make([]uuid.UUID, 0, len(u.AdminUUIDs))
+	for i, raw := range u.AdminUUIDs {
+		userUUID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("users.admin_uuids[%d]: %w", i, err)
+		}
+		admins = append(admins, userUUID)
+	}
+	return admins, nil
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		GRPC:     GRPC{Addr: "localhost:50054"},
+		Admin:    Admin{Addr: "localhost:9054"},
+		Sessions: Sessions{TTL: 24 * time.Hour},
+		Log:      platformconfig.Log{Level: "info"},
+	}
+}
+
+// Validate checks that every setting can be used
+func (c *Config) Validate() error {
+	return errors.Join(
+		platformconfig.Required("grpc.addr", c.GRPC.Addr),
+		platformconfig.Required("admin.addr", c.Admin.Addr),
+		platformconfig.Positive("sessions.ttl", c.Sessions.TTL),
+		validateAdmins(c.Users),
+		c.Log.Validate(),
+	)
+}
+
+func validateAdmins(users Users) error {
+	_, err := users.AdminUserUUIDs()
+	return err
+}
+
+// LogLevel returns the configured log level
+func (c *Config) LogLevel() string {
+	return c.Log.Level
+}
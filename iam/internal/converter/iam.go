@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+// ToRegisterRequest converts the protobuf register request to the domain model
+func ToRegisterRequest(req *iamv1.RegisterRequest) *model.RegisterRequest {
+	return &model.RegisterRequest{
+		Login:    req.GetLogin(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+}
+
+// ToProtoLoginResponse converts a new session to the protobuf login response
+func ToProtoLoginResponse(session *model.Session) *iamv1.LoginResponse {
+	return &iamv1.LoginResponse{
+		SessionUuid: session.UUID.String(),
+		ExpiresAt:   timestamppb.New(session.ExpiresAt),
+	}
+}
+
+// ToProtoValidateSessionResponse converts a valid session to the protobuf response
+func ToProtoValidateSessionResponse(session *model.Session) *iamv1.ValidateSessionResponse {
+	return &iamv1.ValidateSessionResponse{
+		UserUuid:  session.UserUUID.String(),
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		Role:      ToProtoRole(session.UserRole),
+	}
+}
+
+// ToProtoUser converts the domain user to protobuf, leaving out the password hash
+func ToProtoUser(user *model.User) *iamv1.User {
+	return &iamv1.User{
+		Uuid:      user.UUID.String(),
+		Login:     user.Login,
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		Role:      ToProtoRole(user.Role),
+	}
+}
+
+// ToProtoRole converts the domain role to protobuf
+func ToProtoRole(role model.Role) iamv1.Role {
+	switch role {
+	case model.RoleUser:
+		return iamv1.Role_ROLE_USER
+	case model.RoleAdmin:
+		return iamv1.Role_ROLE_ADMIN
+	default:
+		return iamv1.Role_ROLE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Repository errors
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrSessionNotFound   = errors.New("session not found")
+)
+
+// ServiceError represents a service layer error
+type ServiceError struct {
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *ServiceError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s (%v)", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode and ErrorMessage let errors be sent over gRPC with details
+func (e *ServiceError) ErrorCode() string    { return e.Code }
+func (e *ServiceError) ErrorMessage() string { return e.Message }
+
+// Common service error codes
+const (
+	ErrCodeValidationError    = "VALIDATION_ERROR"
+	ErrCodeUserAlreadyExists  = "USER_ALREADY_EXISTS"
+	ErrCodeUserNotFound       = "USER_NOT_FOUND"
+	ErrCodeInvalidCredentials = "INVALID_CREDENTIALS"
+	ErrCodeInvalidSession     = "INVALID_SESSION"
+	ErrCodeInternalError      = "INTERNAL_ERROR"
+)
+
+// Error constructors
+func NewValidationError(message string) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeValidationError,
+		Message: message,
+	}
+}
+
+func NewUserAlreadyExistsError(login string) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeUserAlreadyExists,
+		Message: fmt.Sprintf("user %s already exists", login),
+	}
+}
+
+func NewUserNotFoundError(userUUID string) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeUserNotFound,
+		Message: fmt.Sprintf("user %s not found", userUUID),
+	}
+}
+
+// NewInvalidCredentialsError does not tell an unknown login from a wrong
+// password, so that logins cannot be enumerated
+func NewInvalidCredentialsError() *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeInvalidCredentials,
+		Message: "invalid login or password",
+	}
+}
+
+// NewInvalidSessionError is returned for unknown and expired sessions alike
+func NewInvalidSessionError() *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeInvalidSession,
+		Message: "session is invalid or expired",
+	}
+}
+
+func NewInternalError(err error) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeInternalError,
+		Message: "internal service error",
+		Err:     err,
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role defines what a user is allowed to do
+type Role string
+
+const (
+	RoleUser Role = "USER"
+	// RoleAdmin may act on orders of every user
+	RoleAdmin Role = "ADMIN"
+)
+
+// User is a registered user; the password is only kept as a bcrypt hash
+type User struct {
+	UUID         uuid.UUID
+	Login        string
+	Email        string
+	PasswordHash []byte
+	Role         Role
+	CreatedAt    time.Time
+}
+
+// RegisterRequest holds the data of a new user
+type RegisterRequest struct {
+	Login    string
+	Email    string
+	Password string
+}
+
+// Session is a login of a user, valid until ExpiresAt
+type Session struct {
+	UUID     uuid.UUID
+	UserUUID uuid.UUID
+	// UserRole is the role of the user at login
+	UserRole  Role
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the session is no longer valid at now
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+// UserRepository stores registered users
+type UserRepository interface {
+	// Create returns model.ErrUserAlreadyExists when the login or email is taken
+	Create(ctx context.Context, user *model.User) error
+	// Get returns model.ErrUserNotFound for unknown users
+	Get(ctx context.Context, userUUID uuid.UUID) (*model.User, error)
+	// GetByLogin returns model.ErrUserNotFound for unknown logins
+	GetByLogin(ctx context.Context, login string) (*model.User, error)
+}
+
+// SessionRepository stores sessions of logged in users
+type SessionRepository interface {
+	Create(ctx context.Context, session *model.Session) error
+	// Get returns model.ErrSessionNotFound for unknown sessions
+	Get(ctx context.Context, sessionUUID uuid.UUID) (*model.Session, error)
+	Delete(ctx context.Context, sessionUUID uuid.UUID) error
+}
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+// MemorySessionRepository implements SessionRepository using in-memory storage
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]*model.Session
+}
+
+// NewMemorySessionRepository creates a new in-memory session repository
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: make(map[uuid.UUID]*model.Session),
+	}
+}
+
+// Create stores a new session
+func (r *MemorySessionRepository) Create(ctx context.Context, session *model.Session) error {
+	if session == nil {
+		return fmt.Errorf("session cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessionCopy := *session
+	r.sessions[session.UUID] = &sessionCopy
+
+	return nil
+}
+
+// Get returns the session with sessionUUID, expired or not
+func (r *MemorySessionRepository) Get(ctx context.Context, sessionUUID uuid.UUID) (*model.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionUUID]
+	if !ok {
+		return nil, fmt.Errorf("session %s: %w", sessionUUID, model.ErrSessionNotFound)
+	}
+
+	sessionCopy := *session
+	return &sessionCopy, nil
+}
+
+// Delete removes the session; deleting an unknown session is not an error
+func (r *MemorySessionRepository) Delete(ctx context.Context, sessionUUID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, sessionUUID)
+
+	return nil
+}
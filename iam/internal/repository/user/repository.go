@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+// MemoryUserRepository implements UserRepository using in-memory storage
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]*model.User
+	// byLogin and byEmail index users by lowercased login and email
+	byLogin map[string]uuid.UUID
+	byEmail map[string]uuid.UUID
+}
+
+// NewMemoryUserRepository creates a new in-memory user repository
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		users:   make(map[uuid.UUID]*model.User),
+		byLogin: make(map[string]uuid.UUID),
+		byEmail: make(map[string]uuid.UUID),
+	}
+}
+
+// Create stores a new user; logins and emails are unique case-insensitively
+func (r *MemoryUserRepository) Create(ctx context.Context, user *model.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil")
+	}
+
+	login := strings.ToLower(user.Login)
+	email := strings.ToLower(user.Email)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byLogin[login]; ok {
+		return fmt.Errorf("login %s: %w", user.Login, model.ErrUserAlreadyExists)
+	}
+	if _, ok := r.byEmail[email]; ok {
+		return fmt.Errorf("email %s: %w", user.Email, model.ErrUserAlreadyExists)
+	}
+
+	// Store a copy to avoid external modifications
+	userCopy := *user
+	r.users[user.UUID] = &userCopy
+	r.byLogin[login] = user.UUID
+	r.byEmail[email] = user.UUID
+
+	return nil
+}
+
+// Get returns the user with userUUID
+func (r *MemoryUserRepository) Get(ctx context.Context, userUUID uuid.UUID) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[userUUID]
+	if !ok {
+		return nil, fmt.Errorf("user with UUID %s: %w", userUUID, model.ErrUserNotFound)
+	}
+
+	userCopy := *user
+	return &userCopy, nil
+}
+
+// GetByLogin returns the user with login, ignoring case
+func (r *MemoryUserRepository) GetByLogin(ctx context.Context, login string) (*model.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userUUID, ok := r.byLogin[strings.ToLower(login)]
+	if !ok {
+		return nil, fmt.Errorf("user with login %s: %w", login, model.ErrUserNotFound)
+	}
+
+	userCopy := *r.users[userUUID]
+	return &userCopy, nil
+}
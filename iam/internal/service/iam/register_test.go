@@ -0,0 +1,99 @@
+package iam
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+func (s *IAMServiceTestSuite) TestRegister_Success() {
+	user, err := s.service.Register(context.Background(), &model.RegisterRequest{
+		Login:    "gagarin",
+		Email:    "gagarin@example.com",
+		Password: "vostok-1961",
+	})
+
+	s.Require().NoError(err)
+	s.Equal("gagarin", user.Login)
+	s.Equal(model.RoleUser, user.Role)
+	s.Equal(s.now, user.CreatedAt)
+	s.NotEqual([]byte("vostok-1961"), user.PasswordHash)
+	s.NoError(bcrypt.CompareHashAndPassword(user.PasswordHash, []byte("vostok-1961")))
+
+	stored, err := s.service.GetUser(context.Background(), user.UUID)
+	s.Require().NoError(err)
+	s.Equal("gagarin@example.com", stored.Email)
+}
+
+func (s *IAMServiceTestSuite) TestRegister_NeverGrantsAdmin() {
+	// A login an operator might pick for themselves gives no privileges
+	user, err := s.service.Register(context.Background(), &model.RegisterRequest{
+		Login:    "admin",
+		Email:    "admin@example.com",
+		Password: "sputnik-1957",
+	})
+
+	s.Require().NoError(err)
+	s.Equal(model.RoleUser, user.Role)
+
+	session, err := s.service.Login(context.Background(), "admin", "sputnik-1957")
+	s.Require().NoError(err)
+	s.Equal(model.RoleUser, session.UserRole)
+}
+
+func (s *IAMServiceTestSuite) TestLogin_AdminGrantedByOperator() {
+	user, err := s.service.Register(context.Background(), &model.RegisterRequest{
+		Login:    "korolev",
+		Email:    "korolev@example.com",
+		Password: "sputnik-1957",
+	})
+	s.Require().NoError(err)
+
+	// The operator adds the UUID of the registered user to the admins
+	service := s.newService(user.UUID)
+
+	session, err := service.Login(context.Background(), "korolev", "sputnik-1957")
+	s.Require().NoError(err)
+	s.Equal(model.RoleAdmin, session.UserRole)
+
+	stored, err := service.GetUser(context.Background(), user.UUID)
+	s.Require().NoError(err)
+	s.Equal(model.RoleAdmin, stored.Role)
+}
+
+func (s *IAMServiceTestSuite) TestRegister_DuplicateLogin() {
+	req := &model.RegisterRequest{Login: "gagarin", Email: "gagarin@example.com", Password: "vostok-1961"}
+	_, err := s.service.Register(context.Background(), req)
+	s.Require().NoError(err)
+
+	_, err = s.service.Register(context.Background(), &model.RegisterRequest{Login: "Gagarin", Email: "other@example.com", Password: "vostok-1961"})
+
+	s.requireCode(err, model.ErrCodeUserAlreadyExists)
+}
+
+func (s *IAMServiceTestSuite) TestRegister_Validation() {
+	tests := []struct {
+		name string
+		req  *model.RegisterRequest
+	}{
+		{"short login", &model.RegisterRequest{Login: "yu", Email: "yu@example.com", Password: "vostok-1961"}},
+		{"invalid email", &model.RegisterRequest{Login: "gagarin", Email: "gagarin", Password: "vostok-1961"}},
+		{"short password", &model.RegisterRequest{Login: "gagarin", Email: "gagarin@example.com", Password: "short"}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			_, err := s.service.Register(context.Background(), tt.req)
+			s.requireCode(err, model.ErrCodeValidationError)
+		})
+	}
+}
+
+func (s *IAMServiceTestSuite) requireCode(err error, code string) {
+	var serviceErr *model.ServiceError
+	s.Require().True(errors.As(err, &serviceErr), "expected ServiceError, got %v", err)
+	s.Equal(code, serviceErr.Code)
+}
@@ -0,0 +1,181 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+	"github.com/nimbodex/microservices-factory/iam/internal/repository"
+)
+
+const (
+	minLoginLength    = 3
+	maxLoginLength    = 64
+	minPasswordLength = 8
+	// maxPasswordLength is the longest input bcrypt accepts
+	maxPasswordLength = 72
+)
+
+// dummyHash is compared against when the login is unknown, so that a failed
+// login takes the same time whether or not the user exists
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// IAMServiceImpl implements IAMService interface
+type IAMServiceImpl struct {
+	userRepo    repository.UserRepository
+	sessionRepo repository.SessionRepository
+	sessionTTL  time.Duration
+	// adminUUIDs are users with the admin role, granted by the operator
+	adminUUIDs map[uuid.UUID]struct{}
+	hashCost   int
+	now        func() time.Time
+}
+
+// NewIAMService creates a new IAM service whose sessions expire after
+// sessionTTL. Users with one of adminUUIDs have the admin role; everyone
+// else, including every newly registered user, has the user role.
+func NewIAMService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, sessionTTL time.Duration, adminUUIDs []uuid.UUID) *IAMServiceImpl {
+	admins := make(map[uuid.UUID]struct{}, len(adminUUIDs))
+	for _, userUUID := range adminUUIDs {
+		admins[userUUID] = struct{}{}
+	}
+
+	return &IAMServiceImpl{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		sessionTTL:  sessionTTL,
+		adminUUIDs:  admins,
+		hashCost:    bcrypt.DefaultCost,
+		now:         time.Now,
+	}
+}
+
+// Register creates a user with a bcrypt hash of the password
+func (s *IAMServiceImpl) Register(ctx context.Context, req *model.RegisterRequest) (*model.User, error) {
+	if err := validateRegisterRequest(req); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), s.hashCost)
+	if err != nil {
+		return nil, model.NewInternalError(fmt.Errorf("hash password: %w", err))
+	}
+
+	user := &model.User{
+		UUID:         uuid.New(),
+		Login:        req.Login,
+		Email:        req.Email,
+		PasswordHash: hash,
+		Role:         model.RoleUser,
+		CreatedAt:    s.now(),
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, model.ErrUserAlreadyExists) {
+			return nil, model.NewUserAlreadyExistsError(req.Login)
+		}
+		return nil, model.NewInternalError(err)
+	}
+
+	return user, nil
+}
+
+// Login opens a session of the user with login when the password matches
+func (s *IAMServiceImpl) Login(ctx context.Context, login, password string) (*model.Session, error) {
+	user, err := s.userRepo.GetByLogin(ctx, login)
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.NewInternalError(err)
+		}
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, model.NewInvalidCredentialsError()
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password)); err != nil {
+		return nil, model.NewInvalidCredentialsError()
+	}
+
+	now := s.now()
+	session := &model.Session{
+		UUID:      uuid.New(),
+		UserUUID:  user.UUID,
+		UserRole:  s.role(user),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, model.NewInternalError(err)
+	}
+
+	return session, nil
+}
+
+// ValidateSession returns the session unless it is unknown or expired.
+// Expired sessions are deleted when they are first seen.
+func (s *IAMServiceImpl) ValidateSession(ctx context.Context, sessionUUID uuid.UUID) (*model.Session, error) {
+	session, err := s.sessionRepo.Get(ctx, sessionUUID)
+	if err != nil {
+		if errors.Is(err, model.ErrSessionNotFound) {
+			return nil, model.NewInvalidSessionError()
+		}
+		return nil, model.NewInternalError(err)
+	}
+
+	if session.Expired(s.now()) {
+		if err := s.sessionRepo.Delete(ctx, sessionUUID); err != nil {
+			return nil, model.NewInternalError(err)
+		}
+		return nil, model.NewInvalidSessionError()
+	}
+
+	return session, nil
+}
+
+// GetUser returns the user with userUUID
+func (s *IAMServiceImpl) GetUser(ctx context.Context, userUUID uuid.UUID) (*model.User, error) {
+	user, err := s.userRepo.Get(ctx, userUUID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.NewUserNotFoundError(userUUID.String())
+		}
+		return nil, model.NewInternalError(err)
+	}
+	user.Role = s.role(user)
+
+	return user, nil
+}
+
+// role returns the role of user, which is admin when the operator granted it
+func (s *IAMServiceImpl) role(user *model.User) model.Role {
+	if _, ok := s.adminUUIDs[user.UUID]; ok {
+		return model.RoleAdmin
+	}
+	return user.Role
+}
+
+func validateRegisterRequest(req *model.RegisterRequest) error {
+	if req == nil {
+		return model.NewValidationError("request cannot be nil")
+	}
+
+	if n := utf8.RuneCountInString(req.Login); n < minLoginLength || n > maxLoginLength {
+		return model.NewValidationError(fmt.Sprintf("login must be %d to %d characters long", minLoginLength, maxLoginLength))
+	}
+
+	addr, err := mail.ParseAddress(req.Email)
+	if err != nil || addr.Address != req.Email {
+		return model.NewValidationError("email is invalid")
+	}
+
+	if n := len(req.Password); n < minPasswordLength || n > maxPasswordLength {
+		return model.NewValidationError(fmt.Sprintf("password must be %d to %d bytes long", minPasswordLength, maxPasswordLength))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package iam
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+func (s *IAMServiceTestSuite) register() *model.User {
+	user, err := s.service.Register(context.Background(), &model.RegisterRequest{
+		Login:    "tereshkova",
+		Email:    "tereshkova@example.com",
+		Password: "vostok-1963",
+	})
+	s.Require().NoError(err)
+
+	return user
+}
+
+func (s *IAMServiceTestSuite) TestLogin_Success() {
+	user := s.register()
+
+	session, err := s.service.Login(context.Background(), "tereshkova", "vostok-1963")
+
+	s.Require().NoError(err)
+	s.Equal(user.UUID, session.UserUUID)
+	s.Equal(s.now.Add(time.Hour), session.ExpiresAt)
+}
+
+func (s *IAMServiceTestSuite) TestLogin_WrongPassword() {
+	s.register()
+
+	_, err := s.service.Login(context.Background(), "tereshkova", "wrong-password")
+
+	s.requireCode(err, model.ErrCodeInvalidCredentials)
+}
+
+func (s *IAMServiceTestSuite) TestLogin_UnknownLogin() {
+	_, err := s.service.Login(context.Background(), "nobody", "vostok-1963")
+
+	s.requireCode(err, model.ErrCodeInvalidCredentials)
+}
+
+func (s *IAMServiceTestSuite) TestValidateSession_Active() {
+	user := s.register()
+	session, err := s.service.Login(context.Background(), "tereshkova", "vostok-1963")
+	s.Require().NoError(err)
+
+	s.now = s.now.Add(59 * time.Minute)
+	validated, err := s.service.ValidateSession(context.Background(), session.UUID)
+
+	s.Require().NoError(err)
+	s.Equal(user.UUID, validated.UserUUID)
+}
+
+func (s *IAMServiceTestSuite) TestValidateSession_Expired() {
+	s.register()
+	session, err := s.service.Login(context.Background(), "tereshkova", "vostok-1963")
+	s.Require().NoError(err)
+
+	s.now = s.now.Add(time.Hour)
+	_, err = s.service.ValidateSession(context.Background(), session.UUID)
+	s.requireCode(err, model.ErrCodeInvalidSession)
+
+	// The expired session is gone even if the clock goes back
+	s.now = s.now.Add(-time.Hour)
+	_, err = s.service.ValidateSession(context.Background(), session.UUID)
+	s.requireCode(err, model.ErrCodeInvalidSession)
+}
+
+func (s *IAMServiceTestSuite) TestValidateSession_Unknown() {
+	_, err := s.service.ValidateSession(context.Background(), uuid.New())
+
+	s.requireCode(err, model.ErrCodeInvalidSession)
+}
+
+func (s *IAMServiceTestSuite) TestGetUser_NotFound() {
+	_, err := s.service.GetUser(context.Background(), uuid.New())
+
+	s.requireCode(err, model.ErrCodeUserNotFound)
+}
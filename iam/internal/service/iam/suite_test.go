@@ -0,0 +1,43 @@
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/repository/session"
+	"github.com/nimbodex/microservices-factory/iam/internal/repository/user"
+)
+
+type IAMServiceTestSuite struct {
+	suite.Suite
+
+	service     *IAMServiceImpl
+	userRepo    *user.MemoryUserRepository
+	sessionRepo *session.MemorySessionRepository
+	now         time.Time
+}
+
+func (s *IAMServiceTestSuite) SetupTest() {
+	s.now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	s.userRepo = user.NewMemoryUserRepository()
+	s.sessionRepo = session.NewMemorySessionRepository()
+	s.service = s.newService()
+}
+
+// newService returns a service over the suite repositories with adminUUIDs as admins
+func (s *IAMServiceTestSuite) newService(adminUUIDs ...uuid.UUID) *IAMServiceImpl {
+	service := NewIAMService(s.userRepo, s.sessionRepo, time.Hour, adminUUIDs)
+	// The cheapest cost keeps the tests fast
+	service.hashCost = bcrypt.MinCost
+	service.now = func() time.Time { return s.now }
+	return service
+}
+
+func TestIAMServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IAMServiceTestSuite))
+}
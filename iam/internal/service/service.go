@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/iam/internal/model"
+)
+
+// IAMService defines the interface for IAM service operations
+type IAMService interface {
+	Register(ctx context.Context, req *model.RegisterRequest) (*model.User, error)
+	// Login checks the credentials and opens a new session
+	Login(ctx context.Context, login, password string) (*model.Session, error)
+	// ValidateSession returns the session if it exists and has not expired
+	ValidateSession(ctx context.Context, sessionUUID uuid.UUID) (*model.Session, error)
+	GetUser(ctx context.Context, userUUID uuid.UUID) (*model.User, error)
+}
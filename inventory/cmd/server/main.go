@@ -1,34 +1,50 @@
 package main
 
 import (
-	"log"
-	"net"
+	"context"
+	"fmt"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	v1 "github.com/nimbodex/microservices-factory/inventory/internal/api/inventory/v1"
+	"github.com/nimbodex/microservices-factory/inventory/internal/config"
+	stockmetrics "github.com/nimbodex/microservices-factory/inventory/internal/metrics"
 	"github.com/nimbodex/microservices-factory/inventory/internal/repository/part"
+	repotracing "github.com/nimbodex/microservices-factory/inventory/internal/repository/tracing"
 	inventoryservice "github.com/nimbodex/microservices-factory/inventory/internal/service/inventory"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/metrics"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
 	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
 )
 
-const (
-	port = "localhost:50051"
-)
-
 func main() {
-	log.Println("Starting Inventory Service...")
+	cfg := config.Default()
+	app.Run("inventory", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
 
-	lis, err := net.Listen("tcp", port)
+func setup(ctx context.Context, a *app.App, cfg *config.Config) error {
+	shutdownTracing, err := tracing.Init(ctx, "inventory", cfg.Tracing)
 	if err != nil {
-		log.Fatalf("Failed to listen on port %s: %v", port, err)
+		return fmt.Errorf("init tracing: %w", err)
 	}
+	// Registered first so that spans of the last requests are flushed after everything else is closed
+	a.OnShutdown("tracer provider", shutdownTracing)
 
-	grpcServer := grpc.NewServer()
+	reg := metrics.NewRegistry()
+	grpcServer := grpc.NewServer(interceptor.ServerOptions(a.Logger(), reg)...)
 
 	// Initialize repository
-	partRepo := part.NewMemoryPartRepository()
+	memoryRepo := part.NewMemoryPartRepository()
+	partRepo := repotracing.NewPartRepository(memoryRepo)
+
+	// Stock levels are read at scrape time without recording spans
+	reg.MustRegister(stockmetrics.NewStockCollector(memoryRepo))
 
 	// Initialize service layer
 	inventoryService := inventoryservice.NewInventoryService(partRepo)
@@ -40,13 +56,18 @@ func main() {
 
 	reflection.Register(grpcServer)
 
-	log.Printf("Inventory Service listening on %s", port)
-	log.Println("Available methods:")
-	log.Println("\t - GetPart: getting a detail by UUID")
-	log.Println("\t - ListParts: getting parts list with filtering")
-	log.Println("For testing use grpcurl or any gRPC client")
+	// Probes see NOT_SERVING while in-flight calls finish on shutdown
+	healthServer := health.RegisterGRPC(grpcServer)
+	a.BeforeStop(healthServer.Shutdown)
 
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve gRPC server: %v", err)
-	}
+	a.Go("grpc", app.GRPCServer(cfg.GRPC.Addr, grpcServer))
+	a.Go("admin", app.HTTPServer(metrics.NewAdminServer(cfg.Admin.Addr, reg)))
+
+	a.Logger().Info("Inventory Service listening",
+		"addr", cfg.GRPC.Addr,
+		"admin_addr", cfg.Admin.Addr,
+		"methods", []string{"GetPart", "ListParts", "ReserveParts", "ReleaseReservation", "CommitReservation", "ReturnParts"},
+	)
+
+	return nil
 }
@@ -3,10 +3,25 @@ package v1
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
 	"github.com/nimbodex/microservices-factory/inventory/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
 	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
 )
 
+// errorMapper sends service errors with the status codes callers can act on
+var errorMapper = grpcerr.NewMapper("inventory", map[string]codes.Code{
+	model.ErrCodeInvalidUUID:          codes.InvalidArgument,
+	model.ErrCodeInvalidFilter:        codes.InvalidArgument,
+	model.ErrCodeValidationError:      codes.InvalidArgument,
+	model.ErrCodePartNotFound:         codes.NotFound,
+	model.ErrCodeReservationNotFound:  codes.NotFound,
+	model.ErrCodeReservationCommitted: codes.FailedPrecondition,
+	model.ErrCodeInternalError:        codes.Internal,
+})
+
 // APIHandler handles gRPC requests for inventory API
 type APIHandler struct {
 	inventoryv1.UnimplementedInventoryServiceServer
@@ -22,10 +37,60 @@ func NewAPIHandler(inventoryService service.InventoryService) *APIHandler {
 
 // GetPart handles GetPart gRPC requests
 func (h *APIHandler) GetPart(ctx context.Context, req *inventoryv1.GetPartRequest) (*inventoryv1.GetPartResponse, error) {
-	return h.inventoryService.GetPart(ctx, req)
+	resp, err := h.inventoryService.GetPart(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
 }
 
 // ListParts handles ListParts gRPC requests
 func (h *APIHandler) ListParts(ctx context.Context, req *inventoryv1.ListPartsRequest) (*inventoryv1.ListPartsResponse, error) {
-	return h.inventoryService.ListParts(ctx, req)
+	resp, err := h.inventoryService.ListParts(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// ReserveParts handles ReserveParts gRPC requests
+func (h *APIHandler) ReserveParts(ctx context.Context, req *inventoryv1.ReservePartsRequest) (*inventoryv1.ReservePartsResponse, error) {
+	resp, err := h.inventoryService.ReserveParts(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// ReleaseReservation handles ReleaseReservation gRPC requests
+func (h *APIHandler) ReleaseReservation(ctx context.Context, req *inventoryv1.ReleaseReservationRequest) (*inventoryv1.ReleaseReservationResponse, error) {
+	resp, err := h.inventoryService.ReleaseReservation(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// CommitReservation handles CommitReservation gRPC requests
+func (h *APIHandler) CommitReservation(ctx context.Context, req *inventoryv1.CommitReservationRequest) (*inventoryv1.CommitReservationResponse, error) {
+	resp, err := h.inventoryService.CommitReservation(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// ReturnParts handles ReturnParts gRPC requests
+func (h *APIHandler) ReturnParts(ctx context.Context, req *inventoryv1.ReturnPartsRequest) (*inventoryv1.ReturnPartsResponse, error) {
+	resp, err := h.inventoryService.ReturnParts(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
 }
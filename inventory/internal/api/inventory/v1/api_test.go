@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/inventory/internal/repository/mocks"
+	inventoryservice "github.com/nimbodex/microservices-factory/inventory/internal/service/inventory"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
+	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
+)
+
+func TestAPIHandler_ReserveParts_InvalidQuantity(t *testing.T) {
+	handler := NewAPIHandler(inventoryservice.NewInventoryService(repomocks.NewPartRepository(t)))
+
+	_, err := handler.ReserveParts(context.Background(), &inventoryv1.ReservePartsRequest{
+		OrderUuid: uuid.NewString(),
+		Items:     []*inventoryv1.ReservationItem{{PartUuid: uuid.NewString(), Quantity: 0}},
+	})
+
+	details := grpcerr.Decode(err)
+	assert.Equal(t, codes.InvalidArgument, details.Code)
+	assert.Equal(t, model.ErrCodeValidationError, details.Reason)
+	assert.Equal(t, "inventory", details.Domain)
+	assert.Equal(t, []grpcerr.FieldViolation{{Field: "items[0].quantity", Description: "quantity must be positive"}}, details.FieldViolations)
+}
+
+func TestAPIHandler_ErrorCodes(t *testing.T) {
+	orderUUID := uuid.New()
+
+	tests := []struct {
+		repoErr    error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{repoErr: model.ErrReservationNotFound, wantCode: codes.NotFound, wantReason: model.ErrCodeReservationNotFound},
+		{repoErr: assert.AnError, wantCode: codes.Internal, wantReason: model.ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantCode.String(), func(t *testing.T) {
+			partRepo := repomocks.NewPartRepository(t)
+			partRepo.On("CommitReservation", mock.Anything, orderUUID).Return(tt.repoErr)
+
+			_, err := NewAPIHandler(inventoryservice.NewInventoryService(partRepo)).CommitReservation(context.Background(), &inventoryv1.CommitReservationRequest{OrderUuid: orderUUID.String()})
+
+			details := grpcerr.Decode(err)
+			assert.Equal(t, tt.wantCode, details.Code)
+			assert.Equal(t, tt.wantReason, details.Reason)
+		})
+	}
+}
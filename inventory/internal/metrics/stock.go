@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	"github.com/nimbodex/microservices-factory/inventory/internal/repository"
+)
+
+// collectTimeout bounds reading stock levels during a scrape
+const collectTimeout = 5 * time.Second
+
+var stockDesc = prometheus.NewDesc(
+	"inventory_part_stock",
+	"Units of a part in stock and not reserved.",
+	[]string{"part_uuid", "part_name"}, nil,
+)
+
+// StockCollector reports the stock level of every part when metrics are
+// scraped, so the levels are never stale and no bookkeeping is needed on
+// stock changes
+type StockCollector struct {
+	partRepo repository.PartRepository
+}
+
+// NewStockCollector creates a collector reading stock levels from partRepo
+func NewStockCollector(partRepo repository.PartRepository) *StockCollector {
+	return &StockCollector{partRepo: partRepo}
+}
+
+// Describe implements prometheus.Collector
+func (c *StockCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- stockDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *StockCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	parts, err := c.partRepo.List(ctx, &model.PartsFilter{})
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(stockDesc, err)
+		return
+	}
+
+	for _, part := range parts {
+		ch <- prometheus.MustNewConstMetric(stockDesc, prometheus.GaugeValue, float64(part.StockQuantity), part.UUID.String(), part.Name)
+	}
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/inventory/internal/repository/mocks"
+)
+
+func TestStockCollector_ReportsStockOfEveryPart(t *testing.T) {
+	engineUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	wingUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+
+	repo := repomocks.NewPartRepository(t)
+	repo.On("List", mock.Anything, &model.PartsFilter{}).Return([]*model.Part{
+		{UUID: engineUUID, Name: "Engine", StockQuantity: 5},
+		{UUID: wingUUID, Name: "Wing", StockQuantity: 0},
+	}, nil)
+
+	want := `
+# HELP inventory_part_stock Units of a part in stock and not reserved.
+# TYPE inventory_part_stock gauge
+inventory_part_stock{part_name="Engine",part_uuid="550e8400-e29b-41d4-a716-446655440001"} 5
+inventory_part_stock{part_name="Wing",part_uuid="550e8400-e29b-41d4-a716-446655440002"} 0
+`
+	require.NoError(t, testutil.CollectAndCompare(NewStockCollector(repo), strings.NewReader(want)))
+}
+
+func TestStockCollector_ReportsListFailure(t *testing.T) {
+	repo := repomocks.NewPartRepository(t)
+	repo.On("List", mock.Anything, mock.Anything).Return(nil, errors.New("storage unavailable"))
+
+	_, err := testutil.CollectAndLint(NewStockCollector(repo))
+	require.ErrorContains(t, err, "storage unavailable")
+}
@@ -1,12 +1,24 @@
 package model
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Repository errors
+var (
+	ErrPartNotFound         = errors.New("part not found")
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationCommitted = errors.New("reservation already committed")
+)
 
 // ServiceError represents a service layer error
 type ServiceError struct {
 	Code    string
 	Message string
-	Err     error
+	// Field is the request field that caused the error, if any
+	Field string
+	Err   error
 }
 
 func (e *ServiceError) Error() string {
@@ -16,13 +28,24 @@ func (e *ServiceError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode, ErrorMessage and ErrorField let errors be sent over gRPC with details
+func (e *ServiceError) ErrorCode() string    { return e.Code }
+func (e *ServiceError) ErrorMessage() string { return e.Message }
+func (e *ServiceError) ErrorField() string   { return e.Field }
+
 // Common service error codes
 const (
-	ErrCodePartNotFound    = "PART_NOT_FOUND"
-	ErrCodeInvalidUUID     = "INVALID_UUID"
-	ErrCodeInvalidFilter   = "INVALID_FILTER"
-	ErrCodeInternalError   = "INTERNAL_ERROR"
-	ErrCodeValidationError = "VALIDATION_ERROR"
+	ErrCodePartNotFound         = "PART_NOT_FOUND"
+	ErrCodeInvalidUUID          = "INVALID_UUID"
+	ErrCodeInvalidFilter        = "INVALID_FILTER"
+	ErrCodeInternalError        = "INTERNAL_ERROR"
+	ErrCodeValidationError      = "VALIDATION_ERROR"
+	ErrCodeReservationNotFound  = "RESERVATION_NOT_FOUND"
+	ErrCodeReservationCommitted = "RESERVATION_COMMITTED"
 )
 
 // Error constructors
@@ -33,10 +56,11 @@ func NewPartNotFoundError(partUUID string) *ServiceError {
 	}
 }
 
-func NewInvalidUUIDError(uuid string) *ServiceError {
+func NewInvalidUUIDError(field, uuid string) *ServiceError {
 	return &ServiceError{
 		Code:    ErrCodeInvalidUUID,
 		Message: fmt.Sprintf("invalid UUID: %s", uuid),
+		Field:   field,
 	}
 }
 
@@ -55,9 +79,24 @@ func NewInternalError(err error) *ServiceError {
 	}
 }
 
-func NewValidationError(message string) *ServiceError {
+func NewValidationError(field, message string) *ServiceError {
 	return &ServiceError{
 		Code:    ErrCodeValidationError,
 		Message: message,
+		Field:   field,
+	}
+}
+
+func NewReservationNotFoundError(orderUUID string) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeReservationNotFound,
+		Message: fmt.Sprintf("reservation for order %s not found or expired", orderUUID),
+	}
+}
+
+func NewReservationCommittedError(orderUUID string) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeReservationCommitted,
+		Message: fmt.Sprintf("reservation for order %s already committed", orderUUID),
 	}
 }
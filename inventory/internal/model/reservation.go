@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reservation holds stock of parts for an order
+type Reservation struct {
+	OrderUUID uuid.UUID `json:"order_uuid"`
+	// Quantities maps part UUIDs to the number of held units
+	Quantities map[uuid.UUID]int32 `json:"quantities"`
+	ExpiresAt  time.Time           `json:"expires_at"`
+	// Committed reservations are sold and no longer expire
+	Committed bool `json:"committed"`
+}
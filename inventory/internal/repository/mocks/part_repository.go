@@ -16,6 +16,24 @@ type PartRepository struct {
 	mock.Mock
 }
 
+// CommitReservation provides a mock function with given fields: ctx, orderUUID
+func (_m *PartRepository) CommitReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ret := _m.Called(ctx, orderUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, part
 func (_m *PartRepository) Create(ctx context.Context, part *model.Part) error {
 	ret := _m.Called(ctx, part)
@@ -112,6 +130,72 @@ func (_m *PartRepository) List(ctx context.Context, filter *model.PartsFilter) (
 	return r0, r1
 }
 
+// ReleaseReservation provides a mock function with given fields: ctx, orderUUID
+func (_m *PartRepository) ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ret := _m.Called(ctx, orderUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveParts provides a mock function with given fields: ctx, reservation
+func (_m *PartRepository) ReserveParts(ctx context.Context, reservation *model.Reservation) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveParts")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reservation) ([]uuid.UUID, error)); ok {
+		return rf(ctx, reservation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reservation) []uuid.UUID); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Reservation) error); ok {
+		r1 = rf(ctx, reservation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnParts provides a mock function with given fields: ctx, orderUUID, quantities
+func (_m *PartRepository) ReturnParts(ctx context.Context, orderUUID uuid.UUID, quantities map[uuid.UUID]int32) error {
+	ret := _m.Called(ctx, orderUUID, quantities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnParts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[uuid.UUID]int32) error); ok {
+		r0 = rf(ctx, orderUUID, quantities)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, part
 func (_m *PartRepository) Update(ctx context.Context, part *model.Part) error {
 	ret := _m.Called(ctx, part)
@@ -13,16 +13,25 @@ import (
 	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
 )
 
-// MemoryPartRepository implements PartRepository using in-memory storage
+// MemoryPartRepository implements PartRepository using in-memory storage.
+// StockQuantity of a part is its free stock: reserving decrements it and
+// releasing or expiring a reservation gives the units back.
 type MemoryPartRepository struct {
-	mu    sync.RWMutex
-	parts map[string]*model.Part
+	mu           sync.RWMutex
+	parts        map[string]*model.Part
+	reservations map[uuid.UUID]*model.Reservation
+	// returned holds orders whose parts were already put back into stock
+	returned map[uuid.UUID]struct{}
+	now      func() time.Time
 }
 
 // NewMemoryPartRepository creates a new in-memory part repository
 func NewMemoryPartRepository() *MemoryPartRepository {
 	repo := &MemoryPartRepository{
-		parts: make(map[string]*model.Part),
+		parts:        make(map[string]*model.Part),
+		reservations: make(map[uuid.UUID]*model.Reservation),
+		returned:     make(map[uuid.UUID]struct{}),
+		now:          time.Now,
 	}
 
 	repo.initSampleData()
@@ -125,6 +134,143 @@ func (r *MemoryPartRepository) Delete(ctx context.Context, uuid uuid.UUID) error
 	return nil
 }
 
+// ReserveParts atomically holds stock for the reservation. On success, or when
+// the order already holds a reservation, reservation.ExpiresAt is set to the
+// expiry of the stored reservation.
+func (r *MemoryPartRepository) ReserveParts(ctx context.Context, reservation *model.Reservation) ([]uuid.UUID, error) {
+	if reservation == nil {
+		return nil, fmt.Errorf("reservation cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expireReservations()
+
+	if existing, exists := r.reservations[reservation.OrderUUID]; exists {
+		reservation.ExpiresAt = existing.ExpiresAt
+		return nil, nil
+	}
+
+	var unavailable []uuid.UUID
+	for partUUID, quantity := range reservation.Quantities {
+		part, exists := r.parts[partUUID.String()]
+		if !exists {
+			return nil, fmt.Errorf("part with UUID %s: %w", partUUID, model.ErrPartNotFound)
+		}
+		if part.StockQuantity < quantity {
+			unavailable = append(unavailable, partUUID)
+		}
+	}
+	if len(unavailable) > 0 {
+		return unavailable, nil
+	}
+
+	now := r.now()
+	for partUUID, quantity := range reservation.Quantities {
+		part := r.parts[partUUID.String()]
+		part.StockQuantity -= quantity
+		part.UpdatedAt = now
+	}
+
+	// Create a copy to avoid external modifications
+	reservationCopy := *reservation
+	reservationCopy.Quantities = make(map[uuid.UUID]int32, len(reservation.Quantities))
+	for partUUID, quantity := range reservation.Quantities {
+		reservationCopy.Quantities[partUUID] = quantity
+	}
+	reservationCopy.Committed = false
+	r.reservations[reservation.OrderUUID] = &reservationCopy
+
+	return nil, nil
+}
+
+// ReleaseReservation returns held stock of the order to the inventory
+func (r *MemoryPartRepository) ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expireReservations()
+
+	reservation, exists := r.reservations[orderUUID]
+	if !exists {
+		return nil
+	}
+	if reservation.Committed {
+		return fmt.Errorf("reservation for order %s: %w", orderUUID, model.ErrReservationCommitted)
+	}
+
+	r.restock(reservation)
+	delete(r.reservations, orderUUID)
+
+	return nil
+}
+
+// CommitReservation marks held stock of the order as sold; committing twice is a no-op
+func (r *MemoryPartRepository) CommitReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expireReservations()
+
+	reservation, exists := r.reservations[orderUUID]
+	if !exists {
+		return fmt.Errorf("reservation for order %s: %w", orderUUID, model.ErrReservationNotFound)
+	}
+
+	reservation.Committed = true
+
+	return nil
+}
+
+// ReturnParts adds units sold to the order back to stock. The units come from
+// the caller, not from the reservation, so a return after the committed
+// reservation has expired restocks the same units; returned keeps it idempotent.
+func (r *MemoryPartRepository) ReturnParts(ctx context.Context, orderUUID uuid.UUID, quantities map[uuid.UUID]int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, done := r.returned[orderUUID]; done {
+		return nil
+	}
+
+	// A committed reservation that has not expired yet is no longer needed
+	delete(r.reservations, orderUUID)
+
+	r.restock(&model.Reservation{OrderUUID: orderUUID, Quantities: quantities})
+	r.returned[orderUUID] = struct{}{}
+
+	return nil
+}
+
+// expireReservations returns stock of expired reservations and forgets
+// committed ones past their expiry: their units are sold, and a later refund
+// restocks them through ReturnParts. Must be called with mu held.
+func (r *MemoryPartRepository) expireReservations() {
+	now := r.now()
+	for orderUUID, reservation := range r.reservations {
+		if now.Before(reservation.ExpiresAt) {
+			continue
+		}
+		if !reservation.Committed {
+			r.restock(reservation)
+		}
+		delete(r.reservations, orderUUID)
+	}
+}
+
+// restock gives the units of a reservation back to free stock. Must be called with mu held.
+func (r *MemoryPartRepository) restock(reservation *model.Reservation) {
+	now := r.now()
+	for partUUID, quantity := range reservation.Quantities {
+		// The part may have been deleted while reserved
+		if part, exists := r.parts[partUUID.String()]; exists {
+			part.StockQuantity += quantity
+			part.UpdatedAt = now
+		}
+	}
+}
+
 func isEmptyFilter(filter *model.PartsFilter) bool {
 	if filter == nil {
 		return true
@@ -0,0 +1,156 @@
+package part
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+)
+
+// unknownComponentUUID is the sample part with a single unit in stock
+var unknownComponentUUID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+
+func stockOf(t *testing.T, repo *MemoryPartRepository, partUUID uuid.UUID) int32 {
+	t.Helper()
+
+	part, err := repo.GetByUUID(context.Background(), partUUID)
+	require.NoError(t, err)
+	return part.StockQuantity
+}
+
+func TestMemoryPartRepository_ReserveReleaseCommit(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPartRepository()
+	expiresAt := time.Now().Add(time.Minute)
+
+	first := &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: expiresAt}
+	unavailable, err := repo.ReserveParts(ctx, first)
+	require.NoError(t, err)
+	require.Empty(t, unavailable)
+	require.Zero(t, stockOf(t, repo, unknownComponentUUID))
+
+	// Reserving again for the same order does not hold more stock
+	unavailable, err = repo.ReserveParts(ctx, &model.Reservation{OrderUUID: first.OrderUUID, Quantities: first.Quantities, ExpiresAt: expiresAt})
+	require.NoError(t, err)
+	require.Empty(t, unavailable)
+
+	second := &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: expiresAt}
+	unavailable, err = repo.ReserveParts(ctx, second)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{unknownComponentUUID}, unavailable)
+
+	require.NoError(t, repo.ReleaseReservation(ctx, first.OrderUUID))
+	require.Equal(t, int32(1), stockOf(t, repo, unknownComponentUUID))
+	require.NoError(t, repo.ReleaseReservation(ctx, first.OrderUUID), "release must be idempotent")
+
+	unavailable, err = repo.ReserveParts(ctx, second)
+	require.NoError(t, err)
+	require.Empty(t, unavailable)
+	require.NoError(t, repo.CommitReservation(ctx, second.OrderUUID))
+	require.NoError(t, repo.CommitReservation(ctx, second.OrderUUID), "commit must be idempotent")
+	require.ErrorIs(t, repo.ReleaseReservation(ctx, second.OrderUUID), model.ErrReservationCommitted)
+	require.Zero(t, stockOf(t, repo, unknownComponentUUID))
+
+	require.ErrorIs(t, repo.CommitReservation(ctx, uuid.New()), model.ErrReservationNotFound)
+
+	_, err = repo.ReserveParts(ctx, &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{uuid.New(): 1}, ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, model.ErrPartNotFound)
+}
+
+func TestMemoryPartRepository_ReservationExpires(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPartRepository()
+	now := time.Now()
+	repo.now = func() time.Time { return now }
+
+	reservation := &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: now.Add(time.Minute)}
+	_, err := repo.ReserveParts(ctx, reservation)
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+
+	// The expired hold is returned before checking stock for the next order
+	unavailable, err := repo.ReserveParts(ctx, &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Empty(t, unavailable)
+	require.ErrorIs(t, repo.CommitReservation(ctx, reservation.OrderUUID), model.ErrReservationNotFound)
+}
+
+func TestMemoryPartRepository_ReturnParts(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPartRepository()
+
+	order := &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: time.Now().Add(time.Minute)}
+	unavailable, err := repo.ReserveParts(ctx, order)
+	require.NoError(t, err)
+	require.Empty(t, unavailable)
+	require.NoError(t, repo.CommitReservation(ctx, order.OrderUUID))
+	require.Zero(t, stockOf(t, repo, unknownComponentUUID))
+
+	require.NoError(t, repo.ReturnParts(ctx, order.OrderUUID, order.Quantities))
+	require.Equal(t, int32(1), stockOf(t, repo, unknownComponentUUID))
+
+	require.NoError(t, repo.ReturnParts(ctx, order.OrderUUID, order.Quantities), "return must be idempotent")
+	require.Equal(t, int32(1), stockOf(t, repo, unknownComponentUUID))
+}
+
+func TestMemoryPartRepository_ReturnPartsAfterCommittedReservationExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPartRepository()
+	now := time.Now()
+	repo.now = func() time.Time { return now }
+
+	order := &model.Reservation{OrderUUID: uuid.New(), Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1}, ExpiresAt: now.Add(time.Minute)}
+	_, err := repo.ReserveParts(ctx, order)
+	require.NoError(t, err)
+	require.NoError(t, repo.CommitReservation(ctx, order.OrderUUID))
+
+	now = now.Add(time.Minute)
+
+	// Expiry forgets the committed reservation without restocking sold units
+	require.NoError(t, repo.ReleaseReservation(ctx, order.OrderUUID))
+	require.Zero(t, stockOf(t, repo, unknownComponentUUID))
+
+	require.NoError(t, repo.ReturnParts(ctx, order.OrderUUID, order.Quantities))
+	require.Equal(t, int32(1), stockOf(t, repo, unknownComponentUUID))
+
+	require.NoError(t, repo.ReturnParts(ctx, order.OrderUUID, order.Quantities), "return must be idempotent")
+	require.Equal(t, int32(1), stockOf(t, repo, unknownComponentUUID))
+}
+
+func TestMemoryPartRepository_ConcurrentReservationsDoNotOversell(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryPartRepository()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unavailable, err := repo.ReserveParts(ctx, &model.Reservation{
+				OrderUUID:  uuid.New(),
+				Quantities: map[uuid.UUID]int32{unknownComponentUUID: 1},
+				ExpiresAt:  time.Now().Add(time.Minute),
+			})
+			require.NoError(t, err)
+			if len(unavailable) == 0 {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, reserved)
+	require.Zero(t, stockOf(t, repo, unknownComponentUUID))
+}
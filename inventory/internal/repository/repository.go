@@ -15,4 +15,15 @@ type PartRepository interface {
 	Create(ctx context.Context, part *model.Part) error
 	Update(ctx context.Context, part *model.Part) error
 	Delete(ctx context.Context, uuid uuid.UUID) error
+	// ReserveParts atomically holds stock for the reservation. If some parts lack
+	// free stock nothing is held and their UUIDs are returned. Reserving again
+	// for the same order returns the existing reservation unchanged.
+	ReserveParts(ctx context.Context, reservation *model.Reservation) ([]uuid.UUID, error)
+	// ReleaseReservation returns held stock; releasing an unknown or expired reservation is a no-op
+	ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error
+	// CommitReservation makes held stock permanently sold
+	CommitReservation(ctx context.Context, orderUUID uuid.UUID) error
+	// ReturnParts adds sold units of the order back to stock; returning parts
+	// of the same order twice is a no-op
+	ReturnParts(ctx context.Context, orderUUID uuid.UUID, quantities map[uuid.UUID]int32) error
 }
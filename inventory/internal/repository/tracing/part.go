@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	"github.com/nimbodex/microservices-factory/inventory/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+const instrumentation = "github.com/nimbodex/microservices-factory/inventory/internal/repository"
+
+// PartRepository wraps a PartRepository and records a span for every operation
+type PartRepository struct {
+	next repository.PartRepository
+}
+
+// NewPartRepository creates a new tracing part repository on top of next
+func NewPartRepository(next repository.PartRepository) *PartRepository {
+	return &PartRepository{next: next}
+}
+
+// GetByUUID retrieves a part by its UUID
+func (r *PartRepository) GetByUUID(ctx context.Context, partUUID uuid.UUID) (*model.Part, error) {
+	ctx, span := start(ctx, "PartRepository.GetByUUID", attribute.String("part_uuid", partUUID.String()))
+	part, err := r.next.GetByUUID(ctx, partUUID)
+	tracing.End(span, err)
+	return part, err
+}
+
+// List retrieves parts matching the filter
+func (r *PartRepository) List(ctx context.Context, filter *model.PartsFilter) ([]*model.Part, error) {
+	ctx, span := start(ctx, "PartRepository.List")
+	parts, err := r.next.List(ctx, filter)
+	span.SetAttributes(attribute.Int("parts", len(parts)))
+	tracing.End(span, err)
+	return parts, err
+}
+
+// Create stores a new part
+func (r *PartRepository) Create(ctx context.Context, part *model.Part) error {
+	ctx, span := start(ctx, "PartRepository.Create", attribute.String("part_uuid", part.UUID.String()))
+	err := r.next.Create(ctx, part)
+	tracing.End(span, err)
+	return err
+}
+
+// Update stores changes of an existing part
+func (r *PartRepository) Update(ctx context.Context, part *model.Part) error {
+	ctx, span := start(ctx, "PartRepository.Update", attribute.String("part_uuid", part.UUID.String()))
+	err := r.next.Update(ctx, part)
+	tracing.End(span, err)
+	return err
+}
+
+// Delete removes a part
+func (r *PartRepository) Delete(ctx context.Context, partUUID uuid.UUID) error {
+	ctx, span := start(ctx, "PartRepository.Delete", attribute.String("part_uuid", partUUID.String()))
+	err := r.next.Delete(ctx, partUUID)
+	tracing.End(span, err)
+	return err
+}
+
+// ReserveParts holds stock for the reservation
+func (r *PartRepository) ReserveParts(ctx context.Context, reservation *model.Reservation) ([]uuid.UUID, error) {
+	ctx, span := start(ctx, "PartRepository.ReserveParts", attribute.String("order_uuid", reservation.OrderUUID.String()))
+	unavailable, err := r.next.ReserveParts(ctx, reservation)
+	span.SetAttributes(attribute.Int("unavailable_parts", len(unavailable)))
+	tracing.End(span, err)
+	return unavailable, err
+}
+
+// ReleaseReservation returns held stock
+func (r *PartRepository) ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ctx, span := start(ctx, "PartRepository.ReleaseReservation", attribute.String("order_uuid", orderUUID.String()))
+	err := r.next.ReleaseReservation(ctx, orderUUID)
+	tracing.End(span, err)
+	return err
+}
+
+// CommitReservation makes held stock permanently sold
+func (r *PartRepository) CommitReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ctx, span := start(ctx, "PartRepository.CommitReservation", attribute.String("order_uuid", orderUUID.String()))
+	err := r.next.CommitReservation(ctx, orderUUID)
+	tracing.End(span, err)
+	return err
+}
+
+// ReturnParts adds sold units of the order back to stock
+func (r *PartRepository) ReturnParts(ctx context.Context, orderUUID uuid.UUID, quantities map[uuid.UUID]int32) error {
+	ctx, span := start(ctx, "PartRepository.ReturnParts", attribute.String("order_uuid", orderUUID.String()))
+	err := r.next.ReturnParts(ctx, orderUUID, quantities)
+	tracing.End(span, err)
+	return err
+}
+
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, instrumentation, name, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/repository/part"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+func TestPartRepository_RecordsSpans(t *testing.T) {
+	provider, exporter := tracing.NewInMemory()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	repo := NewPartRepository(part.NewMemoryPartRepository())
+
+	ctx, parent := tracing.Start(context.Background(), "test", "InventoryService/GetPart")
+	missing := uuid.New()
+	_, err := repo.GetByUUID(ctx, missing)
+	require.Error(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+	require.Equal(t, "PartRepository.GetByUUID", span.Name)
+	require.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	require.Equal(t, codes.Error, span.Status.Code)
+	require.Contains(t, span.Attributes, attribute.String("part_uuid", missing.String()))
+}
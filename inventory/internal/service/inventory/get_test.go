@@ -65,8 +65,8 @@ func (s *InventoryServiceTestSuite) TestGetPart_EmptyUUID() {
 
 	result, err := service.GetPart(ctx, req)
 
-	s.Error(err)
 	s.Nil(result)
+	s.Equal("uuid", s.requireCode(err, model.ErrCodeValidationError).Field)
 
 	mockRepo.AssertExpectations(s.T())
 }
@@ -84,8 +84,8 @@ func (s *InventoryServiceTestSuite) TestGetPart_InvalidUUID() {
 
 	result, err := service.GetPart(ctx, req)
 
-	s.Error(err)
 	s.Nil(result)
+	s.Equal("uuid", s.requireCode(err, model.ErrCodeInvalidUUID).Field)
 
 	mockRepo.AssertExpectations(s.T())
 }
@@ -105,8 +105,8 @@ func (s *InventoryServiceTestSuite) TestGetPart_NotFound() {
 
 	result, err := service.GetPart(ctx, req)
 
-	s.Error(err)
 	s.Nil(result)
+	s.requireCode(err, model.ErrCodePartNotFound)
 
 	mockRepo.AssertExpectations(s.T())
 }
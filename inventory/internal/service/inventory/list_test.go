@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,23 +23,24 @@ func (s *InventoryServiceTestSuite) TestListParts_Success() {
 		},
 	}
 
+	// Listed in reverse, as parts are returned ordered by UUID
 	expectedParts := []*model.Part{
 		{
-			UUID:          uuid.New(),
-			Name:          "Engine Part 1",
-			Description:   "Engine Description 1",
-			Price:         100.0,
-			StockQuantity: 10,
+			UUID:          uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Name:          "Engine Part 2",
+			Description:   "Engine Description 2",
+			Price:         200.0,
+			StockQuantity: 5,
 			Category:      inventoryv1.Category_CATEGORY_ENGINE,
 			CreatedAt:     time.Now(),
 			UpdatedAt:     time.Now(),
 		},
 		{
-			UUID:          uuid.New(),
-			Name:          "Engine Part 2",
-			Description:   "Engine Description 2",
-			Price:         200.0,
-			StockQuantity: 5,
+			UUID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Name:          "Engine Part 1",
+			Description:   "Engine Description 1",
+			Price:         100.0,
+			StockQuantity: 10,
 			Category:      inventoryv1.Category_CATEGORY_ENGINE,
 			CreatedAt:     time.Now(),
 			UpdatedAt:     time.Now(),
@@ -116,8 +118,8 @@ func (s *InventoryServiceTestSuite) TestListParts_RepositoryError() {
 
 	result, err := service.ListParts(ctx, req)
 
-	s.Error(err)
 	s.Nil(result)
+	s.requireCode(err, model.ErrCodeInternalError)
 
 	mockRepo.AssertExpectations(s.T())
 }
@@ -144,3 +146,47 @@ func (s *InventoryServiceTestSuite) TestListParts_EmptyResult() {
 
 	mockRepo.AssertExpectations(s.T())
 }
+
+func (s *InventoryServiceTestSuite) TestListParts_Paginates() {
+	ctx := context.Background()
+
+	parts := make([]*model.Part, 5)
+	for i := range parts {
+		parts[len(parts)-1-i] = &model.Part{UUID: uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", i+1))}
+	}
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(parts, nil)
+
+	service := NewInventoryService(mockRepo)
+
+	tests := []struct {
+		limit, offset int32
+		want          []string
+	}{
+		{limit: 2, offset: 0, want: []string{"1", "2"}},
+		{limit: 2, offset: 4, want: []string{"5"}},
+		{limit: 0, offset: 3, want: []string{"4", "5"}},
+		{limit: 2, offset: 5, want: nil},
+	}
+
+	for _, tt := range tests {
+		result, err := service.ListParts(ctx, &inventoryv1.ListPartsRequest{Limit: tt.limit, Offset: tt.offset})
+		s.Require().NoError(err)
+
+		var got []string
+		for _, part := range result.Parts {
+			got = append(got, part.Uuid[len(part.Uuid)-1:])
+		}
+		s.Equal(tt.want, got, "limit %d offset %d", tt.limit, tt.offset)
+	}
+}
+
+func (s *InventoryServiceTestSuite) TestListParts_NegativeLimit() {
+	service := NewInventoryService(repomocks.NewPartRepository(s.T()))
+
+	result, err := service.ListParts(context.Background(), &inventoryv1.ListPartsRequest{Limit: -1})
+
+	s.Nil(result)
+	s.Equal("limit", s.requireCode(err, model.ErrCodeValidationError).Field)
+}
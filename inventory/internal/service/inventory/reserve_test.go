@@ -0,0 +1,157 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/inventory/internal/repository/mocks"
+	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
+)
+
+func (s *InventoryServiceTestSuite) TestReserveParts_Success() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+	partUUID1 := uuid.New()
+	partUUID2 := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReserveParts", mock.Anything, mock.MatchedBy(func(reservation *model.Reservation) bool {
+		return reservation.OrderUUID == orderUUID &&
+			reservation.Quantities[partUUID1] == 2 &&
+			reservation.Quantities[partUUID2] == 1 &&
+			time.Until(reservation.ExpiresAt) > 4*time.Minute
+	})).Return(nil, nil)
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReserveParts(ctx, &inventoryv1.ReservePartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items: []*inventoryv1.ReservationItem{
+			{PartUuid: partUUID1.String(), Quantity: 2},
+			{PartUuid: partUUID2.String(), Quantity: 1},
+		},
+		Ttl: durationpb.New(5 * time.Minute),
+	})
+
+	s.NoError(err)
+	s.True(result.Reserved)
+	s.Empty(result.UnavailablePartUuids)
+	s.NotNil(result.ExpiresAt)
+}
+
+func (s *InventoryServiceTestSuite) TestReserveParts_InsufficientStock() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+	partUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReserveParts", mock.Anything, mock.Anything).Return([]uuid.UUID{partUUID}, nil)
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReserveParts(ctx, &inventoryv1.ReservePartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items:     []*inventoryv1.ReservationItem{{PartUuid: partUUID.String(), Quantity: 1}},
+	})
+
+	s.NoError(err)
+	s.False(result.Reserved)
+	s.Equal([]string{partUUID.String()}, result.UnavailablePartUuids)
+	s.Nil(result.ExpiresAt)
+}
+
+func (s *InventoryServiceTestSuite) TestReserveParts_PartNotFound() {
+	ctx := context.Background()
+	partUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReserveParts", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("part with UUID %s: %w", partUUID, model.ErrPartNotFound))
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReserveParts(ctx, &inventoryv1.ReservePartsRequest{
+		OrderUuid: uuid.New().String(),
+		Items:     []*inventoryv1.ReservationItem{{PartUuid: partUUID.String(), Quantity: 1}},
+	})
+
+	s.Nil(result)
+	s.requireCode(err, model.ErrCodePartNotFound)
+}
+
+func (s *InventoryServiceTestSuite) TestReserveParts_InvalidRequest() {
+	ctx := context.Background()
+	service := NewInventoryService(repomocks.NewPartRepository(s.T()))
+
+	partUUID := uuid.New().String()
+	tests := []struct {
+		req   *inventoryv1.ReservePartsRequest
+		code  string
+		field string
+	}{
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: "not-a-uuid", Items: []*inventoryv1.ReservationItem{{PartUuid: partUUID, Quantity: 1}}}, code: model.ErrCodeInvalidUUID, field: "order_uuid"},
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: uuid.New().String()}, code: model.ErrCodeValidationError, field: "items"},
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: uuid.New().String(), Items: []*inventoryv1.ReservationItem{{PartUuid: "not-a-uuid", Quantity: 1}}}, code: model.ErrCodeInvalidUUID, field: "items[0].part_uuid"},
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: uuid.New().String(), Items: []*inventoryv1.ReservationItem{{PartUuid: partUUID, Quantity: 0}}}, code: model.ErrCodeValidationError, field: "items[0].quantity"},
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: uuid.New().String(), Items: []*inventoryv1.ReservationItem{{PartUuid: partUUID, Quantity: 1}, {PartUuid: partUUID, Quantity: 2}}}, code: model.ErrCodeValidationError, field: "items[1].part_uuid"},
+		{req: &inventoryv1.ReservePartsRequest{OrderUuid: uuid.New().String(), Items: []*inventoryv1.ReservationItem{{PartUuid: partUUID, Quantity: 1}}, Ttl: durationpb.New(-time.Second)}, code: model.ErrCodeValidationError, field: "ttl"},
+	}
+
+	for _, tt := range tests {
+		result, err := service.ReserveParts(ctx, tt.req)
+		s.Nil(result)
+		s.Equal(tt.field, s.requireCode(err, tt.code).Field)
+	}
+}
+
+func (s *InventoryServiceTestSuite) TestReleaseReservation_Committed() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReleaseReservation", mock.Anything, orderUUID).Return(model.ErrReservationCommitted)
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReleaseReservation(ctx, &inventoryv1.ReleaseReservationRequest{OrderUuid: orderUUID.String()})
+
+	s.Nil(result)
+	s.requireCode(err, model.ErrCodeReservationCommitted)
+}
+
+func (s *InventoryServiceTestSuite) TestCommitReservation_NotFound() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("CommitReservation", mock.Anything, orderUUID).Return(model.ErrReservationNotFound)
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.CommitReservation(ctx, &inventoryv1.CommitReservationRequest{OrderUuid: orderUUID.String()})
+
+	s.Nil(result)
+	s.requireCode(err, model.ErrCodeReservationNotFound)
+}
+
+func (s *InventoryServiceTestSuite) TestCommitReservation_InternalError() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("CommitReservation", mock.Anything, orderUUID).Return(errors.New("storage failure"))
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.CommitReservation(ctx, &inventoryv1.CommitReservationRequest{OrderUuid: orderUUID.String()})
+
+	s.Nil(result)
+	s.requireCode(err, model.ErrCodeInternalError)
+}
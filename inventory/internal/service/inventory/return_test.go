@@ -0,0 +1,71 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/inventory/internal/repository/mocks"
+	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
+)
+
+func (s *InventoryServiceTestSuite) TestReturnParts_Success() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+	partUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReturnParts", mock.Anything, orderUUID, map[uuid.UUID]int32{partUUID: 3}).Return(nil)
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReturnParts(ctx, &inventoryv1.ReturnPartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items:     []*inventoryv1.ReservationItem{{PartUuid: partUUID.String(), Quantity: 3}},
+	})
+
+	s.NoError(err)
+	s.NotNil(result)
+}
+
+func (s *InventoryServiceTestSuite) TestReturnParts_InvalidRequest() {
+	ctx := context.Background()
+	service := NewInventoryService(repomocks.NewPartRepository(s.T()))
+
+	tests := []struct {
+		req   *inventoryv1.ReturnPartsRequest
+		code  string
+		field string
+	}{
+		{req: &inventoryv1.ReturnPartsRequest{OrderUuid: "not-a-uuid", Items: []*inventoryv1.ReservationItem{{PartUuid: uuid.New().String(), Quantity: 1}}}, code: model.ErrCodeInvalidUUID, field: "order_uuid"},
+		{req: &inventoryv1.ReturnPartsRequest{OrderUuid: uuid.New().String()}, code: model.ErrCodeValidationError, field: "items"},
+		{req: &inventoryv1.ReturnPartsRequest{OrderUuid: uuid.New().String(), Items: []*inventoryv1.ReservationItem{{PartUuid: uuid.New().String(), Quantity: -1}}}, code: model.ErrCodeValidationError, field: "items[0].quantity"},
+	}
+
+	for _, tt := range tests {
+		result, err := service.ReturnParts(ctx, tt.req)
+		s.Nil(result)
+		s.Equal(tt.field, s.requireCode(err, tt.code).Field)
+	}
+}
+
+func (s *InventoryServiceTestSuite) TestReturnParts_InternalError() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewPartRepository(s.T())
+	mockRepo.On("ReturnParts", mock.Anything, orderUUID, mock.Anything).Return(errors.New("storage failure"))
+
+	service := NewInventoryService(mockRepo)
+
+	result, err := service.ReturnParts(ctx, &inventoryv1.ReturnPartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items:     []*inventoryv1.ReservationItem{{PartUuid: uuid.New().String(), Quantity: 1}},
+	})
+
+	s.Nil(result)
+	s.requireCode(err, model.ErrCodeInternalError)
+}
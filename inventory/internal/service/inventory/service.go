@@ -2,17 +2,26 @@ package inventory
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nimbodex/microservices-factory/inventory/internal/converter"
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
 	"github.com/nimbodex/microservices-factory/inventory/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
 	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
 )
 
+// DefaultReservationTTL is how long stock is held when the request sets no TTL
+const DefaultReservationTTL = 15 * time.Minute
+
 // InventoryServiceImpl implements InventoryService interface
 type InventoryServiceImpl struct {
 	inventoryv1.UnimplementedInventoryServiceServer
@@ -28,25 +37,26 @@ func NewInventoryService(partRepo repository.PartRepository) *InventoryServiceIm
 
 // GetPart retrieves a part by its UUID from the inventory
 func (s *InventoryServiceImpl) GetPart(ctx context.Context, req *inventoryv1.GetPartRequest) (*inventoryv1.GetPartResponse, error) {
-	log.Printf("GetPart request received for UUID: %s", req.Uuid)
+	log := logger.FromContext(ctx).With(slog.String("part_uuid", req.Uuid))
+	log.Debug("GetPart request received")
 
 	if req.Uuid == "" {
-		return nil, status.Error(codes.InvalidArgument, "UUID cannot be empty")
+		return nil, model.NewValidationError("uuid", "UUID cannot be empty")
 	}
 
 	partUUID, err := uuid.Parse(req.Uuid)
 	if err != nil {
-		log.Printf("Invalid UUID format: %s, error: %v", req.Uuid, err)
-		return nil, status.Error(codes.InvalidArgument, "invalid UUID format")
+		log.Warn("Invalid part UUID format", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("uuid", req.Uuid)
 	}
 
 	part, err := s.partRepo.GetByUUID(ctx, partUUID)
 	if err != nil {
-		log.Printf("Part not found for UUID: %s, error: %v", req.Uuid, err)
-		return nil, status.Error(codes.NotFound, "part not found")
+		log.Warn("Part not found", logger.Err(err))
+		return nil, model.NewPartNotFoundError(req.Uuid)
 	}
 
-	log.Printf("Part found: %s (%s)", part.Name, part.UUID)
+	log.Debug("Part found", slog.String("name", part.Name))
 
 	protoPart := converter.ToProtoPart(part)
 	return &inventoryv1.GetPartResponse{
@@ -56,17 +66,27 @@ func (s *InventoryServiceImpl) GetPart(ctx context.Context, req *inventoryv1.Get
 
 // ListParts retrieves a list of parts matching the provided filter criteria
 func (s *InventoryServiceImpl) ListParts(ctx context.Context, req *inventoryv1.ListPartsRequest) (*inventoryv1.ListPartsResponse, error) {
-	log.Printf("ListParts request received with filter: %+v", req.Filter)
+	log := logger.FromContext(ctx)
+	log.Debug("ListParts request received", slog.Any("filter", req.Filter), slog.Int("limit", int(req.Limit)), slog.Int("offset", int(req.Offset)))
+
+	if req.Limit < 0 {
+		return nil, model.NewValidationError("limit", "limit cannot be negative")
+	}
+	if req.Offset < 0 {
+		return nil, model.NewValidationError("offset", "offset cannot be negative")
+	}
 
 	filter := converter.ToServiceFilter(req.Filter)
 
 	parts, err := s.partRepo.List(ctx, filter)
 	if err != nil {
-		log.Printf("Error retrieving parts: %v", err)
-		return nil, status.Error(codes.Internal, "internal server error")
+		log.Error("Failed to list parts", logger.Err(err))
+		return nil, model.NewInternalError(err)
 	}
 
-	log.Printf("Found %d parts matching the filter", len(parts))
+	parts = page(parts, int(req.Limit), int(req.Offset))
+
+	log.Debug("Parts listed", slog.Int("count", len(parts)))
 
 	protoParts := make([]*inventoryv1.Part, len(parts))
 	for i, part := range parts {
@@ -77,3 +97,184 @@ func (s *InventoryServiceImpl) ListParts(ctx context.Context, req *inventoryv1.L
 		Parts: protoParts,
 	}, nil
 }
+
+// ReserveParts holds stock of the requested parts for an order
+func (s *InventoryServiceImpl) ReserveParts(ctx context.Context, req *inventoryv1.ReservePartsRequest) (*inventoryv1.ReservePartsResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.OrderUuid))
+	log.Debug("ReserveParts request received", slog.Int("items", len(req.Items)))
+
+	orderUUID, err := uuid.Parse(req.OrderUuid)
+	if err != nil {
+		log.Warn("Invalid order UUID format", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.OrderUuid)
+	}
+
+	quantities, err := toQuantities(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := DefaultReservationTTL
+	if req.Ttl != nil {
+		ttl = req.Ttl.AsDuration()
+		if ttl <= 0 {
+			return nil, model.NewValidationError("ttl", "ttl must be positive")
+		}
+	}
+
+	reservation := &model.Reservation{
+		OrderUUID:  orderUUID,
+		Quantities: quantities,
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+
+	unavailable, err := s.partRepo.ReserveParts(ctx, reservation)
+	if err != nil {
+		if errors.Is(err, model.ErrPartNotFound) {
+			log.Warn("Cannot reserve parts", logger.Err(err))
+			return nil, &model.ServiceError{Code: model.ErrCodePartNotFound, Message: "part not found", Err: err}
+		}
+		log.Error("Failed to reserve parts", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
+	if len(unavailable) > 0 {
+		log.Info("Not enough stock", slog.Any("unavailable_part_uuids", unavailable))
+
+		unavailableUUIDs := make([]string, len(unavailable))
+		for i, partUUID := range unavailable {
+			unavailableUUIDs[i] = partUUID.String()
+		}
+
+		return &inventoryv1.ReservePartsResponse{
+			Reserved:             false,
+			UnavailablePartUuids: unavailableUUIDs,
+		}, nil
+	}
+
+	log.Info("Parts reserved", slog.Time("expires_at", reservation.ExpiresAt))
+
+	return &inventoryv1.ReservePartsResponse{
+		Reserved:  true,
+		ExpiresAt: timestamppb.New(reservation.ExpiresAt),
+	}, nil
+}
+
+// ReleaseReservation returns stock held for an order to the inventory
+func (s *InventoryServiceImpl) ReleaseReservation(ctx context.Context, req *inventoryv1.ReleaseReservationRequest) (*inventoryv1.ReleaseReservationResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.OrderUuid))
+	log.Debug("ReleaseReservation request received")
+
+	orderUUID, err := uuid.Parse(req.OrderUuid)
+	if err != nil {
+		log.Warn("Invalid order UUID format", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.OrderUuid)
+	}
+
+	if err := s.partRepo.ReleaseReservation(ctx, orderUUID); err != nil {
+		if errors.Is(err, model.ErrReservationCommitted) {
+			log.Warn("Cannot release reservation", logger.Err(err))
+			return nil, model.NewReservationCommittedError(req.OrderUuid)
+		}
+		log.Error("Failed to release reservation", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
+	log.Info("Reservation released")
+
+	return &inventoryv1.ReleaseReservationResponse{}, nil
+}
+
+// CommitReservation makes stock held for an order permanently sold
+func (s *InventoryServiceImpl) CommitReservation(ctx context.Context, req *inventoryv1.CommitReservationRequest) (*inventoryv1.CommitReservationResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.OrderUuid))
+	log.Debug("CommitReservation request received")
+
+	orderUUID, err := uuid.Parse(req.OrderUuid)
+	if err != nil {
+		log.Warn("Invalid order UUID format", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.OrderUuid)
+	}
+
+	if err := s.partRepo.CommitReservation(ctx, orderUUID); err != nil {
+		if errors.Is(err, model.ErrReservationNotFound) {
+			log.Warn("Cannot commit reservation", logger.Err(err))
+			return nil, model.NewReservationNotFoundError(req.OrderUuid)
+		}
+		log.Error("Failed to commit reservation", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
+	log.Info("Reservation committed")
+
+	return &inventoryv1.CommitReservationResponse{}, nil
+}
+
+// ReturnParts puts units sold to a refunded order back into stock
+func (s *InventoryServiceImpl) ReturnParts(ctx context.Context, req *inventoryv1.ReturnPartsRequest) (*inventoryv1.ReturnPartsResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.OrderUuid))
+	log.Debug("ReturnParts request received", slog.Int("items", len(req.Items)))
+
+	orderUUID, err := uuid.Parse(req.OrderUuid)
+	if err != nil {
+		log.Warn("Invalid order UUID format", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.OrderUuid)
+	}
+
+	quantities, err := toQuantities(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.partRepo.ReturnParts(ctx, orderUUID, quantities); err != nil {
+		log.Error("Failed to return parts", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
+	log.Info("Parts returned to stock")
+
+	return &inventoryv1.ReturnPartsResponse{}, nil
+}
+
+// toQuantities validates reservation items and maps them by part UUID
+func toQuantities(ctx context.Context, items []*inventoryv1.ReservationItem) (map[uuid.UUID]int32, error) {
+	if len(items) == 0 {
+		return nil, model.NewValidationError("items", "items cannot be empty")
+	}
+
+	quantities := make(map[uuid.UUID]int32, len(items))
+	for i, item := range items {
+		partUUID, err := uuid.Parse(item.PartUuid)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Invalid part UUID format", slog.String("part_uuid", item.PartUuid), logger.Err(err))
+			return nil, model.NewInvalidUUIDError(fmt.Sprintf("items[%d].part_uuid", i), item.PartUuid)
+		}
+		if item.Quantity <= 0 {
+			return nil, model.NewValidationError(fmt.Sprintf("items[%d].quantity", i), "quantity must be positive")
+		}
+		if _, duplicate := quantities[partUUID]; duplicate {
+			return nil, model.NewValidationError(fmt.Sprintf("items[%d].part_uuid", i), "each part may appear once")
+		}
+		quantities[partUUID] = item.Quantity
+	}
+
+	return quantities, nil
+}
+
+// page orders parts by UUID and returns limit of them after offset; zero
+// limit returns all the rest
+func page(parts []*model.Part, limit, offset int) []*model.Part {
+	slices.SortFunc(parts, func(a, b *model.Part) int {
+		return strings.Compare(a.UUID.String(), b.UUID.String())
+	})
+
+	if offset >= len(parts) {
+		return nil
+	}
+	parts = parts[offset:]
+	if limit > 0 && limit < len(parts) {
+		parts = parts[:limit]
+	}
+
+	return parts
+}
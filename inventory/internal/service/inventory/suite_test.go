@@ -1,9 +1,12 @@
 package inventory
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/nimbodex/microservices-factory/inventory/internal/model"
 )
 
 type InventoryServiceTestSuite struct {
@@ -13,3 +16,12 @@ type InventoryServiceTestSuite struct {
 func TestInventoryServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(InventoryServiceTestSuite))
 }
+
+// requireCode asserts that err is a ServiceError with code and returns it
+func (s *InventoryServiceTestSuite) requireCode(err error, code string) *model.ServiceError {
+	var serviceErr *model.ServiceError
+	s.Require().True(errors.As(err, &serviceErr), "expected ServiceError, got %v", err)
+	s.Equal(code, serviceErr.Code)
+
+	return serviceErr
+}
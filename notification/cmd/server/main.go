@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+
+	v1 "github.com/nimbodex/microservices-factory/notification/internal/api/notification/v1"
+	"github.com/nimbodex/microservices-factory/notification/internal/channel"
+	"github.com/nimbodex/microservices-factory/notification/internal/channel/recording"
+	"github.com/nimbodex/microservices-factory/notification/internal/channel/smtp"
+	"github.com/nimbodex/microservices-factory/notification/internal/channel/telegram"
+	"github.com/nimbodex/microservices-factory/notification/internal/config"
+	"github.com/nimbodex/microservices-factory/notification/internal/consumer"
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	"github.com/nimbodex/microservices-factory/notification/internal/renderer"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository/delivery"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository/recipient"
+	notificationservice "github.com/nimbodex/microservices-factory/notification/internal/service/notification"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker/kafka"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/metrics"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+	notificationv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/notification/v1"
+)
+
+func main() {
+	cfg := config.Default()
+	app.Run("notification", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
+
+func setup(_ context.Context, a *app.App, cfg *config.Config) error {
+	reg := metrics.NewRegistry()
+
+	channels, err := newChannels(a.Logger(), cfg)
+	if err != nil {
+		return fmt.Errorf("create notification channels: %w", err)
+	}
+
+	messageRenderer, err := renderer.NewRenderer()
+	if err != nil {
+		return fmt.Errorf("load notification templates: %w", err)
+	}
+
+	deliveryRepo := delivery.NewMemoryDeliveryRepository()
+	recipientRepo, err := newRecipientRepository(a, cfg)
+	if err != nil {
+		return fmt.Errorf("create recipient repository: %w", err)
+	}
+
+	notificationService := notificationservice.NewNotificationService(deliveryRepo, recipientRepo, messageRenderer, channels, cfg.Notifications.SendAttempts, cfg.Notifications.SendBackoff)
+
+	subscriber := newSubscriber(a.Logger(), cfg.Events)
+	eventsConsumer := consumer.NewConsumer(notificationService)
+	a.Go("order events consumer", func(ctx context.Context) error {
+		return subscriber.Subscribe(ctx, cfg.Events.OrderTopic, eventsConsumer.Handle)
+	})
+	a.Go("assembly events consumer", func(ctx context.Context) error {
+		return subscriber.Subscribe(ctx, cfg.Events.AssemblyTopic, eventsConsumer.Handle)
+	})
+
+	grpcServer := grpc.NewServer(interceptor.ServerOptions(a.Logger(), reg)...)
+	notificationv1.RegisterNotificationServiceServer(grpcServer, v1.NewAPIHandler(notificationService))
+	reflection.Register(grpcServer)
+
+	// Probes see NOT_SERVING while in-flight calls finish on shutdown
+	healthServer := health.RegisterGRPC(grpcServer)
+	a.BeforeStop(healthServer.Shutdown)
+
+	a.Go("grpc", app.GRPCServer(cfg.GRPC.Addr, grpcServer))
+	a.Go("admin", app.HTTPServer(metrics.NewAdminServer(cfg.Admin.Addr, reg)))
+
+	a.Logger().Info("Notification Service listening",
+		"addr", cfg.GRPC.Addr,
+		"admin_addr", cfg.Admin.Addr,
+		"topics", []string{cfg.Events.OrderTopic, cfg.Events.AssemblyTopic},
+		"methods", []string{"ListDeliveries"},
+	)
+
+	return nil
+}
+
+// newRecipientRepository looks recipients up in IAM, or returns the operator
+// contact for every user when static recipients are configured
+func newRecipientRepository(a *app.App, cfg *config.Config) (repository.RecipientRepository, error) {
+	if cfg.Notifications.Recipients == config.RecipientsStatic {
+		a.Logger().Warn("Every notification goes to the operator contact")
+		return recipient.NewStaticRecipientRepository(model.Recipient{
+			Locale:         cfg.Notifications.Locale,
+			TelegramChatID: cfg.Telegram.ChatID,
+			Email:          cfg.SMTP.To,
+		}), nil
+	}
+
+	opts := append(interceptor.DialOptions(interceptor.CallPolicy{Timeout: cfg.Upstreams.Timeout}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(cfg.Upstreams.IAMAddr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to IAM service: %w", err)
+	}
+	// Runners stop before connections are closed, so lookups of events in flight finish
+	a.CloseOnShutdown("IAM client", conn)
+
+	a.Logger().Info("Notifying users at their IAM contacts", "iam_addr", cfg.Upstreams.IAMAddr)
+	return recipient.NewIAMRecipientRepository(iamv1.NewIAMServiceClient(conn), cfg.Notifications.Locale), nil
+}
+
+// newChannels creates the configured channels. Without any of them messages
+// go to a recording channel and are only kept in the log.
+func newChannels(log *slog.Logger, cfg *config.Config) ([]channel.Channel, error) {
+	var channels []channel.Channel
+
+	if cfg.Telegram.Token != "" {
+		log.Info("Sending notifications via Telegram")
+		channels = append(channels, telegram.NewChannel(cfg.Telegram.APIURL, cfg.Telegram.Token))
+	}
+
+	if cfg.SMTP.Addr != "" {
+		smtpChannel, err := smtp.NewChannel(cfg.SMTP.Addr, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info("Sending notifications via SMTP", "addr", cfg.SMTP.Addr)
+		channels = append(channels, smtpChannel)
+	}
+
+	if len(channels) == 0 {
+		log.Info("Neither Telegram nor SMTP is configured, notifications are only recorded")
+		channels = append(channels, recording.NewChannel())
+	}
+
+	return channels, nil
+}
+
+// newSubscriber creates the Kafka subscriber for the configured brokers, or
+// an in-memory broker when there are none
+func newSubscriber(log *slog.Logger, cfg config.Events) broker.Subscriber {
+	if len(cfg.KafkaBrokers) == 0 {
+		log.Info("Kafka brokers are not set, events are kept in memory")
+		return broker.NewMemoryBroker()
+	}
+
+	log.Info("Using Kafka", "brokers", cfg.KafkaBrokers)
+	return kafka.NewSubscriber(cfg.KafkaBrokers, cfg.ConsumerGroup)
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/converter"
+	"github.com/nimbodex/microservices-factory/notification/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	notificationv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/notification/v1"
+)
+
+// APIHandler handles gRPC requests for notification API
+type APIHandler struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	notificationService service.NotificationService
+}
+
+// NewAPIHandler creates a new API handler
+func NewAPIHandler(notificationService service.NotificationService) *APIHandler {
+	return &APIHandler{
+		notificationService: notificationService,
+	}
+}
+
+// ListDeliveries handles ListDeliveries gRPC requests
+func (h *APIHandler) ListDeliveries(ctx context.Context, req *notificationv1.ListDeliveriesRequest) (*notificationv1.ListDeliveriesResponse, error) {
+	orderUUID, err := uuid.Parse(req.GetOrderUuid())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order UUID format")
+	}
+
+	deliveries, err := h.notificationService.ListDeliveries(ctx, orderUUID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to list deliveries", slog.String("order_uuid", orderUUID.String()), logger.Err(err))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	return converter.ToProtoListDeliveriesResponse(deliveries), nil
+}
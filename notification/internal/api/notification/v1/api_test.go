@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	servicemocks "github.com/nimbodex/microservices-factory/notification/internal/service/mocks"
+	notificationv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/notification/v1"
+)
+
+func TestAPIHandler_ListDeliveries(t *testing.T) {
+	orderUUID := uuid.New()
+	delivery := &model.Delivery{
+		UUID:      uuid.New(),
+		EventID:   uuid.NewString(),
+		EventType: model.EventTypeOrderPaid,
+		OrderUUID: orderUUID,
+		UserUUID:  uuid.New(),
+		Channel:   "telegram",
+		Locale:    "en",
+		Subject:   "Order paid",
+		Status:    model.DeliveryStatusFailed,
+		Attempts:  3,
+		Error:     "bot blocked",
+		CreatedAt: time.Now(),
+	}
+
+	notificationService := servicemocks.NewNotificationService(t)
+	notificationService.On("ListDeliveries", context.Background(), orderUUID).Return([]*model.Delivery{delivery}, nil)
+
+	resp, err := NewAPIHandler(notificationService).ListDeliveries(context.Background(), &notificationv1.ListDeliveriesRequest{OrderUuid: orderUUID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetDeliveries(), 1)
+	got := resp.GetDeliveries()[0]
+	assert.Equal(t, delivery.UUID.String(), got.GetUuid())
+	assert.Equal(t, "OrderPaid", got.GetEventType())
+	assert.Equal(t, notificationv1.DeliveryStatus_DELIVERY_STATUS_FAILED, got.GetStatus())
+	assert.Equal(t, int32(3), got.GetAttempts())
+	assert.Equal(t, "bot blocked", got.GetError())
+}
+
+func TestAPIHandler_ListDeliveriesInvalidUUID(t *testing.T) {
+	notificationService := servicemocks.NewNotificationService(t)
+
+	_, err := NewAPIHandler(notificationService).ListDeliveries(context.Background(), &notificationv1.ListDeliveriesRequest{OrderUuid: "bad"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,16 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// Channel delivers rendered messages to recipients
+type Channel interface {
+	// Name identifies the channel in the delivery log
+	Name() string
+	// Send delivers msg to recipient. It returns model.ErrNoAddress when the
+	// recipient cannot be reached through the channel; such sends are not retried.
+	Send(ctx context.Context, recipient *model.Recipient, msg *model.Message) error
+}
@@ -0,0 +1,71 @@
+package recording
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// Name identifies the channel in the delivery log
+const Name = "recording"
+
+// Sent is a message accepted by the recording channel
+type Sent struct {
+	Recipient model.Recipient
+	Message   model.Message
+}
+
+// Channel keeps sent messages in memory instead of delivering them. It is
+// meant for tests and local runs without real channels.
+type Channel struct {
+	mu       sync.Mutex
+	sent     []Sent
+	failures []error
+}
+
+// NewChannel creates a new recording channel
+func NewChannel() *Channel {
+	return &Channel{}
+}
+
+// Name returns the channel name
+func (c *Channel) Name() string {
+	return Name
+}
+
+// Send records the message, or returns the next queued failure
+func (c *Channel) Send(ctx context.Context, recipient *model.Recipient, msg *model.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.failures) > 0 {
+		err := c.failures[0]
+		c.failures = c.failures[1:]
+		return err
+	}
+
+	c.sent = append(c.sent, Sent{Recipient: *recipient, Message: *msg})
+
+	return nil
+}
+
+// FailNext makes the next sends fail with errs, one error per send
+func (c *Channel) FailNext(errs ...error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures = append(c.failures, errs...)
+}
+
+// Sent returns the recorded messages, oldest first
+func (c *Channel) Sent() []Sent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Sent(nil), c.sent...)
+}
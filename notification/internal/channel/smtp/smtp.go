@@ -0,0 +1,85 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// Name identifies the channel in the delivery log
+const Name = "smtp"
+
+// sendMailFunc matches smtp.SendMail so tests can replace it
+type sendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// Channel sends messages as plain text emails
+type Channel struct {
+	addr     string
+	from     string
+	auth     smtp.Auth
+	sendMail sendMailFunc
+}
+
+// NewChannel creates an SMTP channel sending from the from address through
+// the server at addr (host:port). Empty username disables authentication.
+func NewChannel(addr, username, password, from string) (*Channel, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP address %q: %w", addr, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &Channel{
+		addr:     addr,
+		from:     from,
+		auth:     auth,
+		sendMail: smtp.SendMail,
+	}, nil
+}
+
+// Name returns the channel name
+func (c *Channel) Name() string {
+	return Name
+}
+
+// Send emails the message to the recipient. net/smtp has no context support,
+// so a cancelled ctx only prevents sends that have not started yet.
+func (c *Channel) Send(ctx context.Context, recipient *model.Recipient, msg *model.Message) error {
+	if recipient.Email == "" {
+		return model.ErrNoAddress
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := c.sendMail(c.addr, c.auth, c.from, []string{recipient.Email}, buildEmail(c.from, recipient.Email, msg)); err != nil {
+		return fmt.Errorf("send email to %s: %w", recipient.Email, err)
+	}
+
+	return nil
+}
+
+// buildEmail formats a UTF-8 plain text email
+func buildEmail(from, to string, msg *model.Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
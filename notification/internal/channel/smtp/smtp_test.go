@@ -0,0 +1,64 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+func TestChannel_Send(t *testing.T) {
+	channel, err := NewChannel("mail.example.com:587", "user", "pass", "shop@example.com")
+	require.NoError(t, err)
+
+	var gotTo []string
+	var gotMsg string
+	channel.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "mail.example.com:587", addr)
+		assert.NotNil(t, auth)
+		assert.Equal(t, "shop@example.com", from)
+		gotTo = to
+		gotMsg = string(msg)
+		return nil
+	}
+
+	err = channel.Send(context.Background(), &model.Recipient{Email: "pilot@example.com"}, &model.Message{Subject: "Заказ оплачен", Body: "line 1\nline 2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pilot@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "To: pilot@example.com\r\n")
+	assert.Contains(t, gotMsg, "Subject: =?utf-8?q?")
+	assert.Contains(t, gotMsg, "\r\n\r\nline 1\r\nline 2\r\n")
+}
+
+func TestChannel_SendFailure(t *testing.T) {
+	channel, err := NewChannel("mail.example.com:25", "", "", "shop@example.com")
+	require.NoError(t, err)
+	channel.sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	err = channel.Send(context.Background(), &model.Recipient{Email: "pilot@example.com"}, &model.Message{})
+
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestChannel_SendWithoutEmail(t *testing.T) {
+	channel, err := NewChannel("mail.example.com:25", "", "", "shop@example.com")
+	require.NoError(t, err)
+
+	err = channel.Send(context.Background(), &model.Recipient{}, &model.Message{})
+
+	assert.ErrorIs(t, err, model.ErrNoAddress)
+}
+
+func TestNewChannel_InvalidAddress(t *testing.T) {
+	_, err := NewChannel("mail.example.com", "", "", "shop@example.com")
+
+	assert.Error(t, err)
+}
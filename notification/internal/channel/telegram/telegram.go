@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+const (
+	// Name identifies the channel in the delivery log
+	Name = "telegram"
+
+	// DefaultAPIURL is the Telegram Bot API endpoint
+	DefaultAPIURL = "https://api.telegram.org"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Channel sends messages through the Telegram Bot API sendMessage method
+type Channel struct {
+	apiURL     string
+	token      string
+	httpClient *http.Client
+}
+
+// NewChannel creates a Telegram channel for the bot with token. apiURL is
+// normally DefaultAPIURL.
+func NewChannel(apiURL, token string) *Channel {
+	return &Channel{
+		apiURL:     apiURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Name returns the channel name
+func (c *Channel) Name() string {
+	return Name
+}
+
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type sendMessageResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// Send posts the message to the recipient Telegram chat
+func (c *Channel) Send(ctx context.Context, recipient *model.Recipient, msg *model.Message) error {
+	if recipient.TelegramChatID == "" {
+		return model.ErrNoAddress
+	}
+
+	payload, err := json.Marshal(sendMessageRequest{
+		ChatID: recipient.TelegramChatID,
+		Text:   msg.Subject + "\n\n" + msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("encode telegram request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", c.apiURL, c.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create telegram request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The error includes the URL, which contains the bot token
+		return fmt.Errorf("send telegram message: %w", redactToken(err, c.token))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result sendMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode telegram response with status %d: %w", resp.StatusCode, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram rejected message with status %d: %s", resp.StatusCode, result.Description)
+	}
+
+	return nil
+}
+
+// redactToken removes the bot token from errors of the HTTP client
+func redactToken(err error, token string) error {
+	if token == "" {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), token, "REDACTED"))
+}
@@ -0,0 +1,61 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+func TestChannel_Send(t *testing.T) {
+	var got sendMessageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/botsecret/sendMessage", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	channel := NewChannel(server.URL, "secret")
+	err := channel.Send(context.Background(), &model.Recipient{TelegramChatID: "42"}, &model.Message{Subject: "Order paid", Body: "Thanks"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "42", got.ChatID)
+	assert.Equal(t, "Order paid\n\nThanks", got.Text)
+}
+
+func TestChannel_SendRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	channel := NewChannel(server.URL, "secret")
+	err := channel.Send(context.Background(), &model.Recipient{TelegramChatID: "42"}, &model.Message{})
+
+	assert.ErrorContains(t, err, "chat not found")
+}
+
+func TestChannel_SendWithoutChat(t *testing.T) {
+	channel := NewChannel(DefaultAPIURL, "secret")
+
+	err := channel.Send(context.Background(), &model.Recipient{}, &model.Message{})
+
+	assert.ErrorIs(t, err, model.ErrNoAddress)
+}
+
+func TestChannel_SendErrorHidesToken(t *testing.T) {
+	channel := NewChannel("http://127.0.0.1:1", "secret")
+
+	err := channel.Send(context.Background(), &model.Recipient{TelegramChatID: "42"}, &model.Message{})
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/channel/telegram"
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+)
+
+const (
+	// RecipientsIAM notifies every user at the email IAM keeps for them
+	RecipientsIAM = "iam"
+	// RecipientsStatic sends every notification to the operator contact set
+	// by telegram.chat_id and smtp.to
+	RecipientsStatic = "static"
+)
+
+// Config is the configuration of the notification service
+type Config struct {
+	GRPC          GRPC               `yaml:"grpc"`
+	Admin         Admin              `yaml:"admin"`
+	Upstreams     Upstreams          `yaml:"upstreams"`
+	Events        Events             `yaml:"events"`
+	Notifications Notifications      `yaml:"notifications"`
+	Telegram      Telegram           `yaml:"telegram"`
+	SMTP          SMTP               `yaml:"smtp"`
+	Log           platformconfig.Log `yaml:"log"`
+}
+
+// GRPC configures the notification gRPC server
+type GRPC struct {
+	Addr string `yaml:"addr" env:"NOTIFICATION_GRPC_ADDR"`
+}
+
+// Admin configures the admin HTTP server exposing metrics
+type Admin struct {
+	Addr string `yaml:"addr" env:"NOTIFICATION_ADMIN_ADDR"`
+}
+
+// Upstreams are the gRPC services the notification service calls
+type Upstreams struct {
+	IAMAddr string `yaml:"iam_addr" env:"NOTIFICATION_IAM_ADDR"`
+	// Timeout bounds every call
+	Timeout time.Duration `yaml:"timeout" env:"NOTIFICATION_UPSTREAM_TIMEOUT"`
+}
+
+// Events configures consuming of order and assembly events
+type Events struct {
+	// KafkaBrokers lists Kafka bootstrap brokers; without them events go
+	// through an in-memory broker and never leave the process
+	KafkaBrokers  []string `yaml:"kafka_brokers" env:"NOTIFICATION_KAFKA_BROKERS"`
+	OrderTopic    string   `yaml:"order_topic" env:"NOTIFICATION_ORDER_EVENTS_TOPIC"`
+	AssemblyTopic string   `yaml:"assembly_topic" env:"NOTIFICATION_ASSEMBLY_EVENTS_TOPIC"`
+	ConsumerGroup string   `yaml:"consumer_group" env:"NOTIFICATION_CONSUMER_GROUP"`
+}
+
+// Notifications configures rendering and sending of notifications
+type Notifications struct {
+	// Recipients is iam or static
+	Recipients string `yaml:"recipients" env:"NOTIFICATION_RECIPIENTS"`
+	// Locale is the language of notifications
+	Locale string `yaml:"locale" env:"NOTIFICATION_LOCALE"`
+	// SendAttempts counts the first attempt too; 1 disables retries
+	SendAttempts int `yaml:"send_attempts" env:"NOTIFICATION_SEND_ATTEMPTS"`
+	// SendBackoff is the delay before the first retry of a failed send
+	SendBackoff time.Duration `yaml:"send_backoff" env:"NOTIFICATION_SEND_BACKOFF"`
+}
+
+// Telegram configures the Telegram channel, which is enabled when the token is set
+type Telegram struct {
+	Token string `yaml:"token" env:"NOTIFICATION_TELEGRAM_TOKEN" secret:"true"`
+	// ChatID receives every notification with static recipients
+	ChatID string `yaml:"chat_id" env:"NOTIFICATION_TELEGRAM_CHAT_ID"`
+	APIURL string `yaml:"api_url" env:"NOTIFICATION_TELEGRAM_API_URL"`
+}
+
+// SMTP configures the email channel, which is enabled when the address is set
+type SMTP struct {
+	Addr     string `yaml:"addr" env:"NOTIFICATION_SMTP_ADDR"`
+	Username string `yaml:"username" env:"NOTIFICATION_SMTP_USERNAME"`
+	Password string `yaml:"password" env:"NOTIFICATION_SMTP_PASSWORD" secret:"true"`
+	From     string `yaml:"from" env:"NOTIFICATION_SMTP_FROM"`
+	// To receives every notification with static recipients
+	To string `yaml:"to" env:"NOTIFICATION_SMTP_TO"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		GRPC:  GRPC{Addr: "localhost:50053"},
+		Admin: Admin{Addr: "localhost:9053"},
+		Upstreams: Upstreams{
+			IAMAddr: "localhost:50054",
+			Timeout: 5 * time.Second,
+		},
+		Events: Events{
+			OrderTopic:    "order.events",
+			AssemblyTopic: "assembly.events",
+			ConsumerGroup: "notification",
+		},
+		Notifications: Notifications{
+			Recipients:   RecipientsIAM,
+			SendAttempts: 3,
+			SendBackoff:  time.Second,
+		},
+		Telegram: Telegram{APIURL: telegram.DefaultAPIURL},
+		Log:      platformconfig.Log{Level: "info"},
+	}
+}
+
+// Validate checks that every setting can be used
+func (c *Config) Validate() error {
+	errs := []error{
+		platformconfig.Required("grpc.addr", c.GRPC.Addr),
+		platformconfig.Required("admin.addr", c.Admin.Addr),
+		platformconfig.Required("events.order_topic", c.Events.OrderTopic),
+		platformconfig.Required("events.assembly_topic", c.Events.AssemblyTopic),
+		platformconfig.Required("events.consumer_group", c.Events.ConsumerGroup),
+		platformconfig.OneOf("notifications.recipients", c.Notifications.Recipients, RecipientsIAM, RecipientsStatic),
+		platformconfig.Positive("notifications.send_attempts", c.Notifications.SendAttempts),
+		platformconfig.Positive("notifications.send_backoff", c.Notifications.SendBackoff),
+		c.Log.Validate(),
+	}
+	if c.Notifications.Recipients == RecipientsIAM {
+		errs = append(errs,
+			platformconfig.Required("upstreams.iam_addr", c.Upstreams.IAMAddr),
+			platformconfig.Positive("upstreams.timeout", c.Upstreams.Timeout),
+		)
+	}
+	if c.Telegram.Token != "" {
+		errs = append(errs, platformconfig.Required("telegram.api_url", c.Telegram.APIURL))
+	}
+	if c.SMTP.Addr != "" {
+		errs = append(errs, platformconfig.Required("smtp.from", c.SMTP.From))
+	}
+
+	return errors.Join(errs...)
+}
+
+// LogLevel returns the configured log level
+func (c *Config) LogLevel() string {
+	return c.Log.Level
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+)
+
+func TestDefault_IsValid(t *testing.T) {
+	require.NoError(t, Default().Validate())
+}
+
+func TestValidate_SMTPRequiresSender(t *testing.T) {
+	cfg := Default()
+	cfg.SMTP.Addr = "smtp.example.com:587"
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "smtp.from is required")
+}
+
+func TestValidate_IAMRecipientsRequireAddress(t *testing.T) {
+	cfg := Default()
+	cfg.Upstreams.IAMAddr = ""
+
+	require.ErrorContains(t, cfg.Validate(), "upstreams.iam_addr is required")
+
+	cfg.Notifications.Recipients = RecipientsStatic
+	require.NoError(t, cfg.Validate())
+}
+
+func TestPrint_RedactsCredentials(t *testing.T) {
+	cfg := Default()
+	cfg.Telegram.Token = "123456:bot-token"
+	cfg.SMTP.Password = "mail-password"
+
+	var out bytes.Buffer
+	require.NoError(t, platformconfig.Print(&out, cfg))
+
+	assert.NotContains(t, out.String(), "bot-token")
+	assert.NotContains(t, out.String(), "mail-password")
+}
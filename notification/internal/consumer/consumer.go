@@ -0,0 +1,67 @@
+package consumer
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/converter"
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	"github.com/nimbodex/microservices-factory/notification/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// notifiedEvents are the event types users are notified about
+var notifiedEvents = map[model.EventType]struct{}{
+	model.EventTypeOrderCreated:   {},
+	model.EventTypeOrderPaid:      {},
+	model.EventTypeOrderCancelled: {},
+	model.EventTypeShipAssembled:  {},
+}
+
+// Consumer notifies users about order and assembly events. The same handler
+// serves both topics, since events are told apart by their type header.
+type Consumer struct {
+	notificationService service.NotificationService
+}
+
+// NewConsumer creates a new events consumer
+func NewConsumer(notificationService service.NotificationService) *Consumer {
+	return &Consumer{
+		notificationService: notificationService,
+	}
+}
+
+// Handle processes a single event. Events users are not notified about are
+// skipped and malformed ones are logged and dropped.
+func (c *Consumer) Handle(ctx context.Context, msg broker.Message) error {
+	eventType := model.EventType(msg.Headers[broker.HeaderEventType])
+	if _, ok := notifiedEvents[eventType]; !ok {
+		return nil
+	}
+
+	eventID := msg.Headers[broker.HeaderEventID]
+	log := logger.FromContext(ctx).With(
+		slog.String("event_type", string(eventType)),
+		slog.String("event_id", eventID),
+	)
+
+	if version := msg.Headers[broker.HeaderEventVersion]; version != strconv.Itoa(model.SupportedEventVersion) {
+		log.Warn("Skipping event of unsupported version", slog.String("version", version))
+		return nil
+	}
+	if eventID == "" {
+		log.Warn("Dropping event without id")
+		return nil
+	}
+
+	event, err := converter.ToEvent(eventID, eventType, msg.Value)
+	if err != nil {
+		log.Warn("Dropping malformed event", logger.Err(err))
+		return nil
+	}
+
+	// The notification logs name the event they are about
+	return c.notificationService.Notify(logger.WithContext(ctx, log), event)
+}
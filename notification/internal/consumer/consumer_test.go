@@ -0,0 +1,106 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	servicemocks "github.com/nimbodex/microservices-factory/notification/internal/service/mocks"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+func newTestMessage(t *testing.T, eventType model.EventType, payload proto.Message) broker.Message {
+	t.Helper()
+
+	value, err := proto.Marshal(payload)
+	require.NoError(t, err)
+
+	return broker.Message{
+		Value: value,
+		Headers: map[string]string{
+			broker.HeaderEventID:      uuid.NewString(),
+			broker.HeaderEventType:    string(eventType),
+			broker.HeaderEventVersion: "1",
+		},
+	}
+}
+
+func TestConsumer_OrderCancelled(t *testing.T) {
+	orderUUID := uuid.New()
+	userUUID := uuid.New()
+	cancelledAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	msg := newTestMessage(t, model.EventTypeOrderCancelled, &eventsv1.OrderCancelled{
+		OrderUuid:   orderUUID.String(),
+		UserUuid:    userUUID.String(),
+		Reason:      "EXPIRED",
+		CancelledAt: timestamppb.New(cancelledAt),
+	})
+
+	notificationService := servicemocks.NewNotificationService(t)
+	notificationService.On("Notify", mock.Anything, &model.Event{
+		ID:         msg.Headers[broker.HeaderEventID],
+		Type:       model.EventTypeOrderCancelled,
+		OrderUUID:  orderUUID,
+		UserUUID:   userUUID,
+		OccurredAt: cancelledAt,
+		Reason:     "EXPIRED",
+	}).Return(nil).Once()
+
+	require.NoError(t, NewConsumer(notificationService).Handle(context.Background(), msg))
+}
+
+func TestConsumer_ShipAssembled(t *testing.T) {
+	orderUUID := uuid.New()
+
+	msg := newTestMessage(t, model.EventTypeShipAssembled, &eventsv1.ShipAssembled{
+		OrderUuid: orderUUID.String(),
+		UserUuid:  uuid.NewString(),
+		BuildTime: durationpb.New(12 * time.Second),
+	})
+
+	notificationService := servicemocks.NewNotificationService(t)
+	notificationService.On("Notify", mock.Anything, mock.MatchedBy(func(event *model.Event) bool {
+		return event.OrderUUID == orderUUID && event.BuildTime == 12*time.Second
+	})).Return(nil).Once()
+
+	require.NoError(t, NewConsumer(notificationService).Handle(context.Background(), msg))
+}
+
+func TestConsumer_SkipsOtherAndMalformedEvents(t *testing.T) {
+	notificationService := servicemocks.NewNotificationService(t)
+	consumer := NewConsumer(notificationService)
+
+	started := newTestMessage(t, "ShipAssemblyStarted", &eventsv1.ShipAssemblyStarted{OrderUuid: uuid.NewString()})
+	require.NoError(t, consumer.Handle(context.Background(), started))
+
+	newer := newTestMessage(t, model.EventTypeOrderPaid, &eventsv1.OrderPaid{OrderUuid: uuid.NewString(), UserUuid: uuid.NewString()})
+	newer.Headers[broker.HeaderEventVersion] = "2"
+	require.NoError(t, consumer.Handle(context.Background(), newer))
+
+	badUUID := newTestMessage(t, model.EventTypeOrderPaid, &eventsv1.OrderPaid{OrderUuid: uuid.NewString(), UserUuid: "nobody"})
+	require.NoError(t, consumer.Handle(context.Background(), badUUID))
+
+	withoutID := newTestMessage(t, model.EventTypeOrderPaid, &eventsv1.OrderPaid{OrderUuid: uuid.NewString(), UserUuid: uuid.NewString()})
+	delete(withoutID.Headers, broker.HeaderEventID)
+	require.NoError(t, consumer.Handle(context.Background(), withoutID))
+}
+
+func TestConsumer_ReturnsNotifyErrors(t *testing.T) {
+	notificationService := servicemocks.NewNotificationService(t)
+	notificationService.On("Notify", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+	msg := newTestMessage(t, model.EventTypeOrderCreated, &eventsv1.OrderCreated{OrderUuid: uuid.NewString(), UserUuid: uuid.NewString()})
+
+	assert.ErrorIs(t, NewConsumer(notificationService).Handle(context.Background(), msg), assert.AnError)
+}
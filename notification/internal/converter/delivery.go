@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	notificationv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/notification/v1"
+)
+
+// ToProtoDeliveryStatus converts service DeliveryStatus to protobuf
+func ToProtoDeliveryStatus(status model.DeliveryStatus) notificationv1.DeliveryStatus {
+	switch status {
+	case model.DeliveryStatusSent:
+		return notificationv1.DeliveryStatus_DELIVERY_STATUS_SENT
+	case model.DeliveryStatusFailed:
+		return notificationv1.DeliveryStatus_DELIVERY_STATUS_FAILED
+	default:
+		return notificationv1.DeliveryStatus_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+// ToProtoListDeliveriesResponse converts deliveries to protobuf response
+func ToProtoListDeliveriesResponse(deliveries []*model.Delivery) *notificationv1.ListDeliveriesResponse {
+	protoDeliveries := make([]*notificationv1.Delivery, len(deliveries))
+	for i, delivery := range deliveries {
+		protoDeliveries[i] = &notificationv1.Delivery{
+			Uuid:      delivery.UUID.String(),
+			EventId:   delivery.EventID,
+			EventType: string(delivery.EventType),
+			OrderUuid: delivery.OrderUUID.String(),
+			UserUuid:  delivery.UserUUID.String(),
+			Channel:   delivery.Channel,
+			Locale:    delivery.Locale,
+			Subject:   delivery.Subject,
+			Body:      delivery.Body,
+			Status:    ToProtoDeliveryStatus(delivery.Status),
+			Attempts:  int32(delivery.Attempts),
+			Error:     delivery.Error,
+			CreatedAt: timestamppb.New(delivery.CreatedAt),
+		}
+	}
+
+	return &notificationv1.ListDeliveriesResponse{
+		Deliveries: protoDeliveries,
+	}
+}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+// ToEvent decodes the protobuf payload of an order or assembly event
+func ToEvent(eventID string, eventType model.EventType, payload []byte) (*model.Event, error) {
+	event := &model.Event{
+		ID:   eventID,
+		Type: eventType,
+	}
+
+	var orderUUID, userUUID string
+	switch eventType {
+	case model.EventTypeOrderCreated:
+		var created eventsv1.OrderCreated
+		if err := proto.Unmarshal(payload, &created); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", eventType, err)
+		}
+		orderUUID, userUUID = created.GetOrderUuid(), created.GetUserUuid()
+		event.TotalPrice = created.GetTotalPrice()
+		event.ItemCount = len(created.GetItems())
+		event.OccurredAt = created.GetCreatedAt().AsTime()
+	case model.EventTypeOrderPaid:
+		var paid eventsv1.OrderPaid
+		if err := proto.Unmarshal(payload, &paid); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", eventType, err)
+		}
+		orderUUID, userUUID = paid.GetOrderUuid(), paid.GetUserUuid()
+		event.TotalPrice = paid.GetTotalPrice()
+		event.TransactionUUID = paid.GetTransactionUuid()
+		event.PaymentMethod = paid.GetPaymentMethod()
+		event.OccurredAt = paid.GetPaidAt().AsTime()
+	case model.EventTypeOrderCancelled:
+		var cancelled eventsv1.OrderCancelled
+		if err := proto.Unmarshal(payload, &cancelled); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", eventType, err)
+		}
+		orderUUID, userUUID = cancelled.GetOrderUuid(), cancelled.GetUserUuid()
+		event.Reason = cancelled.GetReason()
+		event.OccurredAt = cancelled.GetCancelledAt().AsTime()
+	case model.EventTypeShipAssembled:
+		var assembled eventsv1.ShipAssembled
+		if err := proto.Unmarshal(payload, &assembled); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", eventType, err)
+		}
+		orderUUID, userUUID = assembled.GetOrderUuid(), assembled.GetUserUuid()
+		event.BuildTime = assembled.GetBuildTime().AsDuration()
+		event.OccurredAt = assembled.GetAssembledAt().AsTime()
+	default:
+		return nil, fmt.Errorf("unsupported event type %s", eventType)
+	}
+
+	var err error
+	if event.OrderUUID, err = uuid.Parse(orderUUID); err != nil {
+		return nil, fmt.Errorf("parse order UUID %q: %w", orderUUID, err)
+	}
+	if event.UserUUID, err = uuid.Parse(userUUID); err != nil {
+		return nil, fmt.Errorf("parse user UUID %q: %w", userUUID, err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,12 @@
+package model
+
+import "errors"
+
+// ErrNoAddress is returned by channels when the recipient has no address for them
+var ErrNoAddress = errors.New("recipient has no address for the channel")
+
+// ErrTemplateNotFound is returned when no template exists for an event type
+var ErrTemplateNotFound = errors.New("template not found")
+
+// ErrRecipientNotFound is returned by recipient repositories for unknown users
+var ErrRecipientNotFound = errors.New("recipient not found")
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a domain event the notification service reacts to
+type EventType string
+
+const (
+	EventTypeOrderCreated   EventType = "OrderCreated"
+	EventTypeOrderPaid      EventType = "OrderPaid"
+	EventTypeOrderCancelled EventType = "OrderCancelled"
+	EventTypeShipAssembled  EventType = "ShipAssembled"
+)
+
+// SupportedEventVersion is the event schema version the service reads
+const SupportedEventVersion = 1
+
+// Event is an order or assembly event decoded for rendering. Fields not
+// carried by an event type are left empty.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       EventType `json:"type"`
+	OrderUUID  uuid.UUID `json:"order_uuid"`
+	UserUUID   uuid.UUID `json:"user_uuid"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Set for OrderCreated and OrderPaid
+	TotalPrice float64 `json:"total_price,omitempty"`
+	// ItemCount is the number of parts in an OrderCreated order
+	ItemCount int `json:"item_count,omitempty"`
+	// Set for OrderPaid
+	TransactionUUID string `json:"transaction_uuid,omitempty"`
+	PaymentMethod   string `json:"payment_method,omitempty"`
+	// Reason is set for OrderCancelled
+	Reason string `json:"reason,omitempty"`
+	// BuildTime is set for ShipAssembled
+	BuildTime time.Duration `json:"build_time,omitempty"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLocale is used for recipients without a locale and for events
+// without a template in the recipient locale
+const DefaultLocale = "en"
+
+// Recipient holds where and in which language a user is notified. Empty
+// addresses mean the user is not reachable through that channel.
+type Recipient struct {
+	UserUUID       uuid.UUID `json:"user_uuid"`
+	Locale         string    `json:"locale"`
+	TelegramChatID string    `json:"telegram_chat_id,omitempty"`
+	Email          string    `json:"email,omitempty"`
+}
+
+// Message is a rendered notification
+type Message struct {
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// DeliveryStatus represents the outcome of a delivery
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent   DeliveryStatus = "SENT"
+	DeliveryStatusFailed DeliveryStatus = "FAILED"
+)
+
+// Delivery records sending a notification about an event through one channel
+type Delivery struct {
+	UUID      uuid.UUID      `json:"uuid"`
+	EventID   string         `json:"event_id"`
+	EventType EventType      `json:"event_type"`
+	OrderUUID uuid.UUID      `json:"order_uuid"`
+	UserUUID  uuid.UUID      `json:"user_uuid"`
+	Channel   string         `json:"channel"`
+	Locale    string         `json:"locale"`
+	Subject   string         `json:"subject"`
+	Body      string         `json:"body"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	// Error is the last send error of a failed delivery
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
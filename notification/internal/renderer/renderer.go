@@ -0,0 +1,110 @@
+package renderer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// templatesFS holds one template per event type and locale, stored as
+// templates/<locale>/<event type>.tmpl. Each template defines "subject" and "body".
+//
+//go:embed templates
+var templatesFS embed.FS
+
+// funcs are helpers available to every template
+var funcs = template.FuncMap{
+	"price": func(amount float64) string {
+		return fmt.Sprintf("%.2f", amount)
+	},
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Second).String()
+	},
+}
+
+// Renderer renders notification messages from localised templates
+type Renderer struct {
+	// templates is keyed by locale and then by event type
+	templates map[string]map[model.EventType]*template.Template
+}
+
+// NewRenderer parses the embedded templates
+func NewRenderer() (*Renderer, error) {
+	return newRenderer(templatesFS)
+}
+
+// newRenderer parses templates laid out like templatesFS
+func newRenderer(fsys fs.FS) (*Renderer, error) {
+	files, err := fs.Glob(fsys, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+
+	r := &Renderer{templates: make(map[string]map[model.EventType]*template.Template)}
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		eventType := model.EventType(strings.TrimSuffix(path.Base(file), ".tmpl"))
+
+		tmpl, err := template.New(path.Base(file)).Funcs(funcs).Option("missingkey=error").ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %s: %w", file, err)
+		}
+		for _, name := range []string{"subject", "body"} {
+			if tmpl.Lookup(name) == nil {
+				return nil, fmt.Errorf("template %s does not define %q", file, name)
+			}
+		}
+
+		if r.templates[locale] == nil {
+			r.templates[locale] = make(map[model.EventType]*template.Template)
+		}
+		r.templates[locale][eventType] = tmpl
+	}
+
+	return r, nil
+}
+
+// Render renders the message about event in locale, falling back to
+// model.DefaultLocale when the locale has no template for the event type
+func (r *Renderer) Render(event *model.Event, locale string) (*model.Message, error) {
+	tmpl, ok := r.templates[locale][event.Type]
+	if !ok {
+		locale = model.DefaultLocale
+		tmpl, ok = r.templates[locale][event.Type]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s event: %w", event.Type, model.ErrTemplateNotFound)
+	}
+
+	subject, err := execute(tmpl, "subject", event)
+	if err != nil {
+		return nil, err
+	}
+	body, err := execute(tmpl, "body", event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Message{
+		Locale:  locale,
+		Subject: subject,
+		Body:    body,
+	}, nil
+}
+
+// execute renders the named part of a template
+func execute(tmpl *template.Template, name string, event *model.Event) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, event); err != nil {
+		return "", fmt.Errorf("render %s of %s event: %w", name, event.Type, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
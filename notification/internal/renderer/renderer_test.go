@@ -0,0 +1,104 @@
+package renderer
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+func TestRenderer_EveryEventTypeHasTemplates(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	eventTypes := []model.EventType{
+		model.EventTypeOrderCreated,
+		model.EventTypeOrderPaid,
+		model.EventTypeOrderCancelled,
+		model.EventTypeShipAssembled,
+	}
+	for _, locale := range []string{"en", "ru"} {
+		for _, eventType := range eventTypes {
+			_, ok := r.templates[locale][eventType]
+			assert.True(t, ok, "no %s template for %s", locale, eventType)
+		}
+	}
+}
+
+func TestRenderer_RendersLocalisedMessage(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	event := &model.Event{
+		Type:            model.EventTypeOrderPaid,
+		OrderUUID:       uuid.MustParse("0b6d6cf1-7a8b-4b8a-9e4c-0c7c2c6f4a11"),
+		TotalPrice:      1500.5,
+		TransactionUUID: "c9a6c9c2-6f0e-4d0b-8d5c-5e5b0f0f7a22",
+	}
+
+	msg, err := r.Render(event, "ru")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ru", msg.Locale)
+	assert.Equal(t, "Заказ 0b6d6cf1-7a8b-4b8a-9e4c-0c7c2c6f4a11 оплачен", msg.Subject)
+	assert.Contains(t, msg.Body, "1500.50")
+	assert.Contains(t, msg.Body, "c9a6c9c2-6f0e-4d0b-8d5c-5e5b0f0f7a22")
+}
+
+func TestRenderer_FallsBackToDefaultLocale(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	event := &model.Event{
+		Type:      model.EventTypeShipAssembled,
+		OrderUUID: uuid.New(),
+		BuildTime: 10*time.Second + 300*time.Millisecond,
+	}
+
+	msg, err := r.Render(event, "de")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.DefaultLocale, msg.Locale)
+	assert.Equal(t, "Your ship is ready", msg.Subject)
+	assert.Contains(t, msg.Body, "10s")
+}
+
+func TestRenderer_CancellationReason(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	event := &model.Event{Type: model.EventTypeOrderCancelled, OrderUUID: uuid.New(), Reason: "EXPIRED"}
+
+	msg, err := r.Render(event, "en")
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "not paid in time")
+
+	event.Reason = "USER_CANCELLED"
+	msg, err = r.Render(event, "en")
+	require.NoError(t, err)
+	assert.NotContains(t, msg.Body, "not paid in time")
+}
+
+func TestRenderer_UnknownEventType(t *testing.T) {
+	r, err := NewRenderer()
+	require.NoError(t, err)
+
+	_, err = r.Render(&model.Event{Type: "OrderRefunded"}, "en")
+
+	assert.ErrorIs(t, err, model.ErrTemplateNotFound)
+}
+
+func TestRenderer_RejectsIncompleteTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/en/OrderPaid.tmpl": {Data: []byte(`{{define "subject"}}Paid{{end}}`)},
+	}
+
+	_, err := newRenderer(fsys)
+
+	assert.ErrorContains(t, err, `does not define "body"`)
+}
@@ -0,0 +1,56 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// MemoryDeliveryRepository implements DeliveryRepository using in-memory storage
+type MemoryDeliveryRepository struct {
+	mu sync.RWMutex
+	// deliveries is keyed by order UUID, each list in insertion order
+	deliveries map[uuid.UUID][]*model.Delivery
+}
+
+// NewMemoryDeliveryRepository creates a new in-memory delivery repository
+func NewMemoryDeliveryRepository() *MemoryDeliveryRepository {
+	return &MemoryDeliveryRepository{
+		deliveries: make(map[uuid.UUID][]*model.Delivery),
+	}
+}
+
+// Add appends a delivery to the log
+func (r *MemoryDeliveryRepository) Add(ctx context.Context, delivery *model.Delivery) error {
+	if delivery == nil {
+		return fmt.Errorf("delivery cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Store a copy to avoid external modifications
+	deliveryCopy := *delivery
+	r.deliveries[delivery.OrderUUID] = append(r.deliveries[delivery.OrderUUID], &deliveryCopy)
+
+	return nil
+}
+
+// ListByOrder returns deliveries about an order, oldest first
+func (r *MemoryDeliveryRepository) ListByOrder(ctx context.Context, orderUUID uuid.UUID) ([]*model.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.deliveries[orderUUID]
+	result := make([]*model.Delivery, len(stored))
+	for i, delivery := range stored {
+		deliveryCopy := *delivery
+		result[i] = &deliveryCopy
+	}
+
+	return result, nil
+}
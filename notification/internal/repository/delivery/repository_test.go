@@ -0,0 +1,53 @@
+package delivery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+func TestMemoryDeliveryRepository_ListByOrder(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryDeliveryRepository()
+	orderUUID := uuid.New()
+
+	first := &model.Delivery{UUID: uuid.New(), OrderUUID: orderUUID, EventType: model.EventTypeOrderCreated}
+	second := &model.Delivery{UUID: uuid.New(), OrderUUID: orderUUID, EventType: model.EventTypeOrderPaid}
+	other := &model.Delivery{UUID: uuid.New(), OrderUUID: uuid.New()}
+
+	require.NoError(t, repo.Add(ctx, first))
+	require.NoError(t, repo.Add(ctx, other))
+	require.NoError(t, repo.Add(ctx, second))
+
+	deliveries, err := repo.ListByOrder(ctx, orderUUID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, first.UUID, deliveries[0].UUID)
+	assert.Equal(t, second.UUID, deliveries[1].UUID)
+
+	// Returned deliveries are copies
+	deliveries[0].Status = model.DeliveryStatusFailed
+	again, err := repo.ListByOrder(ctx, orderUUID)
+	require.NoError(t, err)
+	assert.Empty(t, again[0].Status)
+}
+
+func TestMemoryDeliveryRepository_UnknownOrder(t *testing.T) {
+	repo := NewMemoryDeliveryRepository()
+
+	deliveries, err := repo.ListByOrder(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestMemoryDeliveryRepository_AddNil(t *testing.T) {
+	repo := NewMemoryDeliveryRepository()
+
+	assert.Error(t, repo.Add(context.Background(), nil))
+}
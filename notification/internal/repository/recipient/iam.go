@@ -0,0 +1,52 @@
+package recipient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+// IAMRecipientRepository implements RecipientRepository by looking users up
+// in IAM service, so that every user is notified at their own email. IAM
+// keeps no Telegram chats, so its recipients are not reachable via Telegram.
+type IAMRecipientRepository struct {
+	client iamv1.IAMServiceClient
+	locale string
+}
+
+// NewIAMRecipientRepository creates a repository returning the users known
+// to IAM, notified in locale
+func NewIAMRecipientRepository(client iamv1.IAMServiceClient, locale string) *IAMRecipientRepository {
+	if locale == "" {
+		locale = model.DefaultLocale
+	}
+
+	return &IAMRecipientRepository{
+		client: client,
+		locale: locale,
+	}
+}
+
+// Get returns the contact of the user stored in IAM, or
+// model.ErrRecipientNotFound when IAM does not know the user
+func (r *IAMRecipientRepository) Get(ctx context.Context, userUUID uuid.UUID) (*model.Recipient, error) {
+	resp, err := r.client.GetUser(ctx, &iamv1.GetUserRequest{UserUuid: userUUID.String()})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("user %s: %w", userUUID, model.ErrRecipientNotFound)
+		}
+		return nil, fmt.Errorf("get user %s from IAM: %w", userUUID, err)
+	}
+
+	return &model.Recipient{
+		UserUUID: userUUID,
+		Locale:   r.locale,
+		Email:    resp.GetUser().GetEmail(),
+	}, nil
+}
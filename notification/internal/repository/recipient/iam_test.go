@@ -0,0 +1,67 @@
+package recipient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+)
+
+// iamClient answers GetUser with user or err
+type iamClient struct {
+	iamv1.IAMServiceClient
+	user *iamv1.User
+	err  error
+}
+
+func (c *iamClient) GetUser(_ context.Context, req *iamv1.GetUserRequest, _ ...grpc.CallOption) (*iamv1.GetUserResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if req.GetUserUuid() != c.user.GetUuid() {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &iamv1.GetUserResponse{User: c.user}, nil
+}
+
+func TestIAMRecipientRepository_ReturnsEmailOfUser(t *testing.T) {
+	userUUID := uuid.New()
+	repo := NewIAMRecipientRepository(&iamClient{user: &iamv1.User{Uuid: userUUID.String(), Email: "gagarin@example.com"}}, "")
+
+	recipient, err := repo.Get(context.Background(), userUUID)
+
+	require.NoError(t, err)
+	assert.Equal(t, &model.Recipient{UserUUID: userUUID, Locale: model.DefaultLocale, Email: "gagarin@example.com"}, recipient)
+}
+
+func TestIAMRecipientRepository_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *iamClient
+		wantErr error
+	}{
+		{name: "unknown user", client: &iamClient{user: &iamv1.User{Uuid: uuid.NewString()}}, wantErr: model.ErrRecipientNotFound},
+		{name: "unavailable", client: &iamClient{err: status.Error(codes.Unavailable, "connection refused")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewIAMRecipientRepository(tt.client, "ru").Get(context.Background(), uuid.New())
+
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NotErrorIs(t, err, model.ErrRecipientNotFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package recipient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// StaticRecipientRepository implements RecipientRepository by sending every
+// notification to the same configured contact. It suits a single operator
+// watching every order, such as in development, since users are not told
+// apart.
+type StaticRecipientRepository struct {
+	contact model.Recipient
+}
+
+// NewStaticRecipientRepository creates a repository returning contact for every user
+func NewStaticRecipientRepository(contact model.Recipient) *StaticRecipientRepository {
+	if contact.Locale == "" {
+		contact.Locale = model.DefaultLocale
+	}
+
+	return &StaticRecipientRepository{
+		contact: contact,
+	}
+}
+
+// Get returns the configured contact for the user
+func (r *StaticRecipientRepository) Get(ctx context.Context, userUUID uuid.UUID) (*model.Recipient, error) {
+	recipient := r.contact
+	recipient.UserUUID = userUUID
+
+	return &recipient, nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// DeliveryRepository stores the delivery log
+type DeliveryRepository interface {
+	Add(ctx context.Context, delivery *model.Delivery) error
+	// ListByOrder returns deliveries of notifications about an order, oldest first
+	ListByOrder(ctx context.Context, orderUUID uuid.UUID) ([]*model.Delivery, error)
+}
+
+// RecipientRepository finds out where to notify users
+type RecipientRepository interface {
+	Get(ctx context.Context, userUUID uuid.UUID) (*model.Recipient, error)
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/channel"
+	"github.com/nimbodex/microservices-factory/notification/internal/channel/recording"
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	"github.com/nimbodex/microservices-factory/notification/internal/renderer"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository/delivery"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository/recipient"
+)
+
+// namedChannel lets tests use several recording channels at once
+type namedChannel struct {
+	*recording.Channel
+	name string
+}
+
+func (c namedChannel) Name() string {
+	return c.name
+}
+
+func (s *NotificationServiceTestSuite) newService(contact model.Recipient, attempts int, channels ...channel.Channel) (*NotificationServiceImpl, *delivery.MemoryDeliveryRepository) {
+	r, err := renderer.NewRenderer()
+	s.Require().NoError(err)
+
+	deliveryRepo := delivery.NewMemoryDeliveryRepository()
+	service := NewNotificationService(deliveryRepo, recipient.NewStaticRecipientRepository(contact), r, channels, attempts, time.Millisecond)
+
+	return service, deliveryRepo
+}
+
+// recipientLookup fails every lookup with err
+type recipientLookup struct {
+	err error
+}
+
+func (r recipientLookup) Get(context.Context, uuid.UUID) (*model.Recipient, error) {
+	return nil, r.err
+}
+
+func newPaidEvent() *model.Event {
+	return &model.Event{
+		ID:              uuid.NewString(),
+		Type:            model.EventTypeOrderPaid,
+		OrderUUID:       uuid.New(),
+		UserUUID:        uuid.New(),
+		TotalPrice:      250,
+		TransactionUUID: uuid.NewString(),
+		PaymentMethod:   "CARD",
+		OccurredAt:      time.Now(),
+	}
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_SendsLocalisedMessage() {
+	ch := recording.NewChannel()
+	service, deliveryRepo := s.newService(model.Recipient{Locale: "ru"}, 3, ch)
+	event := newPaidEvent()
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	sent := ch.Sent()
+	s.Require().Len(sent, 1)
+	s.Equal(event.UserUUID, sent[0].Recipient.UserUUID)
+	s.Equal("Заказ "+event.OrderUUID.String()+" оплачен", sent[0].Message.Subject)
+
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.Equal(event.ID, deliveries[0].EventID)
+	s.Equal(recording.Name, deliveries[0].Channel)
+	s.Equal("ru", deliveries[0].Locale)
+	s.Equal(model.DeliveryStatusSent, deliveries[0].Status)
+	s.Equal(1, deliveries[0].Attempts)
+
+	stored, err := deliveryRepo.ListByOrder(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Len(stored, 1)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_RetriesFailedSends() {
+	ch := recording.NewChannel()
+	ch.FailNext(errors.New("timeout"), errors.New("timeout"))
+	service, _ := s.newService(model.Recipient{}, 3, ch)
+	event := newPaidEvent()
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	s.Len(ch.Sent(), 1)
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 1)
+	s.Equal(model.DeliveryStatusSent, deliveries[0].Status)
+	s.Equal(3, deliveries[0].Attempts)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_RecordsFailedDelivery() {
+	failing := namedChannel{Channel: recording.NewChannel(), name: "telegram"}
+	failing.FailNext(errors.New("bot blocked"), errors.New("bot blocked"))
+	working := namedChannel{Channel: recording.NewChannel(), name: "smtp"}
+	service, _ := s.newService(model.Recipient{}, 2, failing, working)
+	event := newPaidEvent()
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	s.Empty(failing.Sent())
+	s.Len(working.Sent(), 1)
+
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Require().Len(deliveries, 2)
+	s.Equal("telegram", deliveries[0].Channel)
+	s.Equal(model.DeliveryStatusFailed, deliveries[0].Status)
+	s.Equal(2, deliveries[0].Attempts)
+	s.Equal("bot blocked", deliveries[0].Error)
+	s.Equal(model.DeliveryStatusSent, deliveries[1].Status)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_RedeliveredEventSentOnce() {
+	failing := namedChannel{Channel: recording.NewChannel(), name: "telegram"}
+	failing.FailNext(errors.New("bot blocked"))
+	working := namedChannel{Channel: recording.NewChannel(), name: "smtp"}
+	service, _ := s.newService(model.Recipient{}, 1, failing, working)
+	event := newPaidEvent()
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	// The failed channel is tried again, the successful one is not
+	s.Len(failing.Sent(), 1)
+	s.Len(working.Sent(), 1)
+
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Len(deliveries, 3)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_SkipsChannelsWithoutAddress() {
+	unreachable := namedChannel{Channel: recording.NewChannel(), name: "telegram"}
+	unreachable.FailNext(model.ErrNoAddress)
+	service, _ := s.newService(model.Recipient{}, 3, unreachable)
+	event := newPaidEvent()
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Empty(deliveries)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_SkipsEventsWithoutTemplate() {
+	ch := recording.NewChannel()
+	service, _ := s.newService(model.Recipient{}, 3, ch)
+	event := newPaidEvent()
+	event.Type = "OrderRefunded"
+
+	s.Require().NoError(service.Notify(context.Background(), event))
+
+	s.Empty(ch.Sent())
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_CancelledWhileRetrying() {
+	ch := recording.NewChannel()
+	ch.FailNext(errors.New("timeout"))
+	service, _ := s.newService(model.Recipient{}, 3, ch)
+	service.backoff = time.Hour
+	event := newPaidEvent()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(service.Notify(ctx, event), context.DeadlineExceeded)
+
+	// Nothing is logged, so the redelivered event is sent again
+	deliveries, err := service.ListDeliveries(context.Background(), event.OrderUUID)
+	s.Require().NoError(err)
+	s.Empty(deliveries)
+}
+
+func (s *NotificationServiceTestSuite) TestNotify_RecipientLookupFailed() {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "unknown user is skipped", err: fmt.Errorf("user: %w", model.ErrRecipientNotFound)},
+		{name: "unavailable lookup is redelivered", err: errors.New("iam unavailable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			r, err := renderer.NewRenderer()
+			s.Require().NoError(err)
+			ch := recording.NewChannel()
+			service := NewNotificationService(delivery.NewMemoryDeliveryRepository(), recipientLookup{err: tt.err}, r, []channel.Channel{ch}, 3, time.Millisecond)
+
+			err = service.Notify(context.Background(), newPaidEvent())
+
+			if tt.wantErr {
+				s.ErrorIs(err, tt.err)
+			} else {
+				s.NoError(err)
+			}
+			s.Empty(ch.Sent())
+		})
+	}
+}
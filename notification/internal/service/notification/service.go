@@ -0,0 +1,173 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/channel"
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+	"github.com/nimbodex/microservices-factory/notification/internal/renderer"
+	"github.com/nimbodex/microservices-factory/notification/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// NotificationServiceImpl implements NotificationService interface. Every
+// send is recorded in the delivery log, which also keeps redelivered events
+// from notifying a user twice through the same channel.
+type NotificationServiceImpl struct {
+	deliveryRepo  repository.DeliveryRepository
+	recipientRepo repository.RecipientRepository
+	renderer      *renderer.Renderer
+	channels      []channel.Channel
+	attempts      int
+	backoff       time.Duration
+	now           func() time.Time
+}
+
+// NewNotificationService creates a new notification service that tries each
+// channel up to attempts times, waiting backoff before the first retry and
+// doubling it after every failure
+func NewNotificationService(
+	deliveryRepo repository.DeliveryRepository,
+	recipientRepo repository.RecipientRepository,
+	renderer *renderer.Renderer,
+	channels []channel.Channel,
+	attempts int,
+	backoff time.Duration,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		deliveryRepo:  deliveryRepo,
+		recipientRepo: recipientRepo,
+		renderer:      renderer,
+		channels:      channels,
+		attempts:      attempts,
+		backoff:       backoff,
+		now:           time.Now,
+	}
+}
+
+// Notify renders the message about event in the recipient locale and sends
+// it through every channel the recipient can be reached by. A channel that
+// keeps failing is logged as a failed delivery rather than blocking later
+// events; an error is returned only if the event should be redelivered.
+func (s *NotificationServiceImpl) Notify(ctx context.Context, event *model.Event) error {
+	log := logger.FromContext(ctx).With(
+		slog.String("order_uuid", event.OrderUUID.String()),
+		slog.String("user_uuid", event.UserUUID.String()),
+	)
+
+	recipient, err := s.recipientRepo.Get(ctx, event.UserUUID)
+	if errors.Is(err, model.ErrRecipientNotFound) {
+		log.Warn("Skipping notification of unknown user")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get recipient of user %s: %w", event.UserUUID, err)
+	}
+
+	msg, err := s.renderer.Render(event, recipient.Locale)
+	if err != nil {
+		log.Warn("Skipping notification that cannot be rendered", logger.Err(err))
+		return nil
+	}
+
+	delivered, err := s.deliveredChannels(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range s.channels {
+		if _, ok := delivered[ch.Name()]; ok {
+			log.Info("Notification already sent", slog.String("channel", ch.Name()))
+			continue
+		}
+
+		attempts, sendErr := s.send(ctx, ch, recipient, msg)
+		if errors.Is(sendErr, model.ErrNoAddress) {
+			continue
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		delivery := &model.Delivery{
+			UUID:      uuid.New(),
+			EventID:   event.ID,
+			EventType: event.Type,
+			OrderUUID: event.OrderUUID,
+			UserUUID:  event.UserUUID,
+			Channel:   ch.Name(),
+			Locale:    msg.Locale,
+			Subject:   msg.Subject,
+			Body:      msg.Body,
+			Status:    model.DeliveryStatusSent,
+			Attempts:  attempts,
+			CreatedAt: s.now(),
+		}
+		if sendErr != nil {
+			log.Error("Failed to send notification", slog.String("channel", ch.Name()), slog.Int("attempts", attempts), logger.Err(sendErr))
+			delivery.Status = model.DeliveryStatusFailed
+			delivery.Error = sendErr.Error()
+		} else {
+			log.Info("Notification sent", slog.String("channel", ch.Name()))
+		}
+
+		if err := s.deliveryRepo.Add(ctx, delivery); err != nil {
+			return fmt.Errorf("record delivery of event %s via %s: %w", event.ID, ch.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the delivery log of an order, oldest first
+func (s *NotificationServiceImpl) ListDeliveries(ctx context.Context, orderUUID uuid.UUID) ([]*model.Delivery, error) {
+	deliveries, err := s.deliveryRepo.ListByOrder(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries of order %s: %w", orderUUID, err)
+	}
+
+	return deliveries, nil
+}
+
+// deliveredChannels returns the names of channels that already sent the
+// notification about event
+func (s *NotificationServiceImpl) deliveredChannels(ctx context.Context, event *model.Event) (map[string]struct{}, error) {
+	deliveries, err := s.deliveryRepo.ListByOrder(ctx, event.OrderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries of order %s: %w", event.OrderUUID, err)
+	}
+
+	delivered := make(map[string]struct{})
+	for _, delivery := range deliveries {
+		if delivery.EventID == event.ID && delivery.Status == model.DeliveryStatusSent {
+			delivered[delivery.Channel] = struct{}{}
+		}
+	}
+
+	return delivered, nil
+}
+
+// send tries to deliver msg through ch with exponential backoff. It returns
+// the number of attempts made and the last error.
+func (s *NotificationServiceImpl) send(ctx context.Context, ch channel.Channel, recipient *model.Recipient, msg *model.Message) (int, error) {
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err := ch.Send(ctx, recipient, msg)
+		if err == nil || errors.Is(err, model.ErrNoAddress) || attempt >= s.attempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
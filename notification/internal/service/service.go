@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/notification/internal/model"
+)
+
+// NotificationService defines the interface for notification service operations
+type NotificationService interface {
+	// Notify sends the message about event through every channel
+	Notify(ctx context.Context, event *model.Event) error
+	// ListDeliveries returns the delivery log of an order, oldest first
+	ListDeliveries(ctx context.Context, orderUUID uuid.UUID) ([]*model.Delivery, error)
+}
@@ -2,92 +2,293 @@ package main
 
 import (
 	"context"
-	"errors"
-	"log"
+	"database/sql"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+
 	v1 "github.com/nimbodex/microservices-factory/order/internal/api/order/v1"
-	"github.com/nimbodex/microservices-factory/order/internal/client/grpc"
+	grpcclient "github.com/nimbodex/microservices-factory/order/internal/client/grpc"
+	"github.com/nimbodex/microservices-factory/order/internal/config"
+	assemblyconsumer "github.com/nimbodex/microservices-factory/order/internal/consumer/assembly"
+	"github.com/nimbodex/microservices-factory/order/internal/expirer"
+	ordermetrics "github.com/nimbodex/microservices-factory/order/internal/metrics"
+	"github.com/nimbodex/microservices-factory/order/internal/migrations"
+	"github.com/nimbodex/microservices-factory/order/internal/reconciler"
+	"github.com/nimbodex/microservices-factory/order/internal/relay"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	idempotencyrepo "github.com/nimbodex/microservices-factory/order/internal/repository/idempotency"
 	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
+	outboxrepo "github.com/nimbodex/microservices-factory/order/internal/repository/outbox"
+	repotracing "github.com/nimbodex/microservices-factory/order/internal/repository/tracing"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+	authzservice "github.com/nimbodex/microservices-factory/order/internal/service/authz"
+	idempotencyservice "github.com/nimbodex/microservices-factory/order/internal/service/idempotency"
 	orderservice "github.com/nimbodex/microservices-factory/order/internal/service/order"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker/kafka"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/http/middleware"
+	"github.com/nimbodex/microservices-factory/platform/pkg/metrics"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
-const (
-	port              = ":8080"
-	readHeaderTimeout = 30 * time.Second
-)
+// storage holds the repositories of the configured storage backend
+type storage struct {
+	orderRepo  repository.OrderRepository
+	outboxRepo repository.OutboxRepository
+	// idempotencyRepo is shared by replicas only with PostgreSQL storage
+	idempotencyRepo repository.IdempotencyRepository
+	// txManager is nil for in-memory storage, which has no transactions
+	txManager repository.TxManager
+	// db is nil for in-memory storage
+	db *sql.DB
+}
 
 func main() {
-	log.Println("Starting Order Service...")
+	cfg := config.Default()
+	app.Run("order", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
+
+func setup(ctx context.Context, a *app.App, cfg *config.Config) error {
+	shutdownTracing, err := tracing.Init(ctx, "order", cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("init tracing: %w", err)
+	}
+	// Registered first so that spans of the last requests are flushed after everything else is closed
+	a.OnShutdown("tracer provider", shutdownTracing)
 
-	orderRepo := orderrepo.NewMemoryOrderRepository()
+	reg := metrics.NewRegistry()
 
-	inventoryClient, err := grpc.NewGRPCInventoryClient()
+	store, err := newStorage(ctx, a.Logger(), cfg.Storage, cfg.Orders.IdempotencyRetention)
 	if err != nil {
-		log.Fatalf("Failed to create inventory client: %v", err)
+		return fmt.Errorf("create order storage: %w", err)
+	}
+	if store.db != nil {
+		a.CloseOnShutdown("database", store.db)
 	}
+	store.orderRepo = repotracing.NewOrderRepository(store.orderRepo)
+	store.outboxRepo = repotracing.NewOutboxRepository(store.outboxRepo)
 
-	paymentClient, err := grpc.NewGRPCPaymentClient()
+	timeouts := grpcclient.Timeouts{
+		Default: cfg.Upstreams.Timeout,
+		Read:    cfg.Upstreams.ReadTimeout,
+		Payment: cfg.Upstreams.PaymentTimeout,
+	}
+	retry := interceptor.RetryPolicy{
+		MaxAttempts:    cfg.Upstreams.Retry.MaxAttempts,
+		InitialBackoff: cfg.Upstreams.Retry.InitialBackoff,
+		MaxBackoff:     cfg.Upstreams.Retry.MaxBackoff,
+	}
+
+	// Sessions are validated while requests are served, so IAM is closed
+	// after the HTTP server together with the other clients
+	iamPolicy := grpcclient.IAMPolicy(timeouts, retry, newBreaker(a.Logger(), "iam", cfg.Upstreams.Breaker))
+	iamClient, err := grpcclient.NewGRPCIAMClient(cfg.Upstreams.IAMAddr, interceptor.DialOptions(iamPolicy)...)
 	if err != nil {
-		log.Fatalf("Failed to create payment client: %v", err)
+		return fmt.Errorf("create IAM client: %w", err)
 	}
+	a.CloseOnShutdown("IAM client", iamClient)
+
+	paymentPolicy := grpcclient.PaymentPolicy(timeouts, retry, newBreaker(a.Logger(), "payment", cfg.Upstreams.Breaker))
+	paymentClient, err := grpcclient.NewGRPCPaymentClient(cfg.Upstreams.PaymentAddr, interceptor.DialOptions(paymentPolicy)...)
+	if err != nil {
+		return fmt.Errorf("create payment client: %w", err)
+	}
+	a.CloseOnShutdown("payment client", paymentClient)
+
+	inventoryPolicy := grpcclient.InventoryPolicy(timeouts, retry, newBreaker(a.Logger(), "inventory", cfg.Upstreams.Breaker))
+	inventoryClient, err := grpcclient.NewGRPCInventoryClient(cfg.Upstreams.InventoryAddr, interceptor.DialOptions(inventoryPolicy)...)
+	if err != nil {
+		return fmt.Errorf("create inventory client: %w", err)
+	}
+	a.CloseOnShutdown("inventory client", inventoryClient)
+
+	// Stock stays held until the expirer has had a run past the pending TTL
+	reservationTTL := cfg.Orders.PendingTTL + cfg.Orders.ExpiryInterval
+	orderService := orderservice.NewOrderService(store.orderRepo, store.outboxRepo, store.txManager, inventoryClient, paymentClient, ordermetrics.New(reg), reservationTTL)
+
+	// The expirer uses the inventory client; runners stop before clients are closed
+	a.Go("expirer", func(ctx context.Context) error {
+		expirer.NewExpirer(orderService, cfg.Orders.PendingTTL, cfg.Orders.ExpiryInterval).Run(ctx)
+		return nil
+	})
+	a.Go("reconciler", func(ctx context.Context) error {
+		reconciler.NewReconciler(orderService, cfg.Orders.StuckAfter, cfg.Orders.ReconcileInterval).Run(ctx)
+		return nil
+	})
 
-	orderService := orderservice.NewOrderService(orderRepo, inventoryClient, paymentClient)
+	publisher, subscriber, closePublisher := newBroker(a.Logger(), cfg.Events)
+	a.OnShutdown("event publisher", func(context.Context) error {
+		return closePublisher()
+	})
 
-	apiHandler := v1.NewAPIHandler(orderService)
+	// Events left in the outbox are published on the next start
+	a.Go("outbox relay", func(ctx context.Context) error {
+		relay.NewRelay(store.outboxRepo, publisher, cfg.Events.Topic, cfg.Events.OutboxInterval, cfg.Events.OutboxRetention).Run(ctx)
+		return nil
+	})
 
-	server, err := orderv1.NewServer(apiHandler)
+	consumer := assemblyconsumer.NewConsumer(orderService)
+	a.Go("assembly consumer", func(ctx context.Context) error {
+		return subscriber.Subscribe(ctx, cfg.Events.AssemblyTopic, consumer.Handle)
+	})
+
+	idempotentOrderService := idempotencyservice.NewOrderService(orderService, store.idempotencyRepo)
+
+	// Access is checked before idempotency so that other users' orders stay hidden
+	authorizedOrderService := authzservice.NewOrderService(idempotentOrderService, store.orderRepo)
+
+	apiHandler := v1.NewAPIHandler(authorizedOrderService)
+
+	server, err := orderv1.NewServer(apiHandler, v1.NewSecurityHandler(iamClient))
 	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
+		return fmt.Errorf("create server: %w", err)
 	}
 
-	httpServer := &http.Server{
-		Addr:              port,
-		Handler:           server,
-		ReadHeaderTimeout: readHeaderTimeout,
+	api := middleware.Chain(server,
+		middleware.Tracing(),
+		middleware.RequestID(a.Logger()),
+		middleware.Metrics(reg, operationOf(server)),
+		middleware.Logging(),
+		middleware.Recovery(),
+	)
+
+	// Readiness follows the dependencies orders cannot be served without
+	checker := health.NewChecker(cfg.HTTP.ReadinessTimeout)
+	checker.Add("inventory", inventoryClient.Check)
+	checker.Add("payment", paymentClient.Check)
+	if store.db != nil {
+		checker.Add("storage", store.db.PingContext)
 	}
+	a.BeforeStop(checker.Shutdown)
 
-	go func() {
-		sigCh := make(chan os.Signal, 1)
-		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-		<-sigCh
+	// Probes bypass the API middlewares so that they are neither logged nor counted
+	mux := http.NewServeMux()
+	checker.Register(mux)
+	mux.Handle("/", api)
 
-		log.Println("Shutting down Order Service...")
+	a.Go("http", app.HTTPServer(&http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+	}))
 
-		if inventoryClient != nil {
-			if closeErr := inventoryClient.Close(); closeErr != nil {
-				log.Printf("Failed to close inventory client: %v", closeErr)
-			}
+	a.Go("admin", app.HTTPServer(metrics.NewAdminServer(cfg.Admin.Addr, reg)))
+
+	a.Logger().Info("Order Service listening",
+		"addr", cfg.HTTP.Addr,
+		"admin_addr", cfg.Admin.Addr,
+		"auth", "session UUID from IAM Login goes in X-Session-Uuid",
+		"endpoints", []string{
+			"GET /api/v1/orders",
+			"POST /api/v1/orders",
+			"GET /api/v1/orders/{uuid}",
+			"POST /api/v1/orders/{uuid}/pay",
+			"POST /api/v1/orders/{uuid}/cancel",
+			"POST /api/v1/orders/{uuid}/refund",
+			"GET " + health.LivenessPath,
+			"GET " + health.ReadinessPath,
+		},
+	)
+
+	return nil
+}
+
+// operationOf names the OpenAPI operation a request is routed to, so that
+// metrics are not split by order UUIDs in paths
+func operationOf(server *orderv1.Server) func(*http.Request) string {
+	return func(r *http.Request) string {
+		route, ok := server.FindRoute(r.Method, r.URL.Path)
+		if !ok {
+			return "unknown"
 		}
-		if paymentClient != nil {
-			if closeErr := paymentClient.Close(); closeErr != nil {
-				log.Printf("Failed to close payment client: %v", closeErr)
+		return route.OperationID()
+	}
+}
+
+// newBreaker creates the circuit breaker of an upstream, logging when calls to
+// it start and stop being rejected
+func newBreaker(log *slog.Logger, upstream string, cfg config.Breaker) *breaker.Breaker {
+	return breaker.New(breaker.Config{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      cfg.OpenTimeout,
+		OnStateChange: func(from, to breaker.State) {
+			level := slog.LevelInfo
+			if to == breaker.StateOpen {
+				level = slog.LevelWarn
 			}
+			log.Log(context.Background(), level, "Circuit breaker state changed",
+				slog.String("upstream", upstream),
+				slog.String("from", from.String()),
+				slog.String("to", to.String()),
+			)
+		},
+	})
+}
+
+// newStorage creates the repositories of the configured backend. For
+// PostgreSQL it also applies pending migrations and keeps the database so it
+// can be closed on shutdown.
+func newStorage(ctx context.Context, log *slog.Logger, cfg config.Storage, idempotencyRetention time.Duration) (*storage, error) {
+	switch cfg.Backend {
+	case config.StoragePostgres:
+		db, err := sql.Open("pgx", cfg.PostgresDSN)
+		if err != nil {
+			return nil, fmt.Errorf("open database: %w", err)
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		if err := db.PingContext(ctx); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("connect to database: %w", err)
+		}
 
-		if shutdownErr := httpServer.Shutdown(ctx); shutdownErr != nil {
-			log.Printf("Server shutdown error: %v", shutdownErr)
+		if err := migrations.Up(ctx, db, goose.DialectPostgres); err != nil {
+			_ = db.Close()
+			return nil, err
 		}
-	}()
 
-	log.Printf("Order Service listening on %s", port)
-	log.Println("Available endpoints:")
-	log.Println("\t - POST /api/v1/orders: create order")
-	log.Println("\t - GET /api/v1/orders/{uuid}: get order")
-	log.Println("\t - POST /api/v1/orders/{uuid}/pay: pay order")
-	log.Println("\t - POST /api/v1/orders/{uuid}/cancel: cancel order")
+		log.Info("Using PostgreSQL order storage")
+		txManager := txmanager.NewManager(db)
+		return &storage{
+			orderRepo:       orderrepo.NewSQLOrderRepository(txManager),
+			outboxRepo:      outboxrepo.NewSQLOutboxRepository(txManager),
+			idempotencyRepo: idempotencyrepo.NewSQLIdempotencyRepository(txManager, idempotencyRetention),
+			txManager:       txManager,
+			db:              db,
+		}, nil
+	default:
+		log.Info("Using in-memory order storage")
+		return &storage{
+			orderRepo:       orderrepo.NewMemoryOrderRepository(),
+			outboxRepo:      outboxrepo.NewMemoryOutboxRepository(),
+			idempotencyRepo: idempotencyrepo.NewMemoryIdempotencyRepository(idempotencyRetention),
+		}, nil
+	}
+}
 
-	if serveErr := httpServer.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
-		log.Fatalf("Server error: %v", serveErr)
+// newBroker creates the Kafka publisher and subscriber for the configured
+// brokers, or an in-memory broker when there are none. The returned function
+// closes the publisher.
+func newBroker(log *slog.Logger, cfg config.Events) (broker.Publisher, broker.Subscriber, func() error) {
+	if len(cfg.KafkaBrokers) == 0 {
+		log.Info("Kafka brokers are not set, events are kept in memory")
+		memoryBroker := broker.NewMemoryBroker()
+		return memoryBroker, memoryBroker, func() error { return nil }
 	}
 
-	log.Println("Order Service stopped")
+	log.Info("Using Kafka", "brokers", cfg.KafkaBrokers)
+	publisher := kafka.NewPublisher(cfg.KafkaBrokers)
+	subscriber := kafka.NewSubscriber(cfg.KafkaBrokers, cfg.ConsumerGroup)
+	return publisher, subscriber, publisher.Close
 }
@@ -2,6 +2,9 @@ package v1
 
 import (
 	"context"
+	"errors"
+
+	"github.com/ogen-go/ogen/ogenerrors"
 
 	"github.com/nimbodex/microservices-factory/order/internal/service"
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
@@ -20,8 +23,8 @@ func NewAPIHandler(orderService service.OrderService) *APIHandler {
 }
 
 // CreateOrder handles POST /orders requests
-func (h *APIHandler) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (orderv1.CreateOrderRes, error) {
-	return h.orderService.CreateOrder(ctx, req)
+func (h *APIHandler) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, params orderv1.CreateOrderParams) (orderv1.CreateOrderRes, error) {
+	return h.orderService.CreateOrder(ctx, req, params)
 }
 
 // GetOrder handles GET /orders/{order_uuid} requests
@@ -29,6 +32,11 @@ func (h *APIHandler) GetOrder(ctx context.Context, params orderv1.GetOrderParams
 	return h.orderService.GetOrder(ctx, params)
 }
 
+// ListOrders handles GET /orders requests
+func (h *APIHandler) ListOrders(ctx context.Context, params orderv1.ListOrdersParams) (orderv1.ListOrdersRes, error) {
+	return h.orderService.ListOrders(ctx, params)
+}
+
 // PayOrder handles POST /orders/{order_uuid}/pay requests
 func (h *APIHandler) PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, params orderv1.PayOrderParams) (orderv1.PayOrderRes, error) {
 	return h.orderService.PayOrder(ctx, req, params)
@@ -39,7 +47,17 @@ func (h *APIHandler) CancelOrder(ctx context.Context, params orderv1.CancelOrder
 	return h.orderService.CancelOrder(ctx, params)
 }
 
-// NewError handles internal server errors
+// RefundOrder handles refund order requests
+func (h *APIHandler) RefundOrder(ctx context.Context, req orderv1.OptRefundOrderRequest, params orderv1.RefundOrderParams) (orderv1.RefundOrderRes, error) {
+	return h.orderService.RefundOrder(ctx, req, params)
+}
+
+// NewError handles authentication failures and internal server errors
 func (h *APIHandler) NewError(ctx context.Context, err error) *orderv1.InternalServerErrorStatusCode {
+	var securityErr *ogenerrors.SecurityError
+	if errors.As(err, &securityErr) {
+		return newSecurityError(ctx, securityErr)
+	}
+
 	return h.orderService.NewError(ctx, err)
 }
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/ogen-go/ogen/ogenerrors"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+// SecurityHandler authenticates requests by the session in X-Session-Uuid
+type SecurityHandler struct {
+	iamClient client.IAMClient
+}
+
+// NewSecurityHandler creates a new security handler validating sessions in IAM
+func NewSecurityHandler(iamClient client.IAMClient) *SecurityHandler {
+	return &SecurityHandler{
+		iamClient: iamClient,
+	}
+}
+
+// HandleSessionAuth validates the session and puts its user into the context
+func (h *SecurityHandler) HandleSessionAuth(ctx context.Context, operationName orderv1.OperationName, t orderv1.SessionAuth) (context.Context, error) {
+	sessionUUID, err := uuid.Parse(t.APIKey)
+	if err != nil {
+		return ctx, client.ErrInvalidSession
+	}
+
+	session, err := h.iamClient.ValidateSession(ctx, sessionUUID)
+	if err != nil {
+		return ctx, err
+	}
+
+	return auth.WithUser(ctx, &auth.User{
+		UUID:  session.UserUUID,
+		Admin: session.UserRole == client.RoleAdmin,
+	}), nil
+}
+
+// newSecurityError converts a failed authentication into a response: 401 when
+// the session is missing or invalid, 503 when IAM could not be asked
+func newSecurityError(ctx context.Context, err *ogenerrors.SecurityError) *orderv1.InternalServerErrorStatusCode {
+	if errors.Is(err, ogenerrors.ErrSecurityRequirementIsNotSatisfied) {
+		return &orderv1.InternalServerErrorStatusCode{
+			StatusCode: http.StatusUnauthorized,
+			Response: orderv1.InternalServerError{
+				Error:   "missing_session",
+				Message: "X-Session-Uuid header is required",
+			},
+		}
+	}
+
+	if errors.Is(err, client.ErrInvalidSession) {
+		return &orderv1.InternalServerErrorStatusCode{
+			StatusCode: http.StatusUnauthorized,
+			Response: orderv1.InternalServerError{
+				Error:   "invalid_session",
+				Message: "session is invalid or expired",
+			},
+		}
+	}
+
+	logger.FromContext(ctx).Error("Failed to validate session", slog.String("operation", err.OperationName()), logger.Err(err))
+	return &orderv1.InternalServerErrorStatusCode{
+		StatusCode: http.StatusServiceUnavailable,
+		Response: orderv1.InternalServerError{
+			Error:   "auth_unavailable",
+			Message: "session cannot be validated right now",
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func TestSecurityHandler_PutsSessionUserIntoContext(t *testing.T) {
+	sessionUUID := uuid.New()
+	userUUID := uuid.New()
+
+	iamClient := clientmocks.NewIAMClient(t)
+	iamClient.On("ValidateSession", mock.Anything, sessionUUID).Return(&client.Session{UUID: sessionUUID, UserUUID: userUUID, UserRole: client.RoleAdmin}, nil)
+
+	ctx, err := NewSecurityHandler(iamClient).HandleSessionAuth(context.Background(), orderv1.CreateOrderOperation, orderv1.SessionAuth{APIKey: sessionUUID.String()})
+
+	require.NoError(t, err)
+	user, ok := auth.UserFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, userUUID, user.UUID)
+	assert.True(t, user.Admin)
+}
+
+func TestServer_RejectsUnauthenticatedRequests(t *testing.T) {
+	sessionUUID := uuid.New()
+
+	tests := []struct {
+		name       string
+		session    string
+		iamErr     error
+		statusCode int
+		errorType  string
+	}{
+		{"missing session", "", nil, http.StatusUnauthorized, `"missing_session"`},
+		{"malformed session", "not-a-uuid", nil, http.StatusUnauthorized, `"invalid_session"`},
+		{"expired session", sessionUUID.String(), client.ErrInvalidSession, http.StatusUnauthorized, `"invalid_session"`},
+		{"IAM unavailable", sessionUUID.String(), errors.New("connection refused"), http.StatusServiceUnavailable, `"auth_unavailable"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iamClient := clientmocks.NewIAMClient(t)
+			if tt.iamErr != nil {
+				iamClient.On("ValidateSession", mock.Anything, sessionUUID).Return(nil, tt.iamErr)
+			}
+
+			// The order service must not be reached
+			server, err := orderv1.NewServer(NewAPIHandler(servicemocks.NewOrderService(t)), NewSecurityHandler(iamClient))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+uuid.NewString(), nil)
+			if tt.session != "" {
+				req.Header.Set("X-Session-Uuid", tt.session)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.statusCode, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.errorType)
+		})
+	}
+}
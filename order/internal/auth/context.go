@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// User is the caller a request was authenticated as
+type User struct {
+	UUID uuid.UUID
+	// Admin users may act on orders of every user
+	Admin bool
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the authenticated user set by WithUser
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok && user != nil
+}
+
+// CanAccess reports whether the user may act on an order owned by ownerUUID
+func (u *User) CanAccess(ownerUUID uuid.UUID) bool {
+	return u.Admin || u.UUID == ownerUUID
+}
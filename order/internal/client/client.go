@@ -2,26 +2,99 @@ package client
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// InventoryClient defines the interface for inventory service client
+// InventoryClient defines the interface for inventory service client. Failed
+// calls of all clients are reported as *model.ServiceError, whose code tells
+// why the upstream failed.
 type InventoryClient interface {
 	GetPart(ctx context.Context, partUUID uuid.UUID) (*Part, error)
-	ListParts(ctx context.Context, limit, offset int) ([]*Part, error)
+	// GetParts looks the parts up in one call; parts that do not exist are
+	// reported in the lookup rather than as an error
+	GetParts(ctx context.Context, partUUIDs []uuid.UUID) (*PartsLookup, error)
+	// ListParts returns up to limit parts matching filter after skipping
+	// offset of them, ordered by UUID; zero limit returns all of them
+	ListParts(ctx context.Context, filter PartsFilter, limit, offset int) ([]*Part, error)
+	// ReserveParts holds stock for the order for ttl; zero ttl keeps the inventory default
+	ReserveParts(ctx context.Context, orderUUID uuid.UUID, items []ReservationItem, ttl time.Duration) (*Reservation, error)
+	ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error
+	CommitReservation(ctx context.Context, orderUUID uuid.UUID) error
+	// ReturnParts puts sold units of a refunded order back into stock
+	ReturnParts(ctx context.Context, orderUUID uuid.UUID, items []ReservationItem) error
 }
 
 // PaymentClient defines the interface for payment service client
 type PaymentClient interface {
-	PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod PaymentMethod) (*PaymentResult, error)
+	PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod PaymentMethod, amount float64) (*PaymentResult, error)
+	// RefundPayment returns amount of the payment; zero amount refunds everything not refunded yet
+	RefundPayment(ctx context.Context, transactionUUID uuid.UUID, amount float64, reason string) (*RefundResult, error)
+	// GetPayment returns the payment made for the order
+	GetPayment(ctx context.Context, orderUUID uuid.UUID) (*PaymentInfo, error)
+}
+
+// IAMClient defines the interface for IAM service client
+type IAMClient interface {
+	// ValidateSession returns ErrInvalidSession for unknown and expired sessions
+	ValidateSession(ctx context.Context, sessionUUID uuid.UUID) (*Session, error)
+}
+
+// ErrInvalidSession is returned by IAMClient when the session is not valid
+var ErrInvalidSession = errors.New("invalid session")
+
+// Role represents the role of a user in IAM service
+type Role string
+
+const (
+	RoleUser  Role = "USER"
+	RoleAdmin Role = "ADMIN"
+)
+
+// Session represents an active session validated by IAM service
+type Session struct {
+	UUID      uuid.UUID `json:"uuid"`
+	UserUUID  uuid.UUID `json:"user_uuid"`
+	UserRole  Role      `json:"user_role"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // Part represents a part from inventory service
 type Part struct {
-	UUID  uuid.UUID `json:"uuid"`
-	Name  string    `json:"name"`
-	Price float64   `json:"price"`
+	UUID          uuid.UUID `json:"uuid"`
+	Name          string    `json:"name"`
+	Price         float64   `json:"price"`
+	StockQuantity int64     `json:"stock_quantity"`
+}
+
+// PartsLookup is the result of looking parts up by UUID
+type PartsLookup struct {
+	Parts            map[uuid.UUID]*Part `json:"parts"`
+	MissingPartUUIDs []uuid.UUID         `json:"missing_part_uuids,omitempty"`
+}
+
+// PartsFilter selects parts having any of the listed values of every set field
+type PartsFilter struct {
+	UUIDs                 []uuid.UUID `json:"uuids,omitempty"`
+	Names                 []string    `json:"names,omitempty"`
+	ManufacturerCountries []string    `json:"manufacturer_countries,omitempty"`
+	Tags                  []string    `json:"tags,omitempty"`
+}
+
+// ReservationItem is a quantity of one part to hold for an order
+type ReservationItem struct {
+	PartUUID uuid.UUID `json:"part_uuid"`
+	Quantity int32     `json:"quantity"`
+}
+
+// Reservation represents the result of reserving parts in inventory service
+type Reservation struct {
+	// Reserved is false when some parts lack stock; nothing is held then
+	Reserved             bool        `json:"reserved"`
+	UnavailablePartUUIDs []uuid.UUID `json:"unavailable_part_uuids,omitempty"`
+	ExpiresAt            time.Time   `json:"expires_at"`
 }
 
 // PaymentMethod represents payment method
@@ -39,3 +112,20 @@ type PaymentResult struct {
 	Success         bool      `json:"success"`
 	Message         string    `json:"message,omitempty"`
 }
+
+// PaymentInfo describes the payment made for an order
+type PaymentInfo struct {
+	TransactionUUID uuid.UUID     `json:"transaction_uuid"`
+	PaymentMethod   PaymentMethod `json:"payment_method"`
+	Amount          float64       `json:"amount"`
+	// RefundedAmount is the total refunded from the payment so far
+	RefundedAmount float64 `json:"refunded_amount"`
+}
+
+// RefundResult represents the result of refund processing
+type RefundResult struct {
+	RefundUUID uuid.UUID `json:"refund_uuid"`
+	// RefundedAmount is the total refunded from the payment so far
+	RefundedAmount  float64 `json:"refunded_amount"`
+	RemainingAmount float64 `json:"remaining_amount"`
+}
@@ -3,23 +3,27 @@ package grpc
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nimbodex/microservices-factory/order/internal/client"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
 	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
 	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
 )
 
-const (
-	InventoryServiceAddr = "localhost:50051"
-	PaymentServiceAddr   = "localhost:50052"
-)
-
-// GRPCInventoryClient implements InventoryClient using gRPC
+// GRPCInventoryClient implements InventoryClient using gRPC. Like the other
+// clients, it returns failed calls as *model.ServiceError.
 type GRPCInventoryClient struct {
 	client inventoryv1.InventoryServiceClient
 	conn   *grpc.ClientConn
@@ -31,9 +35,16 @@ type GRPCPaymentClient struct {
 	conn   *grpc.ClientConn
 }
 
-// NewGRPCInventoryClient creates a new gRPC inventory client
-func NewGRPCInventoryClient() (*GRPCInventoryClient, error) {
-	conn, err := grpc.NewClient(InventoryServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// GRPCIAMClient implements IAMClient using gRPC
+type GRPCIAMClient struct {
+	client iamv1.IAMServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewGRPCInventoryClient creates a gRPC inventory client connected to addr; opts are added to the connection
+func NewGRPCInventoryClient(addr string, opts ...grpc.DialOption) (*GRPCInventoryClient, error) {
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to inventory service: %w", err)
 	}
@@ -46,9 +57,10 @@ func NewGRPCInventoryClient() (*GRPCInventoryClient, error) {
 	}, nil
 }
 
-// NewGRPCPaymentClient creates a new gRPC payment client
-func NewGRPCPaymentClient() (*GRPCPaymentClient, error) {
-	conn, err := grpc.NewClient(PaymentServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewGRPCPaymentClient creates a gRPC payment client connected to addr; opts are added to the connection
+func NewGRPCPaymentClient(addr string, opts ...grpc.DialOption) (*GRPCPaymentClient, error) {
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to payment service: %w", err)
 	}
@@ -61,51 +73,191 @@ func NewGRPCPaymentClient() (*GRPCPaymentClient, error) {
 	}, nil
 }
 
+// NewGRPCIAMClient creates a gRPC IAM client connected to addr; opts are added to the connection
+func NewGRPCIAMClient(addr string, opts ...grpc.DialOption) (*GRPCIAMClient, error) {
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to IAM service: %w", err)
+	}
+
+	client := iamv1.NewIAMServiceClient(conn)
+
+	return &GRPCIAMClient{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
 // GetPart retrieves a part by UUID from inventory service
 func (c *GRPCInventoryClient) GetPart(ctx context.Context, partUUID uuid.UUID) (*client.Part, error) {
 	resp, err := c.client.GetPart(ctx, &inventoryv1.GetPartRequest{
 		Uuid: partUUID.String(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get part %s: %w", partUUID, err)
+		return nil, fmt.Errorf("failed to get part %s: %w", partUUID, serviceError("inventory", model.ErrCodePartNotFound, err))
 	}
 
 	return &client.Part{
-		UUID:  partUUID,
-		Name:  resp.Part.Name,
-		Price: resp.Part.Price,
+		UUID:          partUUID,
+		Name:          resp.Part.Name,
+		Price:         resp.Part.Price,
+		StockQuantity: resp.Part.StockQuantity,
 	}, nil
 }
 
-// ListParts retrieves a list of parts from inventory service
-func (c *GRPCInventoryClient) ListParts(ctx context.Context, limit, offset int) ([]*client.Part, error) {
+// GetParts looks the parts up with a single ListParts call filtered by UUID
+func (c *GRPCInventoryClient) GetParts(ctx context.Context, partUUIDs []uuid.UUID) (*client.PartsLookup, error) {
+	lookup := &client.PartsLookup{Parts: make(map[uuid.UUID]*client.Part, len(partUUIDs))}
+	// An empty filter would match every part
+	if len(partUUIDs) == 0 {
+		return lookup, nil
+	}
+
+	parts, err := c.ListParts(ctx, client.PartsFilter{UUIDs: partUUIDs}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range parts {
+		lookup.Parts[part.UUID] = part
+	}
+	for _, partUUID := range partUUIDs {
+		if _, ok := lookup.Parts[partUUID]; !ok {
+			lookup.MissingPartUUIDs = append(lookup.MissingPartUUIDs, partUUID)
+		}
+	}
+
+	return lookup, nil
+}
+
+// ListParts retrieves a page of parts matching filter from inventory service.
+// Parts whose UUID cannot be parsed are skipped.
+func (c *GRPCInventoryClient) ListParts(ctx context.Context, filter client.PartsFilter, limit, offset int) ([]*client.Part, error) {
+	uuids := make([]string, len(filter.UUIDs))
+	for i, partUUID := range filter.UUIDs {
+		uuids[i] = partUUID.String()
+	}
+
 	resp, err := c.client.ListParts(ctx, &inventoryv1.ListPartsRequest{
-		Filter: &inventoryv1.PartsFilter{},
+		Filter: &inventoryv1.PartsFilter{
+			Uuids:                 uuids,
+			Names:                 filter.Names,
+			ManufacturerCountries: filter.ManufacturerCountries,
+			Tags:                  filter.Tags,
+		},
+		Limit:  int32(limit),
+		Offset: int32(offset),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list parts: %w", err)
+		return nil, fmt.Errorf("failed to list parts: %w", serviceError("inventory", model.ErrCodePartNotFound, err))
 	}
 
-	parts := make([]*client.Part, len(resp.Parts))
-	for i, part := range resp.Parts {
+	parts := make([]*client.Part, 0, len(resp.Parts))
+	for _, part := range resp.Parts {
 		partUUID, err := uuid.Parse(part.Uuid)
 		if err != nil {
-			log.Printf("Failed to parse part UUID %s: %v", part.Uuid, err)
+			logger.FromContext(ctx).Warn("Failed to parse part UUID", slog.String("part_uuid", part.Uuid), logger.Err(err))
 			continue
 		}
 
-		parts[i] = &client.Part{
-			UUID:  partUUID,
-			Name:  part.Name,
-			Price: part.Price,
-		}
+		parts = append(parts, &client.Part{
+			UUID:          partUUID,
+			Name:          part.Name,
+			Price:         part.Price,
+			StockQuantity: part.StockQuantity,
+		})
 	}
 
 	return parts, nil
 }
 
+// ReserveParts holds stock of the parts for an order in inventory service
+func (c *GRPCInventoryClient) ReserveParts(ctx context.Context, orderUUID uuid.UUID, items []client.ReservationItem, ttl time.Duration) (*client.Reservation, error) {
+	reservationItems := make([]*inventoryv1.ReservationItem, len(items))
+	for i, item := range items {
+		reservationItems[i] = &inventoryv1.ReservationItem{
+			PartUuid: item.PartUUID.String(),
+			Quantity: item.Quantity,
+		}
+	}
+
+	req := &inventoryv1.ReservePartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items:     reservationItems,
+	}
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	resp, err := c.client.ReserveParts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve parts for order %s: %w", orderUUID, serviceError("inventory", model.ErrCodePartNotFound, err))
+	}
+
+	unavailable := make([]uuid.UUID, 0, len(resp.UnavailablePartUuids))
+	for _, rawUUID := range resp.UnavailablePartUuids {
+		partUUID, err := uuid.Parse(rawUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse part UUID %s: %w", rawUUID, err)
+		}
+		unavailable = append(unavailable, partUUID)
+	}
+
+	return &client.Reservation{
+		Reserved:             resp.Reserved,
+		UnavailablePartUUIDs: unavailable,
+		ExpiresAt:            resp.ExpiresAt.AsTime(),
+	}, nil
+}
+
+// ReleaseReservation returns stock held for an order to inventory service
+func (c *GRPCInventoryClient) ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	_, err := c.client.ReleaseReservation(ctx, &inventoryv1.ReleaseReservationRequest{
+		OrderUuid: orderUUID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release reservation for order %s: %w", orderUUID, serviceError("inventory", model.ErrCodePartNotFound, err))
+	}
+
+	return nil
+}
+
+// CommitReservation makes stock held for an order permanently sold
+func (c *GRPCInventoryClient) CommitReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	_, err := c.client.CommitReservation(ctx, &inventoryv1.CommitReservationRequest{
+		OrderUuid: orderUUID.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit reservation for order %s: %w", orderUUID, serviceError("inventory", model.ErrCodeReservationNotFound, err))
+	}
+
+	return nil
+}
+
+// ReturnParts puts sold units of a refunded order back into stock
+func (c *GRPCInventoryClient) ReturnParts(ctx context.Context, orderUUID uuid.UUID, items []client.ReservationItem) error {
+	returnItems := make([]*inventoryv1.ReservationItem, len(items))
+	for i, item := range items {
+		returnItems[i] = &inventoryv1.ReservationItem{
+			PartUuid: item.PartUUID.String(),
+			Quantity: item.Quantity,
+		}
+	}
+
+	_, err := c.client.ReturnParts(ctx, &inventoryv1.ReturnPartsRequest{
+		OrderUuid: orderUUID.String(),
+		Items:     returnItems,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to return parts of order %s: %w", orderUUID, serviceError("inventory", model.ErrCodePartNotFound, err))
+	}
+
+	return nil
+}
+
 // PayOrder processes payment for an order
-func (c *GRPCPaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod client.PaymentMethod) (*client.PaymentResult, error) {
+func (c *GRPCPaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod client.PaymentMethod, amount float64) (*client.PaymentResult, error) {
 	var grpcPaymentMethod paymentv1.PaymentMethod
 	switch paymentMethod {
 	case client.PaymentMethodCard:
@@ -119,9 +271,10 @@ func (c *GRPCPaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, p
 	resp, err := c.client.PayOrder(ctx, &paymentv1.PayOrderRequest{
 		OrderUuid:     orderUUID.String(),
 		PaymentMethod: grpcPaymentMethod,
+		Amount:        amount,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to process payment for order %s: %w", orderUUID, err)
+		return nil, fmt.Errorf("failed to process payment for order %s: %w", orderUUID, serviceError("payment", model.ErrCodePaymentNotFound, err))
 	}
 
 	transactionUUID, err := uuid.Parse(resp.TransactionUuid)
@@ -135,6 +288,69 @@ func (c *GRPCPaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, p
 	}, nil
 }
 
+// RefundPayment returns money of a payment
+func (c *GRPCPaymentClient) RefundPayment(ctx context.Context, transactionUUID uuid.UUID, amount float64, reason string) (*client.RefundResult, error) {
+	resp, err := c.client.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{
+		TransactionUuid: transactionUUID.String(),
+		Amount:          amount,
+		Reason:          reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund transaction %s: %w", transactionUUID, serviceError("payment", model.ErrCodePaymentNotFound, err))
+	}
+
+	refundUUID, err := uuid.Parse(resp.RefundUuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse refund UUID %s: %w", resp.RefundUuid, err)
+	}
+
+	return &client.RefundResult{
+		RefundUUID:      refundUUID,
+		RefundedAmount:  resp.RefundedAmount,
+		RemainingAmount: resp.RemainingAmount,
+	}, nil
+}
+
+// GetPayment returns the payment made for an order
+func (c *GRPCPaymentClient) GetPayment(ctx context.Context, orderUUID uuid.UUID) (*client.PaymentInfo, error) {
+	resp, err := c.client.GetPayment(ctx, &paymentv1.GetPaymentRequest{OrderUuid: orderUUID.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment of order %s: %w", orderUUID, serviceError("payment", model.ErrCodePaymentNotFound, err))
+	}
+
+	transactionUUID, err := uuid.Parse(resp.TransactionUuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction UUID %s: %w", resp.TransactionUuid, err)
+	}
+
+	var paymentMethod client.PaymentMethod
+	switch resp.PaymentMethod {
+	case paymentv1.PaymentMethod_PAYMENT_METHOD_CARD:
+		paymentMethod = client.PaymentMethodCard
+	case paymentv1.PaymentMethod_PAYMENT_METHOD_SBP:
+		paymentMethod = client.PaymentMethodSBP
+	default:
+		paymentMethod = client.PaymentMethodUnknown
+	}
+
+	return &client.PaymentInfo{
+		TransactionUUID: transactionUUID,
+		PaymentMethod:   paymentMethod,
+		Amount:          resp.Amount,
+		RefundedAmount:  resp.RefundedAmount,
+	}, nil
+}
+
+// Check returns an error unless inventory service reports itself as serving
+func (c *GRPCInventoryClient) Check(ctx context.Context) error {
+	return health.CheckGRPC(ctx, c.conn, inventoryv1.InventoryService_ServiceDesc.ServiceName)
+}
+
+// Check returns an error unless payment service reports itself as serving
+func (c *GRPCPaymentClient) Check(ctx context.Context) error {
+	return health.CheckGRPC(ctx, c.conn, paymentv1.PaymentService_ServiceDesc.ServiceName)
+}
+
 // Close closes the gRPC connection
 func (c *GRPCInventoryClient) Close() error {
 	if c.conn != nil {
@@ -150,3 +366,41 @@ func (c *GRPCPaymentClient) Close() error {
 	}
 	return nil
 }
+
+// ValidateSession resolves the user of a session in IAM service
+func (c *GRPCIAMClient) ValidateSession(ctx context.Context, sessionUUID uuid.UUID) (*client.Session, error) {
+	resp, err := c.client.ValidateSession(ctx, &iamv1.ValidateSessionRequest{
+		SessionUuid: sessionUUID.String(),
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, client.ErrInvalidSession
+		}
+		return nil, fmt.Errorf("failed to validate session: %w", serviceError("IAM", model.ErrCodeExternalServiceErr, err))
+	}
+
+	userUUID, err := uuid.Parse(resp.UserUuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse user UUID %s: %w", resp.UserUuid, err)
+	}
+
+	role := client.RoleUser
+	if resp.Role == iamv1.Role_ROLE_ADMIN {
+		role = client.RoleAdmin
+	}
+
+	return &client.Session{
+		UUID:      sessionUUID,
+		UserUUID:  userUUID,
+		UserRole:  role,
+		ExpiresAt: resp.ExpiresAt.AsTime(),
+	}, nil
+}
+
+// Close closes the gRPC connection
+func (c *GRPCIAMClient) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
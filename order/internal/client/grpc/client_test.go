@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
+)
+
+// inventoryServer answers ListParts with parts, reserves everything, fails
+// commits with commitErr and keeps the last requests
+type inventoryServer struct {
+	inventoryv1.UnimplementedInventoryServiceServer
+	parts          []*inventoryv1.Part
+	commitErr      error
+	request        *inventoryv1.ListPartsRequest
+	reserveRequest *inventoryv1.ReservePartsRequest
+}
+
+func (s *inventoryServer) ListParts(_ context.Context, req *inventoryv1.ListPartsRequest) (*inventoryv1.ListPartsResponse, error) {
+	s.request = req
+	return &inventoryv1.ListPartsResponse{Parts: s.parts}, nil
+}
+
+func (s *inventoryServer) ReserveParts(_ context.Context, req *inventoryv1.ReservePartsRequest) (*inventoryv1.ReservePartsResponse, error) {
+	s.reserveRequest = req
+	return &inventoryv1.ReservePartsResponse{Reserved: true}, nil
+}
+
+func (s *inventoryServer) CommitReservation(context.Context, *inventoryv1.CommitReservationRequest) (*inventoryv1.CommitReservationResponse, error) {
+	if s.commitErr != nil {
+		return nil, s.commitErr
+	}
+	return &inventoryv1.CommitReservationResponse{}, nil
+}
+
+func newInventoryClient(t *testing.T, server *inventoryServer) *GRPCInventoryClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	inventoryv1.RegisterInventoryServiceServer(srv, server)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	inventoryClient, err := NewGRPCInventoryClient("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = inventoryClient.Close() })
+
+	return inventoryClient
+}
+
+func TestGRPCInventoryClient_GetPartsReportsMissingParts(t *testing.T) {
+	found, missing := uuid.New(), uuid.New()
+	server := &inventoryServer{parts: []*inventoryv1.Part{{Uuid: found.String(), Name: "Engine", Price: 10, StockQuantity: 2}}}
+	inventoryClient := newInventoryClient(t, server)
+
+	lookup, err := inventoryClient.GetParts(context.Background(), []uuid.UUID{found, missing})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{found.String(), missing.String()}, server.request.GetFilter().GetUuids())
+	assert.Equal(t, &client.Part{UUID: found, Name: "Engine", Price: 10, StockQuantity: 2}, lookup.Parts[found])
+	assert.Equal(t, []uuid.UUID{missing}, lookup.MissingPartUUIDs)
+}
+
+func TestGRPCInventoryClient_GetPartsWithoutUUIDsDoesNotListEverything(t *testing.T) {
+	server := &inventoryServer{}
+	inventoryClient := newInventoryClient(t, server)
+
+	lookup, err := inventoryClient.GetParts(context.Background(), nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, lookup.Parts)
+	assert.Nil(t, server.request, "inventory must not be called")
+}
+
+func TestGRPCInventoryClient_ListPartsSendsFilterAndPage(t *testing.T) {
+	partUUID := uuid.New()
+	server := &inventoryServer{parts: []*inventoryv1.Part{
+		{Uuid: "not-a-uuid", Name: "Broken"},
+		{Uuid: partUUID.String(), Name: "Wing"},
+	}}
+	inventoryClient := newInventoryClient(t, server)
+
+	parts, err := inventoryClient.ListParts(context.Background(), client.PartsFilter{Names: []string{"Wing"}, Tags: []string{"light"}}, 10, 20)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, server.request.GetLimit())
+	assert.EqualValues(t, 20, server.request.GetOffset())
+	assert.Equal(t, []string{"Wing"}, server.request.GetFilter().GetNames())
+	assert.Equal(t, []string{"light"}, server.request.GetFilter().GetTags())
+
+	// Parts with malformed UUIDs are skipped rather than left as nil entries
+	require.Len(t, parts, 1)
+	assert.Equal(t, partUUID, parts[0].UUID)
+}
+
+func TestGRPCInventoryClient_ReservePartsSendsTTL(t *testing.T) {
+	server := &inventoryServer{}
+	inventoryClient := newInventoryClient(t, server)
+	items := []client.ReservationItem{{PartUUID: uuid.New(), Quantity: 2}}
+
+	_, err := inventoryClient.ReserveParts(context.Background(), uuid.New(), items, 16*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 16*time.Minute, server.reserveRequest.GetTtl().AsDuration())
+
+	_, err = inventoryClient.ReserveParts(context.Background(), uuid.New(), items, 0)
+	require.NoError(t, err)
+	assert.Nil(t, server.reserveRequest.GetTtl(), "zero TTL keeps the inventory default")
+}
+
+func TestGRPCInventoryClient_CommitReservationNotFound(t *testing.T) {
+	server := &inventoryServer{commitErr: status.Error(codes.NotFound, "reservation not found")}
+	inventoryClient := newInventoryClient(t, server)
+
+	err := inventoryClient.CommitReservation(context.Background(), uuid.New())
+
+	var serviceErr *model.ServiceError
+	require.ErrorAs(t, err, &serviceErr)
+	assert.Equal(t, model.ErrCodeReservationNotFound, serviceErr.Code)
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"google.golang.org/grpc/codes"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
+)
+
+// serviceError translates the status of a failed call to service into a
+// *model.ServiceError wrapping err. NotFound gets notFoundCode, as what is not
+// found differs between services. Messages of statuses the caller can act on
+// are kept; others are replaced so that upstream internals do not leak. The
+// reason and rejected fields the upstream sent with the status are kept too.
+func serviceError(service, notFoundCode string, err error) *model.ServiceError {
+	st := grpcerr.Decode(err)
+
+	serviceErr := &model.ServiceError{
+		Code:    model.ErrCodeExternalServiceErr,
+		Message: service + " service failed",
+		Reason:  st.Reason,
+		Err:     err,
+	}
+	for _, violation := range st.FieldViolations {
+		if serviceErr.Fields == nil {
+			serviceErr.Fields = make(map[string]string, len(st.FieldViolations))
+		}
+		serviceErr.Fields[violation.Field] = violation.Description
+	}
+
+	switch st.Code {
+	case codes.NotFound:
+		serviceErr.Code, serviceErr.Message = notFoundCode, st.Message
+	case codes.InvalidArgument, codes.OutOfRange:
+		serviceErr.Code, serviceErr.Message = model.ErrCodeInvalidArgument, st.Message
+	case codes.FailedPrecondition, codes.AlreadyExists, codes.Aborted:
+		serviceErr.Code, serviceErr.Message = model.ErrCodeConflict, st.Message
+	case codes.Unavailable, codes.ResourceExhausted:
+		serviceErr.Code, serviceErr.Message = model.ErrCodeUnavailable, service+" service is unavailable"
+	case codes.DeadlineExceeded:
+		serviceErr.Code, serviceErr.Message = model.ErrCodeDeadlineExceeded, service+" service did not answer in time"
+	}
+
+	return serviceErr
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+func TestServiceError_TranslatesStatusCodes(t *testing.T) {
+	tests := []struct {
+		code        codes.Code
+		wantCode    string
+		wantMessage string
+	}{
+		{code: codes.NotFound, wantCode: model.ErrCodePartNotFound, wantMessage: "part not found"},
+		{code: codes.InvalidArgument, wantCode: model.ErrCodeInvalidArgument, wantMessage: "part not found"},
+		{code: codes.FailedPrecondition, wantCode: model.ErrCodeConflict, wantMessage: "part not found"},
+		{code: codes.Unavailable, wantCode: model.ErrCodeUnavailable, wantMessage: "inventory service is unavailable"},
+		{code: codes.DeadlineExceeded, wantCode: model.ErrCodeDeadlineExceeded, wantMessage: "inventory service did not answer in time"},
+		{code: codes.Internal, wantCode: model.ErrCodeExternalServiceErr, wantMessage: "inventory service failed"},
+		{code: codes.Unknown, wantCode: model.ErrCodeExternalServiceErr, wantMessage: "inventory service failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			err := status.Error(tt.code, "part not found")
+
+			serviceErr := serviceError("inventory", model.ErrCodePartNotFound, err)
+
+			assert.Equal(t, tt.wantCode, serviceErr.Code)
+			assert.Equal(t, tt.wantMessage, serviceErr.Message)
+			assert.ErrorIs(t, serviceErr, err)
+		})
+	}
+}
+
+func TestServiceError_KeepsReasonAndRejectedFields(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "invalid amount: -1.000000").WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_AMOUNT", Domain: "payment"},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "amount", Description: "invalid amount: -1.000000"}}},
+	)
+	require.NoError(t, err)
+
+	serviceErr := serviceError("payment", model.ErrCodePaymentNotFound, st.Err())
+
+	assert.Equal(t, model.ErrCodeInvalidArgument, serviceErr.Code)
+	assert.Equal(t, "INVALID_AMOUNT", serviceErr.Reason)
+	assert.Equal(t, map[string]string{"amount": "invalid amount: -1.000000"}, serviceErr.Fields)
+}
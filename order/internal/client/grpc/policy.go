@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	iamv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/iam/v1"
+	inventoryv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/inventory/v1"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+// Timeouts bound single attempts of upstream calls by kind of call
+type Timeouts struct {
+	// Default bounds calls of no kind below
+	Default time.Duration
+	// Read bounds lookups
+	Read time.Duration
+	// Payment bounds charging and refunding
+	Payment time.Duration
+}
+
+// InventoryPolicy returns how inventory service is called. Every method is
+// retried: lookups are read-only and reservation calls are idempotent per order.
+func InventoryPolicy(timeouts Timeouts, retry interceptor.RetryPolicy, b *breaker.Breaker) interceptor.CallPolicy {
+	retry.Methods = []string{
+		inventoryv1.InventoryService_GetPart_FullMethodName,
+		inventoryv1.InventoryService_ListParts_FullMethodName,
+		inventoryv1.InventoryService_ReserveParts_FullMethodName,
+		inventoryv1.InventoryService_ReleaseReservation_FullMethodName,
+		inventoryv1.InventoryService_CommitReservation_FullMethodName,
+		inventoryv1.InventoryService_ReturnParts_FullMethodName,
+	}
+
+	return interceptor.CallPolicy{
+		Timeout: timeouts.Default,
+		MethodTimeouts: map[string]time.Duration{
+			inventoryv1.InventoryService_GetPart_FullMethodName:   timeouts.Read,
+			inventoryv1.InventoryService_ListParts_FullMethodName: timeouts.Read,
+		},
+		Retry:   retry,
+		Breaker: b,
+	}
+}
+
+// PaymentPolicy returns how payment service is called. Only payment lookups
+// are retried, as every RefundPayment call moves money anew.
+func PaymentPolicy(timeouts Timeouts, retry interceptor.RetryPolicy, b *breaker.Breaker) interceptor.CallPolicy {
+	retry.Methods = []string{paymentv1.PaymentService_GetPayment_FullMethodName}
+
+	return interceptor.CallPolicy{
+		Timeout: timeouts.Default,
+		MethodTimeouts: map[string]time.Duration{
+			paymentv1.PaymentService_PayOrder_FullMethodName:      timeouts.Payment,
+			paymentv1.PaymentService_RefundPayment_FullMethodName: timeouts.Payment,
+			paymentv1.PaymentService_GetPayment_FullMethodName:    timeouts.Read,
+		},
+		Retry:   retry,
+		Breaker: b,
+	}
+}
+
+// IAMPolicy returns how IAM service is called; session checks are read-only
+// and retried
+func IAMPolicy(timeouts Timeouts, retry interceptor.RetryPolicy, b *breaker.Breaker) interceptor.CallPolicy {
+	retry.Methods = []string{iamv1.IAMService_ValidateSession_FullMethodName}
+
+	return interceptor.CallPolicy{
+		Timeout: timeouts.Default,
+		MethodTimeouts: map[string]time.Duration{
+			iamv1.IAMService_ValidateSession_FullMethodName: timeouts.Read,
+		},
+		Retry:   retry,
+		Breaker: b,
+	}
+}
@@ -6,9 +6,10 @@ import (
 	context "context"
 
 	client "github.com/nimbodex/microservices-factory/order/internal/client"
-
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -17,6 +18,24 @@ type InventoryClient struct {
 	mock.Mock
 }
 
+// CommitReservation provides a mock function with given fields: ctx, orderUUID
+func (_m *InventoryClient) CommitReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ret := _m.Called(ctx, orderUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetPart provides a mock function with given fields: ctx, partUUID
 func (_m *InventoryClient) GetPart(ctx context.Context, partUUID uuid.UUID) (*client.Part, error) {
 	ret := _m.Called(ctx, partUUID)
@@ -47,9 +66,39 @@ func (_m *InventoryClient) GetPart(ctx context.Context, partUUID uuid.UUID) (*cl
 	return r0, r1
 }
 
-// ListParts provides a mock function with given fields: ctx, limit, offset
-func (_m *InventoryClient) ListParts(ctx context.Context, limit int, offset int) ([]*client.Part, error) {
-	ret := _m.Called(ctx, limit, offset)
+// GetParts provides a mock function with given fields: ctx, partUUIDs
+func (_m *InventoryClient) GetParts(ctx context.Context, partUUIDs []uuid.UUID) (*client.PartsLookup, error) {
+	ret := _m.Called(ctx, partUUIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParts")
+	}
+
+	var r0 *client.PartsLookup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) (*client.PartsLookup, error)); ok {
+		return rf(ctx, partUUIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) *client.PartsLookup); ok {
+		r0 = rf(ctx, partUUIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.PartsLookup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, partUUIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListParts provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *InventoryClient) ListParts(ctx context.Context, filter client.PartsFilter, limit int, offset int) ([]*client.Part, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListParts")
@@ -57,19 +106,19 @@ func (_m *InventoryClient) ListParts(ctx context.Context, limit int, offset int)
 
 	var r0 []*client.Part
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*client.Part, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, client.PartsFilter, int, int) ([]*client.Part, error)); ok {
+		return rf(ctx, filter, limit, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*client.Part); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, client.PartsFilter, int, int) []*client.Part); ok {
+		r0 = rf(ctx, filter, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*client.Part)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, client.PartsFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,6 +126,72 @@ func (_m *InventoryClient) ListParts(ctx context.Context, limit int, offset int)
 	return r0, r1
 }
 
+// ReleaseReservation provides a mock function with given fields: ctx, orderUUID
+func (_m *InventoryClient) ReleaseReservation(ctx context.Context, orderUUID uuid.UUID) error {
+	ret := _m.Called(ctx, orderUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, orderUUID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveParts provides a mock function with given fields: ctx, orderUUID, items, ttl
+func (_m *InventoryClient) ReserveParts(ctx context.Context, orderUUID uuid.UUID, items []client.ReservationItem, ttl time.Duration) (*client.Reservation, error) {
+	ret := _m.Called(ctx, orderUUID, items, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveParts")
+	}
+
+	var r0 *client.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []client.ReservationItem, time.Duration) (*client.Reservation, error)); ok {
+		return rf(ctx, orderUUID, items, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []client.ReservationItem, time.Duration) *client.Reservation); ok {
+		r0 = rf(ctx, orderUUID, items, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []client.ReservationItem, time.Duration) error); ok {
+		r1 = rf(ctx, orderUUID, items, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReturnParts provides a mock function with given fields: ctx, orderUUID, items
+func (_m *InventoryClient) ReturnParts(ctx context.Context, orderUUID uuid.UUID, items []client.ReservationItem) error {
+	ret := _m.Called(ctx, orderUUID, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnParts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []client.ReservationItem) error); ok {
+		r0 = rf(ctx, orderUUID, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewInventoryClient creates a new instance of InventoryClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewInventoryClient(t interface {
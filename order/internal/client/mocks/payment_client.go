@@ -6,7 +6,6 @@ import (
 	context "context"
 
 	client "github.com/nimbodex/microservices-factory/order/internal/client"
-
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -17,9 +16,39 @@ type PaymentClient struct {
 	mock.Mock
 }
 
-// PayOrder provides a mock function with given fields: ctx, orderUUID, paymentMethod
-func (_m *PaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod client.PaymentMethod) (*client.PaymentResult, error) {
-	ret := _m.Called(ctx, orderUUID, paymentMethod)
+// GetPayment provides a mock function with given fields: ctx, orderUUID
+func (_m *PaymentClient) GetPayment(ctx context.Context, orderUUID uuid.UUID) (*client.PaymentInfo, error) {
+	ret := _m.Called(ctx, orderUUID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayment")
+	}
+
+	var r0 *client.PaymentInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*client.PaymentInfo, error)); ok {
+		return rf(ctx, orderUUID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *client.PaymentInfo); ok {
+		r0 = rf(ctx, orderUUID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.PaymentInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, orderUUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PayOrder provides a mock function with given fields: ctx, orderUUID, paymentMethod, amount
+func (_m *PaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, paymentMethod client.PaymentMethod, amount float64) (*client.PaymentResult, error) {
+	ret := _m.Called(ctx, orderUUID, paymentMethod, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PayOrder")
@@ -27,19 +56,49 @@ func (_m *PaymentClient) PayOrder(ctx context.Context, orderUUID uuid.UUID, paym
 
 	var r0 *client.PaymentResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, client.PaymentMethod) (*client.PaymentResult, error)); ok {
-		return rf(ctx, orderUUID, paymentMethod)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, client.PaymentMethod, float64) (*client.PaymentResult, error)); ok {
+		return rf(ctx, orderUUID, paymentMethod, amount)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, client.PaymentMethod) *client.PaymentResult); ok {
-		r0 = rf(ctx, orderUUID, paymentMethod)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, client.PaymentMethod, float64) *client.PaymentResult); ok {
+		r0 = rf(ctx, orderUUID, paymentMethod, amount)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*client.PaymentResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, client.PaymentMethod) error); ok {
-		r1 = rf(ctx, orderUUID, paymentMethod)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, client.PaymentMethod, float64) error); ok {
+		r1 = rf(ctx, orderUUID, paymentMethod, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundPayment provides a mock function with given fields: ctx, transactionUUID, amount, reason
+func (_m *PaymentClient) RefundPayment(ctx context.Context, transactionUUID uuid.UUID, amount float64, reason string) (*client.RefundResult, error) {
+	ret := _m.Called(ctx, transactionUUID, amount, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundPayment")
+	}
+
+	var r0 *client.RefundResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, float64, string) (*client.RefundResult, error)); ok {
+		return rf(ctx, transactionUUID, amount, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, float64, string) *client.RefundResult); ok {
+		r0 = rf(ctx, transactionUUID, amount, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*client.RefundResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, float64, string) error); ok {
+		r1 = rf(ctx, transactionUUID, amount, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+const (
+	// StorageMemory keeps orders in process memory; they are lost on restart
+	StorageMemory = "memory"
+	// StoragePostgres keeps orders in PostgreSQL
+	StoragePostgres = "postgres"
+)
+
+// Config is the configuration of the order service
+type Config struct {
+	HTTP      HTTP               `yaml:"http"`
+	Admin     Admin              `yaml:"admin"`
+	Upstreams Upstreams          `yaml:"upstreams"`
+	Storage   Storage            `yaml:"storage"`
+	Orders    Orders             `yaml:"orders"`
+	Events    Events             `yaml:"events"`
+	Tracing   tracing.Config     `yaml:"tracing"`
+	Log       platformconfig.Log `yaml:"log"`
+}
+
+// HTTP configures the order API server
+type HTTP struct {
+	Addr              string        `yaml:"addr" env:"ORDER_HTTP_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"ORDER_HTTP_READ_HEADER_TIMEOUT"`
+	// ReadinessTimeout bounds the dependency checks behind /readyz
+	ReadinessTimeout time.Duration `yaml:"readiness_timeout" env:"ORDER_HTTP_READINESS_TIMEOUT"`
+}
+
+// Admin configures the admin HTTP server exposing metrics; it is kept off the
+// API port so that metrics are not public
+type Admin struct {
+	Addr string `yaml:"addr" env:"ORDER_ADMIN_ADDR"`
+}
+
+// Upstreams are the gRPC services the order service calls
+type Upstreams struct {
+	InventoryAddr string `yaml:"inventory_addr" env:"ORDER_INVENTORY_ADDR"`
+	PaymentAddr   string `yaml:"payment_addr" env:"ORDER_PAYMENT_ADDR"`
+	IAMAddr       string `yaml:"iam_addr" env:"ORDER_IAM_ADDR"`
+	// Timeout bounds every attempt of a call unless a timeout below applies
+	Timeout time.Duration `yaml:"timeout" env:"ORDER_UPSTREAM_TIMEOUT"`
+	// ReadTimeout bounds part lookups and session checks, which most requests wait on
+	ReadTimeout time.Duration `yaml:"read_timeout" env:"ORDER_UPSTREAM_READ_TIMEOUT"`
+	// PaymentTimeout bounds charging and refunding
+	PaymentTimeout time.Duration `yaml:"payment_timeout" env:"ORDER_UPSTREAM_PAYMENT_TIMEOUT"`
+	Retry          Retry         `yaml:"retry"`
+	Breaker        Breaker       `yaml:"breaker"`
+}
+
+// Retry configures retries of idempotent upstream calls failed by a transient error
+type Retry struct {
+	// MaxAttempts counts the first attempt too; 1 disables retries
+	MaxAttempts int `yaml:"max_attempts" env:"ORDER_UPSTREAM_RETRY_MAX_ATTEMPTS"`
+	// InitialBackoff caps the random wait before the first retry; the cap
+	// doubles with every retry up to MaxBackoff
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"ORDER_UPSTREAM_RETRY_INITIAL_BACKOFF"`
+	MaxBackoff     time.Duration `yaml:"max_backoff" env:"ORDER_UPSTREAM_RETRY_MAX_BACKOFF"`
+}
+
+// Breaker configures the circuit breaker kept for every upstream
+type Breaker struct {
+	// FailureThreshold is how many consecutive failed calls open the breaker
+	FailureThreshold int `yaml:"failure_threshold" env:"ORDER_UPSTREAM_BREAKER_FAILURE_THRESHOLD"`
+	// OpenTimeout is how long calls are rejected before a trial call is let through
+	OpenTimeout time.Duration `yaml:"open_timeout" env:"ORDER_UPSTREAM_BREAKER_OPEN_TIMEOUT"`
+}
+
+// Storage selects where orders are kept
+type Storage struct {
+	// Backend is memory or postgres
+	Backend string `yaml:"backend" env:"ORDER_STORAGE"`
+	// PostgresDSN is the connection string used with the postgres backend
+	PostgresDSN string `yaml:"postgres_dsn" env:"ORDER_POSTGRES_DSN" secret:"true"`
+}
+
+// Orders configures the order lifecycle
+type Orders struct {
+	// PendingTTL is how long an order may stay unpaid before it expires
+	PendingTTL time.Duration `yaml:"pending_ttl" env:"ORDER_PENDING_TTL"`
+	// ExpiryInterval is how often unpaid orders are checked for expiry
+	ExpiryInterval time.Duration `yaml:"expiry_interval" env:"ORDER_EXPIRY_INTERVAL"`
+	// StuckAfter is how long an order may stay claimed for payment or refund
+	// before it is reconciled with payment; it has to outlast payment calls
+	StuckAfter time.Duration `yaml:"stuck_after" env:"ORDER_STUCK_AFTER"`
+	// ReconcileInterval is how often orders are checked for being stuck
+	ReconcileInterval time.Duration `yaml:"reconcile_interval" env:"ORDER_RECONCILE_INTERVAL"`
+	// IdempotencyRetention is how long Idempotency-Key responses are kept for replay
+	IdempotencyRetention time.Duration `yaml:"idempotency_retention" env:"ORDER_IDEMPOTENCY_RETENTION"`
+}
+
+// Events configures publishing of order events and consuming of assembly events
+type Events struct {
+	// KafkaBrokers lists Kafka bootstrap brokers; without them events go
+	// through an in-memory broker and never leave the process
+	KafkaBrokers  []string `yaml:"kafka_brokers" env:"ORDER_KAFKA_BROKERS"`
+	Topic         string   `yaml:"topic" env:"ORDER_EVENTS_TOPIC"`
+	AssemblyTopic string   `yaml:"assembly_topic" env:"ORDER_ASSEMBLY_EVENTS_TOPIC"`
+	ConsumerGroup string   `yaml:"consumer_group" env:"ORDER_CONSUMER_GROUP"`
+	// OutboxInterval is how often the outbox is checked for new events
+	OutboxInterval time.Duration `yaml:"outbox_interval" env:"ORDER_OUTBOX_INTERVAL"`
+	// OutboxRetention is how long published events are kept in the outbox
+	OutboxRetention time.Duration `yaml:"outbox_retention" env:"ORDER_OUTBOX_RETENTION"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		HTTP: HTTP{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 30 * time.Second,
+			ReadinessTimeout:  time.Second,
+		},
+		Admin: Admin{Addr: ":9080"},
+		Upstreams: Upstreams{
+			InventoryAddr:  "localhost:50051",
+			PaymentAddr:    "localhost:50052",
+			IAMAddr:        "localhost:50054",
+			Timeout:        5 * time.Second,
+			ReadTimeout:    2 * time.Second,
+			PaymentTimeout: 10 * time.Second,
+			Retry: Retry{
+				MaxAttempts:    3,
+				InitialBackoff: 50 * time.Millisecond,
+				MaxBackoff:     time.Second,
+			},
+			Breaker: Breaker{
+				FailureThreshold: 5,
+				OpenTimeout:      10 * time.Second,
+			},
+		},
+		Storage: Storage{
+			Backend: StorageMemory,
+		},
+		Orders: Orders{
+			// Stock of an order is reserved for PendingTTL plus ExpiryInterval,
+			// so it is held as long as the order can be paid
+			PendingTTL:           15 * time.Minute,
+			ExpiryInterval:       time.Minute,
+			StuckAfter:           5 * time.Minute,
+			ReconcileInterval:    time.Minute,
+			IdempotencyRetention: 24 * time.Hour,
+		},
+		Events: Events{
+			Topic:           "order.events",
+			AssemblyTopic:   "assembly.events",
+			ConsumerGroup:   "order",
+			OutboxInterval:  time.Second,
+			OutboxRetention: 24 * time.Hour,
+		},
+		Log: platformconfig.Log{Level: "info"},
+	}
+}
+
+// Validate checks that every setting can be used
+func (c *Config) Validate() error {
+	errs := []error{
+		platformconfig.Required("http.addr", c.HTTP.Addr),
+		platformconfig.Positive("http.read_header_timeout", c.HTTP.ReadHeaderTimeout),
+		platformconfig.Positive("http.readiness_timeout", c.HTTP.ReadinessTimeout),
+		platformconfig.Required("admin.addr", c.Admin.Addr),
+		platformconfig.Required("upstreams.inventory_addr", c.Upstreams.InventoryAddr),
+		platformconfig.Required("upstreams.payment_addr", c.Upstreams.PaymentAddr),
+		platformconfig.Required("upstreams.iam_addr", c.Upstreams.IAMAddr),
+		platformconfig.Positive("upstreams.timeout", c.Upstreams.Timeout),
+		platformconfig.Positive("upstreams.read_timeout", c.Upstreams.ReadTimeout),
+		platformconfig.Positive("upstreams.payment_timeout", c.Upstreams.PaymentTimeout),
+		platformconfig.Positive("upstreams.retry.max_attempts", c.Upstreams.Retry.MaxAttempts),
+		platformconfig.Positive("upstreams.retry.initial_backoff", c.Upstreams.Retry.InitialBackoff),
+		platformconfig.Positive("upstreams.retry.max_backoff", c.Upstreams.Retry.MaxBackoff),
+		platformconfig.Positive("upstreams.breaker.failure_threshold", c.Upstreams.Breaker.FailureThreshold),
+		platformconfig.Positive("upstreams.breaker.open_timeout", c.Upstreams.Breaker.OpenTimeout),
+		platformconfig.OneOf("storage.backend", c.Storage.Backend, StorageMemory, StoragePostgres),
+		platformconfig.Positive("orders.pending_ttl", c.Orders.PendingTTL),
+		platformconfig.Positive("orders.expiry_interval", c.Orders.ExpiryInterval),
+		platformconfig.Positive("orders.stuck_after", c.Orders.StuckAfter),
+		platformconfig.Positive("orders.reconcile_interval", c.Orders.ReconcileInterval),
+		platformconfig.Positive("orders.idempotency_retention", c.Orders.IdempotencyRetention),
+		platformconfig.Required("events.topic", c.Events.Topic),
+		platformconfig.Required("events.assembly_topic", c.Events.AssemblyTopic),
+		platformconfig.Required("events.consumer_group", c.Events.ConsumerGroup),
+		platformconfig.Positive("events.outbox_interval", c.Events.OutboxInterval),
+		platformconfig.Positive("events.outbox_retention", c.Events.OutboxRetention),
+		c.Log.Validate(),
+	}
+	if c.Storage.Backend == StoragePostgres {
+		errs = append(errs, platformconfig.Required("storage.postgres_dsn", c.Storage.PostgresDSN))
+	}
+	if minStuckAfter := c.paymentCallBound(); c.Orders.StuckAfter <= minStuckAfter {
+		errs = append(errs, fmt.Errorf("orders.stuck_after must be longer than %s, the longest a payment or refund takes with the retried calls before it, got %s", minStuckAfter, c.Orders.StuckAfter))
+	}
+
+	return errors.Join(errs...)
+}
+
+// paymentCallBound is the longest an order can stay claimed by a live
+// request: the reservation commit with all its retries and backoffs, then the
+// charge or refund, which is never retried
+func (c *Config) paymentCallBound() time.Duration {
+	retry := c.Upstreams.Retry
+	attempts := time.Duration(max(retry.MaxAttempts, 1))
+	return c.Upstreams.Timeout*attempts + retry.MaxBackoff*(attempts-1) + c.Upstreams.PaymentTimeout
+}
+
+// LogLevel returns the configured log level
+func (c *Config) LogLevel() string {
+	return c.Log.Level
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault_IsValid(t *testing.T) {
+	require.NoError(t, Default().Validate())
+}
+
+func TestValidate_PostgresRequiresDSN(t *testing.T) {
+	cfg := Default()
+	cfg.Storage.Backend = StoragePostgres
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.postgres_dsn is required")
+}
+
+func TestValidate_ReportsEveryInvalidSetting(t *testing.T) {
+	cfg := Default()
+	cfg.Storage.Backend = "mysql"
+	cfg.Upstreams.InventoryAddr = ""
+	cfg.Orders.PendingTTL = 0
+	cfg.Log.Level = "loud"
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage.backend must be one of")
+	assert.Contains(t, err.Error(), "upstreams.inventory_addr is required")
+	assert.Contains(t, err.Error(), "orders.pending_ttl must be positive")
+	assert.Contains(t, err.Error(), "log.level")
+}
+
+func TestValidate_StuckAfterOutlastsPaymentCalls(t *testing.T) {
+	cfg := Default()
+	cfg.Upstreams.PaymentTimeout = 10 * time.Second
+	cfg.Orders.StuckAfter = 10 * time.Second
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "orders.stuck_after must be longer than 27s")
+
+	cfg.Orders.StuckAfter = 28 * time.Second
+	require.NoError(t, cfg.Validate())
+}
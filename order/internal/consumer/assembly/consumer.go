@@ -0,0 +1,106 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+// Types of assembly events the consumer reacts to
+const (
+	eventTypeShipAssemblyStarted = "ShipAssemblyStarted"
+	eventTypeShipAssembled       = "ShipAssembled"
+)
+
+// supportedEventVersion is the assembly event schema version the consumer reads
+const supportedEventVersion = 1
+
+// Consumer moves orders through assembly as the assembly service reports
+// progress. Status changes are idempotent, so redelivered events need no
+// deduplication.
+type Consumer struct {
+	tracker service.OrderAssemblyTracker
+}
+
+// NewConsumer creates a new assembly events consumer
+func NewConsumer(tracker service.OrderAssemblyTracker) *Consumer {
+	return &Consumer{
+		tracker: tracker,
+	}
+}
+
+// Handle processes a single assembly event. Events that can never be applied
+// are logged and dropped; other failures are returned so the event is redelivered.
+func (c *Consumer) Handle(ctx context.Context, msg broker.Message) error {
+	eventType := msg.Headers[broker.HeaderEventType]
+	if eventType != eventTypeShipAssemblyStarted && eventType != eventTypeShipAssembled {
+		return nil
+	}
+
+	log := logger.FromContext(ctx).With(
+		slog.String("event_type", eventType),
+		slog.String("event_id", msg.Headers[broker.HeaderEventID]),
+	)
+
+	if version := msg.Headers[broker.HeaderEventVersion]; version != strconv.Itoa(supportedEventVersion) {
+		log.Warn("Skipping event of unsupported version", slog.String("version", version))
+		return nil
+	}
+
+	orderUUID, err := decodeOrderUUID(eventType, msg.Value)
+	if err != nil {
+		log.Warn("Dropping malformed event", logger.Err(err))
+		return nil
+	}
+
+	if eventType == eventTypeShipAssemblyStarted {
+		err = c.tracker.StartAssembly(ctx, orderUUID)
+	} else {
+		err = c.tracker.CompleteAssembly(ctx, orderUUID)
+	}
+
+	var serviceErr *model.ServiceError
+	if errors.As(err, &serviceErr) {
+		log.Warn("Ignoring event", slog.String("order_uuid", orderUUID.String()), logger.Err(err))
+		return nil
+	}
+
+	return err
+}
+
+// decodeOrderUUID reads the order UUID from an assembly event payload
+func decodeOrderUUID(eventType string, payload []byte) (uuid.UUID, error) {
+	var rawUUID string
+	switch eventType {
+	case eventTypeShipAssemblyStarted:
+		var event eventsv1.ShipAssemblyStarted
+		if err := proto.Unmarshal(payload, &event); err != nil {
+			return uuid.Nil, fmt.Errorf("decode payload: %w", err)
+		}
+		rawUUID = event.GetOrderUuid()
+	default:
+		var event eventsv1.ShipAssembled
+		if err := proto.Unmarshal(payload, &event); err != nil {
+			return uuid.Nil, fmt.Errorf("decode payload: %w", err)
+		}
+		rawUUID = event.GetOrderUuid()
+	}
+
+	orderUUID, err := uuid.Parse(rawUUID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("parse order UUID %q: %w", rawUUID, err)
+	}
+
+	return orderUUID, nil
+}
@@ -0,0 +1,101 @@
+package assembly
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+func newTestMessage(t *testing.T, eventType string, payload proto.Message) broker.Message {
+	t.Helper()
+
+	value, err := proto.Marshal(payload)
+	require.NoError(t, err)
+
+	return broker.Message{
+		Topic: "assembly.events",
+		Value: value,
+		Headers: map[string]string{
+			broker.HeaderEventID:      uuid.NewString(),
+			broker.HeaderEventType:    eventType,
+			broker.HeaderEventVersion: "1",
+		},
+	}
+}
+
+func TestConsumer_ShipAssemblyStarted(t *testing.T) {
+	orderUUID := uuid.New()
+
+	tracker := servicemocks.NewOrderAssemblyTracker(t)
+	tracker.On("StartAssembly", mock.Anything, orderUUID).Return(nil).Once()
+
+	msg := newTestMessage(t, eventTypeShipAssemblyStarted, &eventsv1.ShipAssemblyStarted{OrderUuid: orderUUID.String()})
+
+	require.NoError(t, NewConsumer(tracker).Handle(context.Background(), msg))
+}
+
+func TestConsumer_ShipAssembled(t *testing.T) {
+	orderUUID := uuid.New()
+
+	tracker := servicemocks.NewOrderAssemblyTracker(t)
+	tracker.On("CompleteAssembly", mock.Anything, orderUUID).Return(nil).Once()
+
+	msg := newTestMessage(t, eventTypeShipAssembled, &eventsv1.ShipAssembled{OrderUuid: orderUUID.String()})
+
+	require.NoError(t, NewConsumer(tracker).Handle(context.Background(), msg))
+}
+
+func TestConsumer_SkipsUnknownAndMalformedEvents(t *testing.T) {
+	tracker := servicemocks.NewOrderAssemblyTracker(t)
+	consumer := NewConsumer(tracker)
+
+	unknown := newTestMessage(t, "ShipDisassembled", &eventsv1.ShipAssembled{OrderUuid: uuid.NewString()})
+	require.NoError(t, consumer.Handle(context.Background(), unknown))
+
+	newer := newTestMessage(t, eventTypeShipAssembled, &eventsv1.ShipAssembled{OrderUuid: uuid.NewString()})
+	newer.Headers[broker.HeaderEventVersion] = "2"
+	require.NoError(t, consumer.Handle(context.Background(), newer))
+
+	badUUID := newTestMessage(t, eventTypeShipAssembled, &eventsv1.ShipAssembled{OrderUuid: "not-a-uuid"})
+	require.NoError(t, consumer.Handle(context.Background(), badUUID))
+
+	badPayload := newTestMessage(t, eventTypeShipAssembled, &eventsv1.ShipAssembled{})
+	badPayload.Value = []byte{0xff}
+	require.NoError(t, consumer.Handle(context.Background(), badPayload))
+}
+
+func TestConsumer_DropsEventsTheServiceRejects(t *testing.T) {
+	orderUUID := uuid.New()
+
+	tracker := servicemocks.NewOrderAssemblyTracker(t)
+	tracker.On("CompleteAssembly", mock.Anything, orderUUID).
+		Return(model.NewInvalidStatusError(model.StatusCancelled, model.StatusAssembling)).Once()
+
+	msg := newTestMessage(t, eventTypeShipAssembled, &eventsv1.ShipAssembled{OrderUuid: orderUUID.String()})
+
+	require.NoError(t, NewConsumer(tracker).Handle(context.Background(), msg))
+}
+
+func TestConsumer_ReturnsRetryableErrors(t *testing.T) {
+	for _, retryable := range []error{assert.AnError, fmt.Errorf("start assembly: %w", model.ErrOrderSettling)} {
+		orderUUID := uuid.New()
+
+		tracker := servicemocks.NewOrderAssemblyTracker(t)
+		tracker.On("StartAssembly", mock.Anything, orderUUID).Return(retryable).Once()
+
+		msg := newTestMessage(t, eventTypeShipAssemblyStarted, &eventsv1.ShipAssemblyStarted{OrderUuid: orderUUID.String()})
+
+		require.ErrorIs(t, NewConsumer(tracker).Handle(context.Background(), msg), retryable)
+	}
+}
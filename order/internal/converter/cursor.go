@@ -0,0 +1,63 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+// cursorPayload is the wire form of an order cursor
+type cursorPayload struct {
+	CreatedAt string `json:"c"`
+	UUID      string `json:"u"`
+}
+
+// EncodeOrderCursor converts a cursor into an opaque URL-safe token
+func EncodeOrderCursor(cursor *model.OrderCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	payload, err := json.Marshal(cursorPayload{
+		CreatedAt: cursor.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UUID:      cursor.UUID.String(),
+	})
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// DecodeOrderCursor parses a token produced by EncodeOrderCursor
+func DecodeOrderCursor(token string) (*model.OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, payload.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor time: %w", err)
+	}
+
+	orderUUID, err := uuid.Parse(payload.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor UUID: %w", err)
+	}
+
+	return &model.OrderCursor{
+		CreatedAt: createdAt,
+		UUID:      orderUUID,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package converter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+// ToOrderCreatedEvent builds the OrderCreated event of a new order
+func ToOrderCreatedEvent(order *model.Order) (*model.Event, error) {
+	items := make([]*eventsv1.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &eventsv1.OrderItem{
+			PartUuid:  item.PartUUID.String(),
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		}
+	}
+
+	return newEvent(model.EventTypeOrderCreated, order.UUID, order.CreatedAt, &eventsv1.OrderCreated{
+		OrderUuid:  order.UUID.String(),
+		UserUuid:   order.UserUUID.String(),
+		Items:      items,
+		TotalPrice: order.TotalPrice,
+		CreatedAt:  timestamppb.New(order.CreatedAt),
+	})
+}
+
+// ToOrderPaidEvent builds the OrderPaid event of an order that has just been paid
+func ToOrderPaidEvent(order *model.Order) (*model.Event, error) {
+	if order.TransactionUUID == nil || order.PaidAt == nil {
+		return nil, fmt.Errorf("order %s has no payment details", order.UUID)
+	}
+
+	return newEvent(model.EventTypeOrderPaid, order.UUID, *order.PaidAt, &eventsv1.OrderPaid{
+		OrderUuid:       order.UUID.String(),
+		UserUuid:        order.UserUUID.String(),
+		TransactionUuid: order.TransactionUUID.String(),
+		PaymentMethod:   string(order.PaymentMethod),
+		TotalPrice:      order.TotalPrice,
+		PaidAt:          timestamppb.New(*order.PaidAt),
+	})
+}
+
+// ToOrderCancelledEvent builds the OrderCancelled event of an order that has just been cancelled
+func ToOrderCancelledEvent(order *model.Order) (*model.Event, error) {
+	if order.CancelledAt == nil {
+		return nil, fmt.Errorf("order %s has no cancellation time", order.UUID)
+	}
+
+	return newEvent(model.EventTypeOrderCancelled, order.UUID, *order.CancelledAt, &eventsv1.OrderCancelled{
+		OrderUuid:   order.UUID.String(),
+		UserUuid:    order.UserUUID.String(),
+		Reason:      string(order.CancellationReason),
+		CancelledAt: timestamppb.New(*order.CancelledAt),
+	})
+}
+
+// ToOrderRefundedEvent builds the OrderRefunded event of an order whose money
+// has just been returned, fully or in parts
+func ToOrderRefundedEvent(order *model.Order) (*model.Event, error) {
+	if order.TransactionUUID == nil {
+		return nil, fmt.Errorf("order %s has no payment details", order.UUID)
+	}
+
+	return newEvent(model.EventTypeOrderRefunded, order.UUID, order.UpdatedAt, &eventsv1.OrderRefunded{
+		OrderUuid:       order.UUID.String(),
+		UserUuid:        order.UserUUID.String(),
+		TransactionUuid: order.TransactionUUID.String(),
+		RefundedAmount:  order.RefundedAmount,
+		TotalPrice:      order.TotalPrice,
+		FullyRefunded:   order.Status == model.StatusRefunded,
+		RefundedAt:      timestamppb.New(order.UpdatedAt),
+	})
+}
+
+// newEvent encodes payload into a new outbox event with a fresh UUID
+func newEvent(eventType model.EventType, orderUUID uuid.UUID, occurredAt time.Time, payload proto.Message) (*model.Event, error) {
+	encoded, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s event: %w", eventType, err)
+	}
+
+	return &model.Event{
+		UUID:       uuid.New(),
+		Type:       eventType,
+		Version:    model.EventVersion,
+		OrderUUID:  orderUUID,
+		Payload:    encoded,
+		OccurredAt: occurredAt,
+	}, nil
+}
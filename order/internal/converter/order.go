@@ -7,46 +7,136 @@ import (
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
-// ToCreateOrderRequest converts OpenAPI request to service model
-func ToCreateOrderRequest(req *orderv1.CreateOrderRequest) *model.CreateOrderRequest {
+// DefaultListLimit is the page size used when the client does not specify one
+const DefaultListLimit = 20
+
+// ToCreateOrderRequest converts OpenAPI request of the authenticated user to service model
+func ToCreateOrderRequest(req *orderv1.CreateOrderRequest, userUUID uuid.UUID) *model.CreateOrderRequest {
 	if req == nil {
 		return nil
 	}
 
-	partUUIDs := make([]uuid.UUID, len(req.PartUuids))
-	copy(partUUIDs, req.PartUuids)
+	items := make([]model.CreateOrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = model.CreateOrderItem{
+			PartUUID: item.PartUUID,
+			Quantity: item.Quantity,
+		}
+	}
 
 	return &model.CreateOrderRequest{
-		UserUUID:  req.UserUUID,
-		PartUUIDs: partUUIDs,
+		UserUUID: userUUID,
+		Items:    items,
 	}
 }
 
 // ToCreateOrderResponse converts service model to OpenAPI response
-func ToCreateOrderResponse(order *model.Order, totalPrice float64) *orderv1.CreateOrderResponse {
+func ToCreateOrderResponse(order *model.Order) *orderv1.CreateOrderResponse {
 	if order == nil {
 		return nil
 	}
 
 	return &orderv1.CreateOrderResponse{
 		OrderUUID:  order.UUID,
-		TotalPrice: totalPrice,
+		TotalPrice: order.TotalPrice,
 	}
 }
 
 // ToGetOrderResponse converts service model to OpenAPI response
-func ToGetOrderResponse(order *model.Order, totalPrice float64) *orderv1.GetOrderResponse {
+func ToGetOrderResponse(order *model.Order) *orderv1.GetOrderResponse {
 	if order == nil {
 		return nil
 	}
 
-	return &orderv1.GetOrderResponse{
-		OrderUUID:  order.UUID,
-		UserUUID:   order.UserUUID,
-		PartUuids:  order.PartUUIDs,
-		TotalPrice: totalPrice,
-		Status:     orderv1.OrderStatus(order.Status),
+	resp := &orderv1.GetOrderResponse{
+		OrderUUID:      order.UUID,
+		UserUUID:       order.UserUUID,
+		Items:          ToOrderItemDtos(order.Items),
+		TotalPrice:     order.TotalPrice,
+		RefundedAmount: order.RefundedAmount,
+		Status:         orderv1.OrderStatus(order.Status),
+	}
+
+	if order.TransactionUUID != nil {
+		resp.TransactionUUID = orderv1.NewOptNilUUID(*order.TransactionUUID)
+	}
+	if order.PaymentMethod != "" {
+		resp.PaymentMethod = orderv1.NewOptPaymentMethod(ToPaymentMethod(order.PaymentMethod))
+	}
+	if order.PaidAt != nil {
+		resp.PaidAt = orderv1.NewOptNilDateTime(*order.PaidAt)
+	}
+	if order.RefundedAt != nil {
+		resp.RefundedAt = orderv1.NewOptNilDateTime(*order.RefundedAt)
+	}
+	if order.CancellationReason != "" {
+		resp.CancellationReason = orderv1.NewOptCancellationReason(orderv1.CancellationReason(order.CancellationReason))
+	}
+	if order.CancelledAt != nil {
+		resp.CancelledAt = orderv1.NewOptNilDateTime(*order.CancelledAt)
+	}
+
+	return resp
+}
+
+// ToOrderItemDtos converts service order items to OpenAPI line items
+func ToOrderItemDtos(items []model.OrderItem) []orderv1.OrderItemDto {
+	dtos := make([]orderv1.OrderItemDto, len(items))
+	for i, item := range items {
+		dtos[i] = orderv1.OrderItemDto{
+			PartUUID:   item.PartUUID,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice,
+			TotalPrice: item.TotalPrice,
+		}
+	}
+
+	return dtos
+}
+
+// ToListOrdersQuery converts OpenAPI list parameters to a repository query.
+// The cursor is decoded separately so that a malformed one can be reported as a bad request.
+func ToListOrdersQuery(params orderv1.ListOrdersParams, after *model.OrderCursor) *model.ListOrdersQuery {
+	query := &model.ListOrdersQuery{
+		After: after,
+		Limit: params.Limit.Or(DefaultListLimit),
+	}
+
+	if userUUID, ok := params.UserUUID.Get(); ok {
+		query.Filter.UserUUID = &userUUID
+	}
+	if status, ok := params.Status.Get(); ok {
+		orderStatus := model.OrderStatus(status)
+		query.Filter.Status = &orderStatus
+	}
+	if createdFrom, ok := params.CreatedFrom.Get(); ok {
+		query.Filter.CreatedFrom = &createdFrom
 	}
+	if createdTo, ok := params.CreatedTo.Get(); ok {
+		query.Filter.CreatedTo = &createdTo
+	}
+	if partUUID, ok := params.PartUUID.Get(); ok {
+		query.Filter.PartUUID = &partUUID
+	}
+
+	return query
+}
+
+// ToListOrdersResponse converts a page of orders to OpenAPI response
+func ToListOrdersResponse(orders []*model.Order, next *model.OrderCursor) *orderv1.ListOrdersResponse {
+	resp := &orderv1.ListOrdersResponse{
+		Orders: make([]orderv1.GetOrderResponse, 0, len(orders)),
+	}
+
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, *ToGetOrderResponse(order))
+	}
+
+	if next != nil {
+		resp.NextCursor = orderv1.NewOptString(EncodeOrderCursor(next))
+	}
+
+	return resp
 }
 
 // ToPayOrderRequest converts OpenAPI request to service model
@@ -77,6 +167,37 @@ func ToPayOrderResponse(transactionUUID uuid.UUID) *orderv1.PayOrderResponse {
 	}
 }
 
+// ToRefundOrderRequest converts an optional OpenAPI request to service model
+func ToRefundOrderRequest(req orderv1.OptRefundOrderRequest) *model.RefundOrderRequest {
+	body := req.Or(orderv1.RefundOrderRequest{})
+
+	return &model.RefundOrderRequest{
+		Amount: body.Amount.Or(0),
+		Reason: body.Reason.Or(""),
+	}
+}
+
+// ToRefundOrderResponse converts a refunded order to OpenAPI response
+func ToRefundOrderResponse(order *model.Order, refundUUID uuid.UUID) *orderv1.RefundOrderResponse {
+	return &orderv1.RefundOrderResponse{
+		RefundUUID:     refundUUID,
+		RefundedAmount: order.RefundedAmount,
+		Status:         ToOrderStatus(order.Status),
+	}
+}
+
+// ToPaymentMethod converts service PaymentMethod to OpenAPI PaymentMethod
+func ToPaymentMethod(method model.PaymentMethod) orderv1.PaymentMethod {
+	switch method {
+	case model.PaymentMethodCard:
+		return orderv1.PaymentMethodCARD
+	case model.PaymentMethodSBP:
+		return orderv1.PaymentMethodSBP
+	default:
+		return orderv1.PaymentMethodUNKNOWN
+	}
+}
+
 // ToOrderStatus converts service OrderStatus to OpenAPI OrderStatus
 func ToOrderStatus(status model.OrderStatus) orderv1.OrderStatus {
 	return orderv1.OrderStatus(status)
@@ -0,0 +1,65 @@
+package expirer
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/order/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// DefaultPageSize is how many unpaid orders are loaded at once on each run
+const DefaultPageSize = 100
+
+// Expirer periodically cancels orders left unpaid longer than the TTL
+type Expirer struct {
+	orderExpirer service.OrderExpirer
+	ttl          time.Duration
+	interval     time.Duration
+	pageSize     int
+	now          func() time.Time
+}
+
+// NewExpirer creates an expirer that checks for unpaid orders every interval
+func NewExpirer(orderExpirer service.OrderExpirer, ttl, interval time.Duration) *Expirer {
+	return &Expirer{
+		orderExpirer: orderExpirer,
+		ttl:          ttl,
+		interval:     interval,
+		pageSize:     DefaultPageSize,
+		now:          time.Now,
+	}
+}
+
+// Run expires orders until ctx is cancelled. A run in progress when ctx is
+// cancelled stops before the next order, so Run returns promptly on shutdown.
+func (e *Expirer) Run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	log.Info("Order expirer started", slog.Duration("ttl", e.ttl), slog.Duration("interval", e.interval))
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.expire(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Info("Order expirer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expire runs a single pass over unpaid orders; failures are logged and retried on the next tick
+func (e *Expirer) expire(ctx context.Context) {
+	expired, err := e.orderExpirer.ExpireOrders(ctx, e.now().Add(-e.ttl), e.pageSize)
+	if err != nil && ctx.Err() == nil {
+		logger.FromContext(ctx).Error("Failed to expire unpaid orders", logger.Err(err))
+	}
+	if expired > 0 {
+		logger.FromContext(ctx).Info("Expired unpaid orders", slog.Int("count", expired))
+	}
+}
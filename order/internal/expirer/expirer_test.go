@@ -0,0 +1,52 @@
+package expirer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+)
+
+func TestExpirer_RunExpiresUntilCancelled(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	orderExpirer := servicemocks.NewOrderExpirer(t)
+	orderExpirer.On("ExpireOrders", mock.Anything, now.Add(-15*time.Minute), DefaultPageSize).
+		Return(0, errors.New("storage failure")).Once()
+	orderExpirer.On("ExpireOrders", mock.Anything, now.Add(-15*time.Minute), DefaultPageSize).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(2, nil).Once()
+
+	e := NewExpirer(orderExpirer, 15*time.Minute, time.Millisecond)
+	e.now = func() time.Time { return now }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expirer did not stop after the context was cancelled")
+	}
+}
+
+func TestExpirer_RunStopsWhenCancelledBeforeTick(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	orderExpirer := servicemocks.NewOrderExpirer(t)
+	orderExpirer.On("ExpireOrders", mock.Anything, mock.Anything, DefaultPageSize).Return(0, context.Canceled).Once()
+
+	NewExpirer(orderExpirer, time.Minute, time.Hour).Run(ctx)
+
+	require.Len(t, orderExpirer.Calls, 1)
+}
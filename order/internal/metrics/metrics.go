@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+// noPaymentMethod labels orders cancelled before a payment method was chosen
+const noPaymentMethod = "NONE"
+
+// Metrics are the business metrics of the order lifecycle. A nil *Metrics
+// records nothing, so services can be created without them in tests.
+type Metrics struct {
+	created   prometheus.Counter
+	paid      *prometheus.CounterVec
+	cancelled *prometheus.CounterVec
+	revenue   *prometheus.CounterVec
+}
+
+// New creates the order metrics and registers them with reg
+func New(reg prometheus.Registerer) *Metrics {
+	factory := promauto.With(reg)
+	return &Metrics{
+		created: factory.NewCounter(prometheus.CounterOpts{
+			Name: "orders_created_total",
+			Help: "Total number of orders created.",
+		}),
+		paid: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "orders_paid_total",
+			Help: "Total number of orders paid, by payment method.",
+		}, []string{"payment_method"}),
+		cancelled: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "orders_cancelled_total",
+			Help: "Total number of orders cancelled, by payment method and reason.",
+		}, []string{"payment_method", "reason"}),
+		revenue: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "orders_revenue_total",
+			Help: "Total price of paid orders, by payment method.",
+		}, []string{"payment_method"}),
+	}
+}
+
+// OrderCreated counts a new order
+func (m *Metrics) OrderCreated() {
+	if m == nil {
+		return
+	}
+	m.created.Inc()
+}
+
+// OrderPaid counts a paid order and adds its total price to the revenue
+func (m *Metrics) OrderPaid(order *model.Order) {
+	if m == nil {
+		return
+	}
+	method := paymentMethod(order)
+	m.paid.WithLabelValues(method).Inc()
+	m.revenue.WithLabelValues(method).Add(order.TotalPrice)
+}
+
+// OrderCancelled counts a cancelled order
+func (m *Metrics) OrderCancelled(order *model.Order) {
+	if m == nil {
+		return
+	}
+	m.cancelled.WithLabelValues(paymentMethod(order), string(order.CancellationReason)).Inc()
+}
+
+func paymentMethod(order *model.Order) string {
+	if order.PaymentMethod == "" {
+		return noPaymentMethod
+	}
+	return string(order.PaymentMethod)
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE orders (
+    uuid             UUID PRIMARY KEY,
+    user_uuid        UUID NOT NULL,
+    total_price      DOUBLE PRECISION NOT NULL,
+    status           TEXT NOT NULL,
+    transaction_uuid UUID,
+    payment_method   TEXT,
+    paid_at          TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL,
+    version          BIGINT NOT NULL DEFAULT 0
+);
+
+-- Serves the default listing order and keyset pagination
+CREATE INDEX orders_created_at_uuid_idx ON orders (created_at DESC, uuid DESC);
+CREATE INDEX orders_user_uuid_created_at_idx ON orders (user_uuid, created_at DESC, uuid DESC);
+
+CREATE TABLE order_parts (
+    order_uuid UUID NOT NULL REFERENCES orders (uuid) ON DELETE CASCADE,
+    ordinal    INTEGER NOT NULL,
+    part_uuid  UUID NOT NULL,
+    price      DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (order_uuid, ordinal)
+);
+
+CREATE INDEX order_parts_part_uuid_idx ON order_parts (part_uuid);
+
+-- +goose Down
+DROP TABLE order_parts;
+DROP TABLE orders;
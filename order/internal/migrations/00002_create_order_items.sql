@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE order_items (
+    order_uuid UUID NOT NULL REFERENCES orders (uuid) ON DELETE CASCADE,
+    ordinal    INTEGER NOT NULL,
+    part_uuid  UUID NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (order_uuid, ordinal),
+    UNIQUE (order_uuid, part_uuid)
+);
+
+CREATE INDEX order_items_part_uuid_idx ON order_items (part_uuid);
+
+-- Every row of order_parts was a single unit, so repeated parts become quantities
+INSERT INTO order_items (order_uuid, ordinal, part_uuid, quantity, unit_price)
+SELECT order_uuid, MIN(ordinal), part_uuid, COUNT(*), MAX(price)
+FROM order_parts
+GROUP BY order_uuid, part_uuid;
+
+DROP TABLE order_parts;
+
+-- +goose Down
+CREATE TABLE order_parts (
+    order_uuid UUID NOT NULL REFERENCES orders (uuid) ON DELETE CASCADE,
+    ordinal    INTEGER NOT NULL,
+    part_uuid  UUID NOT NULL,
+    price      DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (order_uuid, ordinal)
+);
+
+CREATE INDEX order_parts_part_uuid_idx ON order_parts (part_uuid);
+
+-- Quantities are flattened back into one row per unit
+INSERT INTO order_parts (order_uuid, ordinal, part_uuid, price)
+SELECT i.order_uuid, i.ordinal * 1000 + u.n, i.part_uuid, i.unit_price
+FROM order_items i
+JOIN (
+    WITH RECURSIVE units (n) AS (SELECT 0 UNION ALL SELECT n + 1 FROM units WHERE n < 999)
+    SELECT n FROM units
+) u ON u.n < i.quantity;
+
+DROP TABLE order_items;
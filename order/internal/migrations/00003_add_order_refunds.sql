@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN refunded_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN refunded_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN refunded_at;
+ALTER TABLE orders DROP COLUMN refunded_amount;
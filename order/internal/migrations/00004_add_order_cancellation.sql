@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN cancellation_reason TEXT;
+ALTER TABLE orders ADD COLUMN cancelled_at TIMESTAMPTZ;
+
+-- Serves the expirer looking for unpaid orders past their TTL
+CREATE INDEX orders_status_created_at_idx ON orders (status, created_at DESC, uuid DESC);
+
+-- +goose Down
+DROP INDEX orders_status_created_at_idx;
+ALTER TABLE orders DROP COLUMN cancelled_at;
+ALTER TABLE orders DROP COLUMN cancellation_reason;
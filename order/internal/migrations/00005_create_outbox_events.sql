@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE outbox_events (
+    uuid          UUID PRIMARY KEY,
+    event_type    TEXT NOT NULL,
+    event_version INTEGER NOT NULL,
+    order_uuid    UUID NOT NULL,
+    payload       BYTEA NOT NULL,
+    occurred_at   TIMESTAMPTZ NOT NULL,
+    published_at  TIMESTAMPTZ
+);
+
+-- Serves the relay polling for events that are not published yet
+CREATE INDEX outbox_events_unpublished_idx ON outbox_events (occurred_at, uuid) WHERE published_at IS NULL;
+CREATE INDEX outbox_events_published_at_idx ON outbox_events (published_at) WHERE published_at IS NOT NULL;
+
+-- +goose Down
+DROP TABLE outbox_events;
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"log/slog"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Up applies all pending migrations embedded in the binary
+func Up(ctx context.Context, db *sql.DB, dialect goose.Dialect) error {
+	provider, err := goose.NewProvider(dialect, db, files)
+	if err != nil {
+		return fmt.Errorf("create migration provider: %w", err)
+	}
+
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	for _, result := range results {
+		logger.FromContext(ctx).Info("Applied migration", slog.String("path", result.Source.Path), slog.Duration("duration", result.Duration))
+	}
+
+	return nil
+}
@@ -1,12 +1,30 @@
 package model
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrVersionConflict is returned by repositories when an order was modified
+// after it had been read, i.e. the compare-and-swap on Version failed
+var ErrVersionConflict = errors.New("order version conflict")
+
+// ErrOrderNotFound is returned by repositories when no order has the requested UUID
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrOrderSettling is returned when an order is claimed for payment or refund
+// and cannot be changed until the claim is settled
+var ErrOrderSettling = errors.New("order is being paid or refunded")
 
 // ServiceError represents a service layer error
 type ServiceError struct {
 	Code    string
 	Message string
-	Err     error
+	// Reason is the machine-readable code an upstream service gave the error, if any
+	Reason string
+	// Fields maps request fields an upstream service rejected to why
+	Fields map[string]string
+	Err    error
 }
 
 func (e *ServiceError) Error() string {
@@ -16,6 +34,10 @@ func (e *ServiceError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
 // Common service error codes
 const (
 	ErrCodeOrderNotFound      = "ORDER_NOT_FOUND"
@@ -27,6 +49,23 @@ const (
 	ErrCodeExternalServiceErr = "EXTERNAL_SERVICE_ERROR"
 )
 
+// Codes of errors returned by clients of upstream services
+const (
+	ErrCodePaymentNotFound = "PAYMENT_NOT_FOUND"
+	// ErrCodeReservationNotFound is returned when inventory holds no stock for the order any more
+	ErrCodeReservationNotFound = "RESERVATION_NOT_FOUND"
+	// ErrCodeInvalidArgument is returned when the upstream rejects the request as malformed
+	ErrCodeInvalidArgument = "INVALID_ARGUMENT"
+	// ErrCodeConflict is returned when the state of the upstream does not allow the request
+	ErrCodeConflict = "CONFLICT"
+	// ErrCodeUnavailable is returned when the upstream cannot be reached or
+	// its circuit breaker is open
+	ErrCodeUnavailable = "SERVICE_UNAVAILABLE"
+	// ErrCodeDeadlineExceeded is returned when the upstream did not answer in time;
+	// the request may still have been applied
+	ErrCodeDeadlineExceeded = "DEADLINE_EXCEEDED"
+)
+
 // Error constructors
 func NewOrderNotFoundError(orderUUID string) *ServiceError {
 	return &ServiceError{
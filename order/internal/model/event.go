@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a domain event published by the order service
+type EventType string
+
+const (
+	EventTypeOrderCreated   EventType = "OrderCreated"
+	EventTypeOrderPaid      EventType = "OrderPaid"
+	EventTypeOrderCancelled EventType = "OrderCancelled"
+	EventTypeOrderRefunded  EventType = "OrderRefunded"
+)
+
+// EventVersion is the schema version of event payloads. It is bumped on
+// changes consumers cannot read with the previous schema.
+const EventVersion = 1
+
+// Event is a domain event kept in the outbox until the relay publishes it.
+// Payload is the protobuf-encoded event from shared/proto/events.
+type Event struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Type    EventType `json:"type"`
+	Version int       `json:"version"`
+	// OrderUUID is used as the message key, so events of an order are consumed in order
+	OrderUUID   uuid.UUID  `json:"order_uuid"`
+	Payload     []byte     `json:"payload"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
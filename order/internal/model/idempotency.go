@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// IdempotencyRecord remembers a request made with an Idempotency-Key and,
+// once it has finished, the response that was returned for it
+type IdempotencyRecord struct {
+	// Operation scopes the key, so the same key may be used for different endpoints
+	Operation   string
+	Key         string
+	Fingerprint string
+	// Completed is false while the original request is still being processed
+	Completed  bool
+	StatusCode int
+	Response   []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
@@ -11,18 +11,82 @@ type OrderStatus string
 
 const (
 	StatusPendingPayment OrderStatus = "PENDING_PAYMENT"
-	StatusPaid           OrderStatus = "PAID"
-	StatusCancelled      OrderStatus = "CANCELLED"
+	// StatusPaymentInProgress marks an order claimed by a pay request; no other
+	// payment or cancellation can start until it leaves this state
+	StatusPaymentInProgress OrderStatus = "PAYMENT_IN_PROGRESS"
+	StatusPaid              OrderStatus = "PAID"
+	// StatusAssembling marks a paid order whose ship is being built by the assembly service
+	StatusAssembling OrderStatus = "ASSEMBLING"
+	StatusCompleted  OrderStatus = "COMPLETED"
+	StatusCancelled  OrderStatus = "CANCELLED"
+	// StatusRefundInProgress marks a paid order claimed by a refund request; it
+	// returns to StatusPaid after a partial refund
+	StatusRefundInProgress OrderStatus = "REFUND_IN_PROGRESS"
+	StatusRefunded         OrderStatus = "REFUNDED"
+)
+
+// CancellationReason tells why an order was cancelled
+type CancellationReason string
+
+const (
+	CancellationReasonUserCancelled CancellationReason = "USER_CANCELLED"
+	// CancellationReasonExpired marks an order cancelled for staying unpaid past its TTL
+	CancellationReasonExpired CancellationReason = "EXPIRED"
 )
 
 // Order represents an order in the service layer
 type Order struct {
-	UUID      uuid.UUID   `json:"uuid"`
-	UserUUID  uuid.UUID   `json:"user_uuid"`
-	PartUUIDs []uuid.UUID `json:"part_uuids"`
-	Status    OrderStatus `json:"status"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	UUID       uuid.UUID   `json:"uuid"`
+	UserUUID   uuid.UUID   `json:"user_uuid"`
+	Items      []OrderItem `json:"items"`
+	TotalPrice float64     `json:"total_price"`
+	Status     OrderStatus `json:"status"`
+	// Payment details, set once the order is paid
+	TransactionUUID *uuid.UUID    `json:"transaction_uuid,omitempty"`
+	PaymentMethod   PaymentMethod `json:"payment_method,omitempty"`
+	PaidAt          *time.Time    `json:"paid_at,omitempty"`
+	// RefundedAmount is the sum of all refunds; RefundedAt is set once the whole amount is refunded
+	RefundedAmount float64    `json:"refunded_amount"`
+	RefundedAt     *time.Time `json:"refunded_at,omitempty"`
+	// Cancellation details, set once the order is cancelled
+	CancellationReason CancellationReason `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time         `json:"cancelled_at,omitempty"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+	// Version is incremented on every successful update and used for optimistic locking
+	Version int64 `json:"version"`
+}
+
+// OrderItem represents a line of an order. UnitPrice is a snapshot of the
+// part price taken when the order was created.
+type OrderItem struct {
+	PartUUID   uuid.UUID `json:"part_uuid"`
+	Quantity   int32     `json:"quantity"`
+	UnitPrice  float64   `json:"unit_price"`
+	TotalPrice float64   `json:"total_price"`
+}
+
+// OrderFilter represents filter criteria for listing orders
+type OrderFilter struct {
+	UserUUID    *uuid.UUID   `json:"user_uuid,omitempty"`
+	Status      *OrderStatus `json:"status,omitempty"`
+	CreatedFrom *time.Time   `json:"created_from,omitempty"`
+	CreatedTo   *time.Time   `json:"created_to,omitempty"`
+	PartUUID    *uuid.UUID   `json:"part_uuid,omitempty"`
+}
+
+// OrderCursor points at the last order of a page; the next page starts right after it
+type OrderCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	UUID      uuid.UUID `json:"uuid"`
+}
+
+// ListOrdersQuery represents a keyset-paginated query for orders.
+// Orders are returned newest first, ordered by (CreatedAt, UUID) descending.
+type ListOrdersQuery struct {
+	Filter OrderFilter  `json:"filter"`
+	After  *OrderCursor `json:"after,omitempty"`
+	Limit  int          `json:"limit"`
 }
 
 // Part represents a part in the service layer
@@ -43,11 +107,24 @@ const (
 
 // CreateOrderRequest represents request to create an order
 type CreateOrderRequest struct {
-	UserUUID  uuid.UUID   `json:"user_uuid"`
-	PartUUIDs []uuid.UUID `json:"part_uuids"`
+	UserUUID uuid.UUID         `json:"user_uuid"`
+	Items    []CreateOrderItem `json:"items"`
+}
+
+// CreateOrderItem represents a requested part and its quantity
+type CreateOrderItem struct {
+	PartUUID uuid.UUID `json:"part_uuid"`
+	Quantity int32     `json:"quantity"`
 }
 
 // PayOrderRequest represents request to pay an order
 type PayOrderRequest struct {
 	PaymentMethod PaymentMethod `json:"payment_method"`
 }
+
+// RefundOrderRequest represents request to refund a paid order. Zero Amount
+// refunds everything not refunded yet.
+type RefundOrderRequest struct {
+	Amount float64 `json:"amount"`
+	Reason string  `json:"reason,omitempty"`
+}
@@ -0,0 +1,66 @@
+package reconciler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/order/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// DefaultPageSize is how many stuck orders are loaded at once on each run
+const DefaultPageSize = 100
+
+// Reconciler periodically settles orders left claimed for payment or refund
+// longer than a threshold, such as after a crash or a lost payment answer
+type Reconciler struct {
+	orderReconciler service.OrderReconciler
+	stuckAfter      time.Duration
+	interval        time.Duration
+	pageSize        int
+	now             func() time.Time
+}
+
+// NewReconciler creates a reconciler that checks for orders claimed longer
+// than stuckAfter every interval
+func NewReconciler(orderReconciler service.OrderReconciler, stuckAfter, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		orderReconciler: orderReconciler,
+		stuckAfter:      stuckAfter,
+		interval:        interval,
+		pageSize:        DefaultPageSize,
+		now:             time.Now,
+	}
+}
+
+// Run reconciles orders until ctx is cancelled
+func (r *Reconciler) Run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	log.Info("Order reconciler started", slog.Duration("stuck_after", r.stuckAfter), slog.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.reconcile(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Info("Order reconciler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile runs a single pass over stuck orders; failures are logged and retried on the next tick
+func (r *Reconciler) reconcile(ctx context.Context) {
+	settled, err := r.orderReconciler.ReconcileOrders(ctx, r.now().Add(-r.stuckAfter), r.pageSize)
+	if err != nil && ctx.Err() == nil {
+		logger.FromContext(ctx).Error("Failed to reconcile stuck orders", logger.Err(err))
+	}
+	if settled > 0 {
+		logger.FromContext(ctx).Info("Reconciled stuck orders", slog.Int("count", settled))
+	}
+}
@@ -0,0 +1,39 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+)
+
+func TestReconciler_RunReconcilesUntilCancelled(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	orderReconciler := servicemocks.NewOrderReconciler(t)
+	orderReconciler.On("ReconcileOrders", mock.Anything, now.Add(-5*time.Minute), DefaultPageSize).
+		Return(0, errors.New("storage failure")).Once()
+	orderReconciler.On("ReconcileOrders", mock.Anything, now.Add(-5*time.Minute), DefaultPageSize).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(1, nil).Once()
+
+	r := NewReconciler(orderReconciler, 5*time.Minute, time.Millisecond)
+	r.now = func() time.Time { return now }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reconciler did not stop after the context was cancelled")
+	}
+}
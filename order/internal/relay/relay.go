@@ -0,0 +1,135 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// DefaultBatchSize is how many outbox events are published at once
+const DefaultBatchSize = 100
+
+// Relay periodically publishes domain events from the outbox to a broker.
+// Events are marked published only after the broker acknowledged them, so an
+// event may be published again after a failure: delivery is at least once and
+// consumers drop duplicates by the event-id header.
+type Relay struct {
+	outboxRepo repository.OutboxRepository
+	publisher  broker.Publisher
+	topic      string
+	interval   time.Duration
+	retention  time.Duration
+	batchSize  int
+	now        func() time.Time
+}
+
+// NewRelay creates a relay that publishes events to topic every interval and
+// keeps published events in the outbox for retention
+func NewRelay(outboxRepo repository.OutboxRepository, publisher broker.Publisher, topic string, interval, retention time.Duration) *Relay {
+	return &Relay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		topic:      topic,
+		interval:   interval,
+		retention:  retention,
+		batchSize:  DefaultBatchSize,
+		now:        time.Now,
+	}
+}
+
+// Run publishes events until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	log.Info("Outbox relay started", slog.String("topic", r.topic), slog.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.relay(ctx)
+
+		select {
+		case <-ctx.Done():
+			log.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay drains the outbox batch by batch and drops old published events;
+// failures are logged and retried on the next tick
+func (r *Relay) relay(ctx context.Context) {
+	for {
+		published, err := r.publishBatch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.FromContext(ctx).Error("Failed to relay outbox events", logger.Err(err))
+			}
+			return
+		}
+		if published < r.batchSize {
+			break
+		}
+	}
+
+	deleted, err := r.outboxRepo.DeletePublished(ctx, r.now().Add(-r.retention))
+	if err != nil && ctx.Err() == nil {
+		logger.FromContext(ctx).Error("Failed to delete published outbox events", logger.Err(err))
+	}
+	if deleted > 0 {
+		logger.FromContext(ctx).Debug("Deleted published outbox events", slog.Int("count", deleted))
+	}
+}
+
+// publishBatch publishes the oldest unpublished events in one call to the
+// broker, so their order is kept, and returns how many were published
+func (r *Relay) publishBatch(ctx context.Context) (int, error) {
+	events, err := r.outboxRepo.ListUnpublished(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list unpublished events: %w", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	msgs := make([]broker.Message, len(events))
+	eventUUIDs := make([]uuid.UUID, len(events))
+	for i, event := range events {
+		msgs[i] = toMessage(r.topic, event)
+		eventUUIDs[i] = event.UUID
+	}
+
+	if err := r.publisher.Publish(ctx, msgs...); err != nil {
+		return 0, fmt.Errorf("publish %d events: %w", len(events), err)
+	}
+
+	if err := r.outboxRepo.MarkPublished(ctx, eventUUIDs, r.now()); err != nil {
+		return 0, fmt.Errorf("mark %d events published: %w", len(events), err)
+	}
+
+	return len(events), nil
+}
+
+// toMessage converts an outbox event to a broker message keyed by order UUID
+func toMessage(topic string, event *model.Event) broker.Message {
+	return broker.Message{
+		Topic: topic,
+		Key:   []byte(event.OrderUUID.String()),
+		Value: event.Payload,
+		Headers: map[string]string{
+			broker.HeaderEventID:      event.UUID.String(),
+			broker.HeaderEventType:    string(event.Type),
+			broker.HeaderEventVersion: strconv.Itoa(event.Version),
+		},
+	}
+}
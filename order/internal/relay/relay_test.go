@@ -0,0 +1,110 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/outbox"
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+)
+
+const testTopic = "order.events"
+
+// failingPublisher rejects every publish, like a broker that is down
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, ...broker.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func addTestEvents(t *testing.T, repo *outbox.MemoryOutboxRepository, n int) []*model.Event {
+	t.Helper()
+
+	events := make([]*model.Event, n)
+	for i := range events {
+		events[i] = &model.Event{
+			UUID:       uuid.New(),
+			Type:       model.EventTypeOrderPaid,
+			Version:    model.EventVersion,
+			OrderUUID:  uuid.New(),
+			Payload:    []byte{byte(i)},
+			OccurredAt: time.Now(),
+		}
+	}
+	require.NoError(t, repo.Add(context.Background(), events...))
+
+	return events
+}
+
+func TestRelay_PublishesAllBatchesInOrder(t *testing.T) {
+	ctx := context.Background()
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+	events := addTestEvents(t, outboxRepo, 5)
+	memoryBroker := broker.NewMemoryBroker()
+
+	r := NewRelay(outboxRepo, memoryBroker, testTopic, time.Minute, time.Hour)
+	r.batchSize = 2
+	r.relay(ctx)
+
+	msgs := memoryBroker.Messages(testTopic)
+	require.Len(t, msgs, len(events))
+	for i, msg := range msgs {
+		require.Equal(t, events[i].UUID.String(), msg.Headers[broker.HeaderEventID])
+		require.Equal(t, "OrderPaid", msg.Headers[broker.HeaderEventType])
+		require.Equal(t, "1", msg.Headers[broker.HeaderEventVersion])
+		require.Equal(t, []byte(events[i].OrderUUID.String()), msg.Key)
+		require.Equal(t, events[i].Payload, msg.Value)
+	}
+
+	pending, err := outboxRepo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	// A second run has nothing left to publish
+	r.relay(ctx)
+	require.Len(t, memoryBroker.Messages(testTopic), len(events))
+}
+
+func TestRelay_KeepsEventsWhenPublishFails(t *testing.T) {
+	ctx := context.Background()
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+	events := addTestEvents(t, outboxRepo, 2)
+
+	NewRelay(outboxRepo, failingPublisher{}, testTopic, time.Minute, time.Hour).relay(ctx)
+
+	pending, err := outboxRepo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, len(events), "events must be retried after a failed publish")
+
+	memoryBroker := broker.NewMemoryBroker()
+	NewRelay(outboxRepo, memoryBroker, testTopic, time.Minute, time.Hour).relay(ctx)
+	require.Len(t, memoryBroker.Messages(testTopic), len(events))
+}
+
+func TestRelay_DeletesEventsPastRetention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+	addTestEvents(t, outboxRepo, 3)
+
+	r := NewRelay(outboxRepo, broker.NewMemoryBroker(), testTopic, time.Minute, time.Hour)
+	r.now = func() time.Time { return now }
+	r.relay(ctx)
+
+	deleted, err := outboxRepo.DeletePublished(ctx, now)
+	require.NoError(t, err)
+	require.Zero(t, deleted, "events within retention are kept")
+
+	r.now = func() time.Time { return now.Add(2 * time.Hour) }
+	r.relay(ctx)
+
+	deleted, err = outboxRepo.DeletePublished(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, deleted, "the relay has already deleted events past retention")
+}
@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomodel "github.com/nimbodex/microservices-factory/order/internal/repository/model"
+)
+
+// ToRepoEvent converts service model to repository model
+func ToRepoEvent(event *model.Event) *repomodel.Event {
+	if event == nil {
+		return nil
+	}
+
+	return &repomodel.Event{
+		UUID:        event.UUID.String(),
+		Type:        string(event.Type),
+		Version:     event.Version,
+		OrderUUID:   event.OrderUUID.String(),
+		Payload:     event.Payload,
+		OccurredAt:  event.OccurredAt,
+		PublishedAt: event.PublishedAt,
+	}
+}
+
+// FromRepoEvent converts repository model to service model
+func FromRepoEvent(repoEvent *repomodel.Event) (*model.Event, error) {
+	if repoEvent == nil {
+		return nil, fmt.Errorf("repoEvent cannot be nil")
+	}
+
+	eventUUID, err := uuid.Parse(repoEvent.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUUID, err := uuid.Parse(repoEvent.OrderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Event{
+		UUID:        eventUUID,
+		Type:        model.EventType(repoEvent.Type),
+		Version:     repoEvent.Version,
+		OrderUUID:   orderUUID,
+		Payload:     repoEvent.Payload,
+		OccurredAt:  repoEvent.OccurredAt,
+		PublishedAt: repoEvent.PublishedAt,
+	}, nil
+}
@@ -15,18 +15,36 @@ func ToRepoOrder(order *model.Order) *repomodel.Order {
 		return nil
 	}
 
-	partUUIDs := make([]string, len(order.PartUUIDs))
-	for i, uuid := range order.PartUUIDs {
-		partUUIDs[i] = uuid.String()
+	items := make([]repomodel.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = repomodel.OrderItem{
+			PartUUID:  item.PartUUID.String(),
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		}
+	}
+
+	var transactionUUID string
+	if order.TransactionUUID != nil {
+		transactionUUID = order.TransactionUUID.String()
 	}
 
 	return &repomodel.Order{
-		UUID:      order.UUID.String(),
-		UserUUID:  order.UserUUID.String(),
-		PartUUIDs: partUUIDs,
-		Status:    string(order.Status),
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
+		UUID:               order.UUID.String(),
+		UserUUID:           order.UserUUID.String(),
+		Items:              items,
+		TotalPrice:         order.TotalPrice,
+		Status:             string(order.Status),
+		TransactionUUID:    transactionUUID,
+		PaymentMethod:      string(order.PaymentMethod),
+		PaidAt:             order.PaidAt,
+		RefundedAmount:     order.RefundedAmount,
+		RefundedAt:         order.RefundedAt,
+		CancellationReason: string(order.CancellationReason),
+		CancelledAt:        order.CancelledAt,
+		CreatedAt:          order.CreatedAt,
+		UpdatedAt:          order.UpdatedAt,
+		Version:            order.Version,
 	}
 }
 
@@ -46,22 +64,45 @@ func FromRepoOrder(repoOrder *repomodel.Order) (*model.Order, error) {
 		return nil, err
 	}
 
-	partUUIDs := make([]uuid.UUID, len(repoOrder.PartUUIDs))
-	for i, uuidStr := range repoOrder.PartUUIDs {
-		partUUID, err := uuid.Parse(uuidStr)
+	items := make([]model.OrderItem, len(repoOrder.Items))
+	for i, item := range repoOrder.Items {
+		partUUID, err := uuid.Parse(item.PartUUID)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = model.OrderItem{
+			PartUUID:   partUUID,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice,
+			TotalPrice: item.UnitPrice * float64(item.Quantity),
+		}
+	}
+
+	var transactionUUID *uuid.UUID
+	if repoOrder.TransactionUUID != "" {
+		parsed, err := uuid.Parse(repoOrder.TransactionUUID)
 		if err != nil {
 			return nil, err
 		}
-		partUUIDs[i] = partUUID
+		transactionUUID = &parsed
 	}
 
 	return &model.Order{
-		UUID:      orderUUID,
-		UserUUID:  userUUID,
-		PartUUIDs: partUUIDs,
-		Status:    model.OrderStatus(repoOrder.Status),
-		CreatedAt: repoOrder.CreatedAt,
-		UpdatedAt: repoOrder.UpdatedAt,
+		UUID:               orderUUID,
+		UserUUID:           userUUID,
+		Items:              items,
+		TotalPrice:         repoOrder.TotalPrice,
+		Status:             model.OrderStatus(repoOrder.Status),
+		TransactionUUID:    transactionUUID,
+		PaymentMethod:      model.PaymentMethod(repoOrder.PaymentMethod),
+		PaidAt:             repoOrder.PaidAt,
+		RefundedAmount:     repoOrder.RefundedAmount,
+		RefundedAt:         repoOrder.RefundedAt,
+		CancellationReason: model.CancellationReason(repoOrder.CancellationReason),
+		CancelledAt:        repoOrder.CancelledAt,
+		CreatedAt:          repoOrder.CreatedAt,
+		UpdatedAt:          repoOrder.UpdatedAt,
+		Version:            repoOrder.Version,
 	}, nil
 }
 
@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+// MemoryIdempotencyRepository implements IdempotencyRepository using in-memory storage
+type MemoryIdempotencyRepository struct {
+	mu        sync.Mutex
+	records   map[recordKey]*model.IdempotencyRecord
+	retention time.Duration
+	nextPurge time.Time
+	now       func() time.Time
+}
+
+// recordKey identifies a record by operation and client key
+type recordKey struct {
+	operation string
+	key       string
+}
+
+// NewMemoryIdempotencyRepository creates a new in-memory idempotency repository
+// that keeps records for the given retention window
+func NewMemoryIdempotencyRepository(retention time.Duration) *MemoryIdempotencyRepository {
+	return &MemoryIdempotencyRepository{
+		records:   make(map[recordKey]*model.IdempotencyRecord),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Reserve stores the record as in progress unless a live record already exists
+func (r *MemoryIdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.purgeExpired(now)
+
+	k := recordKey{operation: record.Operation, key: record.Key}
+	if existing, exists := r.records[k]; exists && now.Before(existing.ExpiresAt) {
+		// Return a copy to avoid external modifications
+		existingCopy := *existing
+		return &existingCopy, nil
+	}
+
+	recordCopy := *record
+	recordCopy.Completed = false
+	recordCopy.StatusCode = 0
+	recordCopy.Response = nil
+	recordCopy.CreatedAt = now
+	recordCopy.ExpiresAt = now.Add(r.retention)
+	r.records[k] = &recordCopy
+
+	return nil, nil
+}
+
+// Complete stores the response of a reserved record
+func (r *MemoryIdempotencyRepository) Complete(ctx context.Context, operation, key string, statusCode int, response []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.records[recordKey{operation: operation, key: key}]
+	if !exists {
+		return fmt.Errorf("idempotency key %s for %s not found", key, operation)
+	}
+
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.Response = append([]byte(nil), response...)
+
+	return nil
+}
+
+// Release drops a reserved record
+func (r *MemoryIdempotencyRepository) Release(ctx context.Context, operation, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, recordKey{operation: operation, key: key})
+
+	return nil
+}
+
+// purgeExpired drops expired records at most once per retention window,
+// so the sweep cost is amortised over many requests. Must be called with mu held.
+func (r *MemoryIdempotencyRepository) purgeExpired(now time.Time) {
+	if now.Before(r.nextPurge) {
+		return
+	}
+
+	for k, record := range r.records {
+		if !now.Before(record.ExpiresAt) {
+			delete(r.records, k)
+		}
+	}
+
+	r.nextPurge = now.Add(r.retention)
+}
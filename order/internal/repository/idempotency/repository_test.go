@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+func TestMemoryIdempotencyRepository_ReserveCompleteAndExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := NewMemoryIdempotencyRepository(time.Hour)
+	repo.now = func() time.Time { return now }
+
+	record := &model.IdempotencyRecord{Operation: "createOrder", Key: "key-1", Fingerprint: "fp"}
+
+	existing, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.Completed)
+
+	// The same key for another operation is independent
+	existing, err = repo.Reserve(ctx, &model.IdempotencyRecord{Operation: "payOrder", Key: "key-1", Fingerprint: "fp"})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	require.NoError(t, repo.Complete(ctx, "createOrder", "key-1", 201, []byte(`{"ok":true}`)))
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, []byte(`{"ok":true}`), existing.Response)
+	assert.Equal(t, now.Add(time.Hour), existing.ExpiresAt)
+
+	now = now.Add(time.Hour)
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing, "expired record must not be replayed")
+}
+
+func TestMemoryIdempotencyRepository_Release(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryIdempotencyRepository(time.Hour)
+	record := &model.IdempotencyRecord{Operation: "payOrder", Key: "key-1", Fingerprint: "fp"}
+
+	_, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NoError(t, repo.Release(ctx, "payOrder", "key-1"))
+
+	existing, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	assert.Error(t, repo.Complete(ctx, "payOrder", "missing", 200, nil))
+}
@@ -0,0 +1,197 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/sqlutil"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+// SQLIdempotencyRepository implements IdempotencyRepository on top of
+// PostgreSQL, so keys are shared by all replicas and survive restarts
+type SQLIdempotencyRepository struct {
+	txManager *txmanager.Manager
+	retention time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	nextPurge time.Time
+}
+
+// NewSQLIdempotencyRepository creates a new SQL idempotency repository that
+// keeps records for the given retention window
+func NewSQLIdempotencyRepository(txManager *txmanager.Manager, retention time.Duration) *SQLIdempotencyRepository {
+	return &SQLIdempotencyRepository{
+		txManager: txManager,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Reserve stores the record as in progress unless a live record already exists.
+// An expired record with the same key is replaced in place.
+func (r *SQLIdempotencyRepository) Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	if record == nil {
+		return nil, fmt.Errorf("record cannot be nil")
+	}
+
+	now := r.now()
+	if err := r.purgeExpired(ctx, now); err != nil {
+		return nil, err
+	}
+
+	var existing *model.IdempotencyRecord
+	err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		db := r.txManager.Executor(ctx)
+
+		result, err := db.ExecContext(ctx, `
+			INSERT INTO idempotency_keys (operation, idempotency_key, fingerprint, completed, status_code, response, created_at, expires_at)
+			VALUES ($1, $2, $3, FALSE, 0, NULL, $4, $5)
+			ON CONFLICT (operation, idempotency_key) DO UPDATE
+			SET fingerprint = excluded.fingerprint,
+				completed = FALSE,
+				status_code = 0,
+				response = NULL,
+				created_at = excluded.created_at,
+				expires_at = excluded.expires_at
+			WHERE idempotency_keys.expires_at <= excluded.created_at`,
+			record.Operation,
+			record.Key,
+			record.Fingerprint,
+			sqlutil.Time(now),
+			sqlutil.Time(now.Add(r.retention)),
+		)
+		if err != nil {
+			return fmt.Errorf("reserve idempotency key %s for %s: %w", record.Key, record.Operation, err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("reserve idempotency key %s for %s: %w", record.Key, record.Operation, err)
+		}
+		if affected > 0 {
+			return nil
+		}
+
+		// A live record holds the key
+		existing, err = r.get(ctx, record.Operation, record.Key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of a reserved record
+func (r *SQLIdempotencyRepository) Complete(ctx context.Context, operation, key string, statusCode int, response []byte) error {
+	db := r.txManager.Executor(ctx)
+
+	result, err := db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET completed = TRUE, status_code = $1, response = $2
+		WHERE operation = $3 AND idempotency_key = $4`,
+		statusCode,
+		response,
+		operation,
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key %s for %s: %w", key, operation, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("complete idempotency key %s for %s: %w", key, operation, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("idempotency key %s for %s not found", key, operation)
+	}
+
+	return nil
+}
+
+// Release drops a reserved record
+func (r *SQLIdempotencyRepository) Release(ctx context.Context, operation, key string) error {
+	db := r.txManager.Executor(ctx)
+
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE operation = $1 AND idempotency_key = $2`,
+		operation,
+		key,
+	)
+	if err != nil {
+		return fmt.Errorf("release idempotency key %s for %s: %w", key, operation, err)
+	}
+
+	return nil
+}
+
+func (r *SQLIdempotencyRepository) get(ctx context.Context, operation, key string) (*model.IdempotencyRecord, error) {
+	db := r.txManager.Executor(ctx)
+
+	var (
+		record               model.IdempotencyRecord
+		createdAt, expiresAt sqlutil.Timestamp
+	)
+	err := db.QueryRowContext(ctx, `
+		SELECT operation, idempotency_key, fingerprint, completed, status_code, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE operation = $1 AND idempotency_key = $2`,
+		operation,
+		key,
+	).Scan(
+		&record.Operation,
+		&record.Key,
+		&record.Fingerprint,
+		&record.Completed,
+		&record.StatusCode,
+		&record.Response,
+		&createdAt,
+		&expiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released between the insert and the select; the client retries
+		return nil, fmt.Errorf("idempotency key %s for %s was released concurrently", key, operation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select idempotency key %s for %s: %w", key, operation, err)
+	}
+	record.CreatedAt = createdAt.Time
+	record.ExpiresAt = expiresAt.Time
+
+	return &record, nil
+}
+
+// purgeExpired drops expired records at most once per retention window,
+// so the sweep cost is amortised over many requests
+func (r *SQLIdempotencyRepository) purgeExpired(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Before(r.nextPurge) {
+		return nil
+	}
+
+	db := r.txManager.Executor(ctx)
+
+	_, err := db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= $1`,
+		sqlutil.Time(now),
+	)
+	if err != nil {
+		return fmt.Errorf("purge expired idempotency keys: %w", err)
+	}
+
+	r.nextPurge = now.Add(r.retention)
+	return nil
+}
@@ -0,0 +1,96 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/migrations"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+// newTestSQLRepository runs the embedded migrations on an in-memory SQLite database
+func newTestSQLRepository(t *testing.T, retention time.Duration) *SQLIdempotencyRepository {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, migrations.Up(context.Background(), db, goose.DialectSQLite3))
+
+	return NewSQLIdempotencyRepository(txmanager.NewManager(db), retention)
+}
+
+func TestSQLIdempotencyRepository_ReserveCompleteAndExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := newTestSQLRepository(t, time.Hour)
+	repo.now = func() time.Time { return now }
+
+	record := &model.IdempotencyRecord{Operation: "createOrder", Key: "key-1", Fingerprint: "fp"}
+
+	existing, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.Completed)
+	assert.Equal(t, "fp", existing.Fingerprint)
+
+	// The same key for another operation is independent
+	existing, err = repo.Reserve(ctx, &model.IdempotencyRecord{Operation: "payOrder", Key: "key-1", Fingerprint: "fp"})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	require.NoError(t, repo.Complete(ctx, "createOrder", "key-1", 201, []byte(`{"ok":true}`)))
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, []byte(`{"ok":true}`), existing.Response)
+	assert.True(t, now.Add(time.Hour).Equal(existing.ExpiresAt))
+
+	now = now.Add(time.Hour)
+
+	existing, err = repo.Reserve(ctx, &model.IdempotencyRecord{Operation: "createOrder", Key: "key-1", Fingerprint: "fp-2"})
+	require.NoError(t, err)
+	assert.Nil(t, existing, "expired record must not be replayed")
+
+	existing, err = repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.Completed, "expired record must be replaced")
+	assert.Equal(t, "fp-2", existing.Fingerprint)
+	assert.Nil(t, existing.Response)
+}
+
+func TestSQLIdempotencyRepository_Release(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestSQLRepository(t, time.Hour)
+	record := &model.IdempotencyRecord{Operation: "payOrder", Key: "key-1", Fingerprint: "fp"}
+
+	_, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.NoError(t, repo.Release(ctx, "payOrder", "key-1"))
+
+	existing, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	assert.Error(t, repo.Complete(ctx, "payOrder", "missing", 200, nil))
+}
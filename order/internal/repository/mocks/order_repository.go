@@ -82,9 +82,9 @@ func (_m *OrderRepository) GetByUUID(ctx context.Context, _a1 uuid.UUID) (*model
 	return r0, r1
 }
 
-// List provides a mock function with given fields: ctx, limit, offset
-func (_m *OrderRepository) List(ctx context.Context, limit int, offset int) ([]*model.Order, error) {
-	ret := _m.Called(ctx, limit, offset)
+// List provides a mock function with given fields: ctx, query
+func (_m *OrderRepository) List(ctx context.Context, query *model.ListOrdersQuery) ([]*model.Order, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -92,19 +92,19 @@ func (_m *OrderRepository) List(ctx context.Context, limit int, offset int) ([]*
 
 	var r0 []*model.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.Order, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListOrdersQuery) ([]*model.Order, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*model.Order); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ListOrdersQuery) []*model.Order); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ListOrdersQuery) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
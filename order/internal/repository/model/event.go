@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// Event represents an outbox event in the repository layer
+type Event struct {
+	UUID        string     `json:"uuid"`
+	Type        string     `json:"type"`
+	Version     int        `json:"version"`
+	OrderUUID   string     `json:"order_uuid"`
+	Payload     []byte     `json:"payload"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
@@ -6,12 +6,30 @@ import (
 
 // Order represents an order in the repository layer
 type Order struct {
-	UUID      string    `json:"uuid"`
-	UserUUID  string    `json:"user_uuid"`
-	PartUUIDs []string  `json:"part_uuids"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UUID       string      `json:"uuid"`
+	UserUUID   string      `json:"user_uuid"`
+	Items      []OrderItem `json:"items"`
+	TotalPrice float64     `json:"total_price"`
+	Status     string      `json:"status"`
+	// Payment details, empty until the order is paid
+	TransactionUUID string     `json:"transaction_uuid,omitempty"`
+	PaymentMethod   string     `json:"payment_method,omitempty"`
+	PaidAt          *time.Time `json:"paid_at,omitempty"`
+	RefundedAmount  float64    `json:"refunded_amount"`
+	RefundedAt      *time.Time `json:"refunded_at,omitempty"`
+	// Cancellation details, empty until the order is cancelled
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	Version            int64      `json:"version"`
+}
+
+// OrderItem represents an order line in the repository layer
+type OrderItem struct {
+	PartUUID  string  `json:"part_uuid"`
+	Quantity  int32   `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
 }
 
 // Part represents a part in the repository layer
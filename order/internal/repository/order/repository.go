@@ -1,9 +1,12 @@
 package order
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,6 +17,14 @@ import (
 type MemoryOrderRepository struct {
 	mu     sync.RWMutex
 	orders map[string]*model.Order
+	// index keeps orders sorted newest first so that List can seek to a cursor
+	index []indexKey
+}
+
+// indexKey is the sort key of an order in the listing index
+type indexKey struct {
+	createdAt time.Time
+	uuid      uuid.UUID
 }
 
 // NewMemoryOrderRepository creates a new in-memory order repository
@@ -40,6 +51,7 @@ func (r *MemoryOrderRepository) Create(ctx context.Context, order *model.Order)
 	// Create a copy to avoid external modifications
 	orderCopy := *order
 	r.orders[orderKey] = &orderCopy
+	r.insertIndex(keyOf(order))
 
 	return nil
 }
@@ -51,7 +63,7 @@ func (r *MemoryOrderRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (
 
 	order, exists := r.orders[uuid.String()]
 	if !exists {
-		return nil, fmt.Errorf("order with UUID %s not found", uuid)
+		return nil, fmt.Errorf("order with UUID %s: %w", uuid, model.ErrOrderNotFound)
 	}
 
 	// Return a copy to avoid external modifications
@@ -59,7 +71,7 @@ func (r *MemoryOrderRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (
 	return &orderCopy, nil
 }
 
-// Update updates an existing order
+// Update updates an existing order if its version matches the stored one
 func (r *MemoryOrderRepository) Update(ctx context.Context, order *model.Order) error {
 	if order == nil {
 		return fmt.Errorf("order cannot be nil")
@@ -69,8 +81,20 @@ func (r *MemoryOrderRepository) Update(ctx context.Context, order *model.Order)
 	defer r.mu.Unlock()
 
 	orderKey := order.UUID.String()
-	if _, exists := r.orders[orderKey]; !exists {
-		return fmt.Errorf("order with UUID %s not found", order.UUID)
+	existing, exists := r.orders[orderKey]
+	if !exists {
+		return fmt.Errorf("order with UUID %s: %w", order.UUID, model.ErrOrderNotFound)
+	}
+
+	if existing.Version != order.Version {
+		return fmt.Errorf("order with UUID %s: %w", order.UUID, model.ErrVersionConflict)
+	}
+
+	order.Version++
+
+	if !existing.CreatedAt.Equal(order.CreatedAt) {
+		r.removeIndex(keyOf(existing))
+		r.insertIndex(keyOf(order))
 	}
 
 	// Create a copy to avoid external modifications
@@ -86,42 +110,112 @@ func (r *MemoryOrderRepository) Delete(ctx context.Context, uuid uuid.UUID) erro
 	defer r.mu.Unlock()
 
 	orderKey := uuid.String()
-	if _, exists := r.orders[orderKey]; !exists {
-		return fmt.Errorf("order with UUID %s not found", uuid)
+	existing, exists := r.orders[orderKey]
+	if !exists {
+		return fmt.Errorf("order with UUID %s: %w", uuid, model.ErrOrderNotFound)
 	}
 
+	r.removeIndex(keyOf(existing))
 	delete(r.orders, orderKey)
 	return nil
 }
 
-// List retrieves orders with pagination
-func (r *MemoryOrderRepository) List(ctx context.Context, limit, offset int) ([]*model.Order, error) {
+// List retrieves orders matching the query, newest first, starting right after query.After
+func (r *MemoryOrderRepository) List(ctx context.Context, query *model.ListOrdersQuery) ([]*model.Order, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	orders := make([]*model.Order, 0, len(r.orders))
-	for _, order := range r.orders {
-		orders = append(orders, order)
+	filter := query.Filter
+	start := 0
+	if query.After != nil {
+		after := indexKey{createdAt: query.After.CreatedAt, uuid: query.After.UUID}
+		start = sort.Search(len(r.index), func(i int) bool {
+			return before(after, r.index[i])
+		})
+	}
+	if filter.CreatedTo != nil {
+		// Skip everything created at or after the upper bound
+		createdTo := *filter.CreatedTo
+		start = max(start, sort.Search(len(r.index), func(i int) bool {
+			return r.index[i].createdAt.Before(createdTo)
+		}))
 	}
 
-	// Simple pagination (in real implementation, you'd want to sort by created_at desc)
-	start := offset
-	end := offset + limit
+	result := make([]*model.Order, 0, query.Limit)
+	for _, key := range r.index[start:] {
+		if len(result) >= query.Limit {
+			break
+		}
+		if filter.CreatedFrom != nil && key.createdAt.Before(*filter.CreatedFrom) {
+			// The index is sorted, so no older order can match either
+			break
+		}
+
+		order := r.orders[key.uuid.String()]
+		if !matchesFilter(order, &filter) {
+			continue
+		}
+
+		// Return copies to avoid external modifications
+		orderCopy := *order
+		result = append(result, &orderCopy)
+	}
 
-	if start >= len(orders) {
-		return []*model.Order{}, nil
+	return result, nil
+}
+
+func keyOf(order *model.Order) indexKey {
+	return indexKey{createdAt: order.CreatedAt, uuid: order.UUID}
+}
+
+// before reports whether a is listed before b: newer orders first, ties broken by UUID descending
+func before(a, b indexKey) bool {
+	if !a.createdAt.Equal(b.createdAt) {
+		return a.createdAt.After(b.createdAt)
 	}
+	return bytes.Compare(a.uuid[:], b.uuid[:]) > 0
+}
+
+func (r *MemoryOrderRepository) insertIndex(key indexKey) {
+	i := sort.Search(len(r.index), func(i int) bool {
+		return !before(r.index[i], key)
+	})
+	r.index = append(r.index, indexKey{})
+	copy(r.index[i+1:], r.index[i:])
+	r.index[i] = key
+}
 
-	if end > len(orders) {
-		end = len(orders)
+func (r *MemoryOrderRepository) removeIndex(key indexKey) {
+	i := sort.Search(len(r.index), func(i int) bool {
+		return !before(r.index[i], key)
+	})
+	if i < len(r.index) && r.index[i].uuid == key.uuid {
+		r.index = append(r.index[:i], r.index[i+1:]...)
 	}
+}
 
-	// Return copies to avoid external modifications
-	result := make([]*model.Order, end-start)
-	for i, order := range orders[start:end] {
-		orderCopy := *order
-		result[i] = &orderCopy
+func matchesFilter(order *model.Order, filter *model.OrderFilter) bool {
+	if filter.UserUUID != nil && order.UserUUID != *filter.UserUUID {
+		return false
+	}
+	if filter.Status != nil && order.Status != *filter.Status {
+		return false
+	}
+	if filter.PartUUID != nil && !containsPart(order, *filter.PartUUID) {
+		return false
 	}
+	return true
+}
 
-	return result, nil
+func containsPart(order *model.Order, partUUID uuid.UUID) bool {
+	for _, item := range order.Items {
+		if item.PartUUID == partUUID {
+			return true
+		}
+	}
+	return false
 }
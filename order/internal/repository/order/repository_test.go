@@ -0,0 +1,141 @@
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+func TestMemoryOrderRepository_ListPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryOrderRepository()
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	userUUID := uuid.New()
+
+	// Two orders share a timestamp to exercise the UUID tie-breaker
+	var created []*model.Order
+	for i, offset := range []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		order := &model.Order{
+			UUID:      uuid.New(),
+			UserUUID:  userUUID,
+			Status:    model.StatusPendingPayment,
+			CreatedAt: base.Add(offset),
+		}
+		if i%2 == 1 {
+			order.UserUUID = uuid.New()
+		}
+		require.NoError(t, repo.Create(ctx, order))
+		created = append(created, order)
+	}
+
+	var seen []uuid.UUID
+	query := &model.ListOrdersQuery{Limit: 2}
+	for {
+		page, err := repo.List(ctx, query)
+		require.NoError(t, err)
+		for _, order := range page {
+			seen = append(seen, order.UUID)
+		}
+		if len(page) < query.Limit {
+			break
+		}
+		last := page[len(page)-1]
+		query.After = &model.OrderCursor{CreatedAt: last.CreatedAt, UUID: last.UUID}
+	}
+
+	require.Len(t, seen, len(created))
+	for i := 1; i < len(seen); i++ {
+		prev, _ := repo.GetByUUID(ctx, seen[i-1])
+		cur, _ := repo.GetByUUID(ctx, seen[i])
+		require.False(t, cur.CreatedAt.After(prev.CreatedAt), "orders must be newest first")
+	}
+}
+
+func TestMemoryOrderRepository_ListFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryOrderRepository()
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	userUUID := uuid.New()
+	partUUID := uuid.New()
+
+	matching := &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  userUUID,
+		Items:     []model.OrderItem{{PartUUID: partUUID, Quantity: 1}},
+		Status:    model.StatusPaid,
+		CreatedAt: base.Add(time.Hour),
+	}
+	orders := []*model.Order{
+		matching,
+		{UUID: uuid.New(), UserUUID: userUUID, Items: []model.OrderItem{{PartUUID: partUUID, Quantity: 1}}, Status: model.StatusPaid, CreatedAt: base.Add(-time.Hour)},
+		{UUID: uuid.New(), UserUUID: userUUID, Items: []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}}, Status: model.StatusPaid, CreatedAt: base.Add(time.Hour)},
+		{UUID: uuid.New(), UserUUID: userUUID, Items: []model.OrderItem{{PartUUID: partUUID, Quantity: 1}}, Status: model.StatusCancelled, CreatedAt: base.Add(time.Hour)},
+		{UUID: uuid.New(), UserUUID: uuid.New(), Items: []model.OrderItem{{PartUUID: partUUID, Quantity: 1}}, Status: model.StatusPaid, CreatedAt: base.Add(time.Hour)},
+		{UUID: uuid.New(), UserUUID: userUUID, Items: []model.OrderItem{{PartUUID: partUUID, Quantity: 1}}, Status: model.StatusPaid, CreatedAt: base.Add(3 * time.Hour)},
+	}
+	for _, order := range orders {
+		require.NoError(t, repo.Create(ctx, order))
+	}
+
+	status := model.StatusPaid
+	createdFrom := base
+	createdTo := base.Add(2 * time.Hour)
+
+	result, err := repo.List(ctx, &model.ListOrdersQuery{
+		Filter: model.OrderFilter{
+			UserUUID:    &userUUID,
+			Status:      &status,
+			CreatedFrom: &createdFrom,
+			CreatedTo:   &createdTo,
+			PartUUID:    &partUUID,
+		},
+		Limit: 10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, matching.UUID, result[0].UUID)
+}
+
+func TestMemoryOrderRepository_DeleteRemovesFromListing(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryOrderRepository()
+
+	order := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, order))
+	require.NoError(t, repo.Delete(ctx, order.UUID))
+
+	result, err := repo.List(ctx, &model.ListOrdersQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestMemoryOrderRepository_UpdateVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryOrderRepository()
+
+	order := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), Status: model.StatusPendingPayment, CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, order))
+
+	first, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	second, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+
+	first.Status = model.StatusPaymentInProgress
+	require.NoError(t, repo.Update(ctx, first))
+	require.Equal(t, int64(1), first.Version)
+
+	second.Status = model.StatusCancelled
+	require.ErrorIs(t, repo.Update(ctx, second), model.ErrVersionConflict)
+
+	stored, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, model.StatusPaymentInProgress, stored.Status)
+	require.Equal(t, int64(1), stored.Version)
+}
@@ -0,0 +1,398 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/converter"
+	repomodel "github.com/nimbodex/microservices-factory/order/internal/repository/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/sqlutil"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+const orderColumns = `uuid, user_uuid, total_price, status, transaction_uuid, payment_method,
+	paid_at, refunded_amount, refunded_at, cancellation_reason, cancelled_at,
+	created_at, updated_at, version`
+
+// SQLOrderRepository implements OrderRepository on top of PostgreSQL.
+// Queries stick to portable SQL so the repository also runs on SQLite in tests.
+type SQLOrderRepository struct {
+	txManager *txmanager.Manager
+}
+
+// NewSQLOrderRepository creates a new SQL order repository
+func NewSQLOrderRepository(txManager *txmanager.Manager) *SQLOrderRepository {
+	return &SQLOrderRepository{
+		txManager: txManager,
+	}
+}
+
+// Create inserts the order and its items in one transaction
+func (r *SQLOrderRepository) Create(ctx context.Context, order *model.Order) error {
+	if order == nil {
+		return fmt.Errorf("order cannot be nil")
+	}
+
+	repoOrder := converter.ToRepoOrder(order)
+
+	return r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		db := r.txManager.Executor(ctx)
+
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO orders (`+orderColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			repoOrder.UUID,
+			repoOrder.UserUUID,
+			repoOrder.TotalPrice,
+			repoOrder.Status,
+			sqlutil.NullString(repoOrder.TransactionUUID),
+			sqlutil.NullString(repoOrder.PaymentMethod),
+			sqlutil.NullTime(repoOrder.PaidAt),
+			repoOrder.RefundedAmount,
+			sqlutil.NullTime(repoOrder.RefundedAt),
+			sqlutil.NullString(repoOrder.CancellationReason),
+			sqlutil.NullTime(repoOrder.CancelledAt),
+			sqlutil.Time(repoOrder.CreatedAt),
+			sqlutil.Time(repoOrder.UpdatedAt),
+			repoOrder.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("insert order %s: %w", order.UUID, err)
+		}
+
+		return insertItems(ctx, db, repoOrder)
+	})
+}
+
+// GetByUUID retrieves an order with its items by UUID
+func (r *SQLOrderRepository) GetByUUID(ctx context.Context, uuid uuid.UUID) (*model.Order, error) {
+	db := r.txManager.Executor(ctx)
+
+	row := db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE uuid = $1`, uuid.String())
+
+	repoOrder, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order with UUID %s: %w", uuid, model.ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select order %s: %w", uuid, err)
+	}
+
+	if err := loadItems(ctx, db, []*repomodel.Order{repoOrder}); err != nil {
+		return nil, err
+	}
+
+	return converter.FromRepoOrder(repoOrder)
+}
+
+// Update stores the order if its version matches the stored one and
+// increments Version on success
+func (r *SQLOrderRepository) Update(ctx context.Context, order *model.Order) error {
+	if order == nil {
+		return fmt.Errorf("order cannot be nil")
+	}
+
+	repoOrder := converter.ToRepoOrder(order)
+
+	err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		db := r.txManager.Executor(ctx)
+
+		result, err := db.ExecContext(ctx, `
+			UPDATE orders
+			SET user_uuid = $1, total_price = $2, status = $3, transaction_uuid = $4,
+				payment_method = $5, paid_at = $6, refunded_amount = $7, refunded_at = $8,
+				cancellation_reason = $9, cancelled_at = $10, created_at = $11, updated_at = $12,
+				version = version + 1
+			WHERE uuid = $13 AND version = $14`,
+			repoOrder.UserUUID,
+			repoOrder.TotalPrice,
+			repoOrder.Status,
+			sqlutil.NullString(repoOrder.TransactionUUID),
+			sqlutil.NullString(repoOrder.PaymentMethod),
+			sqlutil.NullTime(repoOrder.PaidAt),
+			repoOrder.RefundedAmount,
+			sqlutil.NullTime(repoOrder.RefundedAt),
+			sqlutil.NullString(repoOrder.CancellationReason),
+			sqlutil.NullTime(repoOrder.CancelledAt),
+			sqlutil.Time(repoOrder.CreatedAt),
+			sqlutil.Time(repoOrder.UpdatedAt),
+			repoOrder.UUID,
+			repoOrder.Version,
+		)
+		if err != nil {
+			return fmt.Errorf("update order %s: %w", order.UUID, err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("update order %s: %w", order.UUID, err)
+		}
+		if affected == 0 {
+			return r.missingOrConflict(ctx, db, order.UUID)
+		}
+
+		if _, err := db.ExecContext(ctx, `DELETE FROM order_items WHERE order_uuid = $1`, repoOrder.UUID); err != nil {
+			return fmt.Errorf("delete items of order %s: %w", order.UUID, err)
+		}
+
+		return insertItems(ctx, db, repoOrder)
+	})
+	if err != nil {
+		return err
+	}
+
+	order.Version++
+
+	return nil
+}
+
+// Delete removes an order by its UUID; its items are removed by the foreign key cascade
+func (r *SQLOrderRepository) Delete(ctx context.Context, uuid uuid.UUID) error {
+	db := r.txManager.Executor(ctx)
+
+	result, err := db.ExecContext(ctx, `DELETE FROM orders WHERE uuid = $1`, uuid.String())
+	if err != nil {
+		return fmt.Errorf("delete order %s: %w", uuid, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete order %s: %w", uuid, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("order with UUID %s: %w", uuid, model.ErrOrderNotFound)
+	}
+
+	return nil
+}
+
+// List retrieves orders matching the query, newest first, starting right after query.After
+func (r *SQLOrderRepository) List(ctx context.Context, query *model.ListOrdersQuery) ([]*model.Order, error) {
+	if query == nil {
+		return nil, fmt.Errorf("query cannot be nil")
+	}
+
+	var (
+		conditions []string
+		args       []any
+	)
+	// arg appends a query argument and returns its placeholder
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	filter := query.Filter
+	if filter.UserUUID != nil {
+		conditions = append(conditions, "user_uuid = "+arg(filter.UserUUID.String()))
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "status = "+arg(string(*filter.Status)))
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(sqlutil.Time(*filter.CreatedFrom)))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(sqlutil.Time(*filter.CreatedTo)))
+	}
+	if filter.PartUUID != nil {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM order_items i WHERE i.order_uuid = orders.uuid AND i.part_uuid = "+
+			arg(filter.PartUUID.String())+")")
+	}
+	if query.After != nil {
+		createdAt := sqlutil.Time(query.After.CreatedAt)
+		conditions = append(conditions, fmt.Sprintf("(created_at < %s OR (created_at = %s AND uuid < %s))",
+			arg(createdAt), arg(createdAt), arg(query.After.UUID.String())))
+	}
+
+	statement := `SELECT ` + orderColumns + ` FROM orders`
+	if len(conditions) > 0 {
+		statement += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	statement += ` ORDER BY created_at DESC, uuid DESC LIMIT ` + arg(query.Limit)
+
+	db := r.txManager.Executor(ctx)
+
+	repoOrders, err := queryOrders(ctx, db, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list orders: %w", err)
+	}
+
+	if err := loadItems(ctx, db, repoOrders); err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Order, 0, len(repoOrders))
+	for _, repoOrder := range repoOrders {
+		order, err := converter.FromRepoOrder(repoOrder)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+
+	return result, nil
+}
+
+// missingOrConflict tells apart a missing order from a stale version after an update matched no rows
+func (r *SQLOrderRepository) missingOrConflict(ctx context.Context, db txmanager.Executor, orderUUID uuid.UUID) error {
+	var exists int
+	err := db.QueryRowContext(ctx, `SELECT 1 FROM orders WHERE uuid = $1`, orderUUID.String()).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("order with UUID %s: %w", orderUUID, model.ErrOrderNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("select order %s: %w", orderUUID, err)
+	}
+
+	return fmt.Errorf("order with UUID %s: %w", orderUUID, model.ErrVersionConflict)
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrder(row rowScanner) (*repomodel.Order, error) {
+	var (
+		order           repomodel.Order
+		transactionUUID sql.NullString
+		paymentMethod   sql.NullString
+		paidAt          sqlutil.Timestamp
+		refundedAt      sqlutil.Timestamp
+		cancelReason    sql.NullString
+		cancelledAt     sqlutil.Timestamp
+		createdAt       sqlutil.Timestamp
+		updatedAt       sqlutil.Timestamp
+	)
+
+	err := row.Scan(
+		&order.UUID,
+		&order.UserUUID,
+		&order.TotalPrice,
+		&order.Status,
+		&transactionUUID,
+		&paymentMethod,
+		&paidAt,
+		&order.RefundedAmount,
+		&refundedAt,
+		&cancelReason,
+		&cancelledAt,
+		&createdAt,
+		&updatedAt,
+		&order.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.TransactionUUID = transactionUUID.String
+	order.PaymentMethod = paymentMethod.String
+	if paidAt.Valid {
+		order.PaidAt = &paidAt.Time
+	}
+	if refundedAt.Valid {
+		order.RefundedAt = &refundedAt.Time
+	}
+	order.CancellationReason = cancelReason.String
+	if cancelledAt.Valid {
+		order.CancelledAt = &cancelledAt.Time
+	}
+	order.CreatedAt = createdAt.Time
+	order.UpdatedAt = updatedAt.Time
+
+	return &order, nil
+}
+
+// queryOrders runs a query selecting orderColumns and closes the rows
+// before returning, so the connection can be reused to load items
+func queryOrders(ctx context.Context, db txmanager.Executor, query string, args ...any) ([]*repomodel.Order, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*repomodel.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+func insertItems(ctx context.Context, db txmanager.Executor, order *repomodel.Order) error {
+	for ordinal, item := range order.Items {
+		_, err := db.ExecContext(ctx, `
+			INSERT INTO order_items (order_uuid, ordinal, part_uuid, quantity, unit_price)
+			VALUES ($1, $2, $3, $4, $5)`,
+			order.UUID, ordinal, item.PartUUID, item.Quantity, item.UnitPrice,
+		)
+		if err != nil {
+			return fmt.Errorf("insert item %s of order %s: %w", item.PartUUID, order.UUID, err)
+		}
+	}
+
+	return nil
+}
+
+// loadItems fills Items of the orders with a single query
+func loadItems(ctx context.Context, db txmanager.Executor, orders []*repomodel.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byUUID := make(map[string]*repomodel.Order, len(orders))
+	placeholders := make([]string, len(orders))
+	args := make([]any, len(orders))
+	for i, order := range orders {
+		order.Items = []repomodel.OrderItem{}
+		byUUID[order.UUID] = order
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = order.UUID
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT order_uuid, part_uuid, quantity, unit_price
+		FROM order_items
+		WHERE order_uuid IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY order_uuid, ordinal`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("select order items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			orderUUID string
+			item      repomodel.OrderItem
+		)
+		if err := rows.Scan(&orderUUID, &item.PartUUID, &item.Quantity, &item.UnitPrice); err != nil {
+			return fmt.Errorf("scan order item: %w", err)
+		}
+
+		order, ok := byUUID[orderUUID]
+		if !ok {
+			continue
+		}
+		order.Items = append(order.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("select order items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/migrations"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+// newTestSQLRepository runs the embedded migrations on an in-memory SQLite database
+func newTestSQLRepository(t *testing.T) (*SQLOrderRepository, *txmanager.Manager) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, migrations.Up(context.Background(), db, goose.DialectSQLite3))
+
+	txManager := txmanager.NewManager(db)
+	return NewSQLOrderRepository(txManager), txManager
+}
+
+func TestSQLOrderRepository_CreateAndGet(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestSQLRepository(t)
+
+	partUUID1 := uuid.New()
+	partUUID2 := uuid.New()
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 123456789, time.UTC)
+	order := &model.Order{
+		UUID:     uuid.New(),
+		UserUUID: uuid.New(),
+		Items: []model.OrderItem{
+			{PartUUID: partUUID2, Quantity: 1, UnitPrice: 250.5, TotalPrice: 250.5},
+			{PartUUID: partUUID1, Quantity: 2, UnitPrice: 100.0, TotalPrice: 200.0},
+		},
+		TotalPrice: 450.5,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	require.NoError(t, repo.Create(ctx, order))
+	require.Error(t, repo.Create(ctx, order), "duplicate UUID must be rejected")
+
+	stored, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, order.UserUUID, stored.UserUUID)
+	require.Equal(t, order.Items, stored.Items)
+	require.Equal(t, 450.5, stored.TotalPrice)
+	require.Equal(t, model.StatusPendingPayment, stored.Status)
+	require.True(t, createdAt.Truncate(time.Microsecond).Equal(stored.CreatedAt))
+	require.Nil(t, stored.TransactionUUID)
+	require.Nil(t, stored.PaidAt)
+
+	_, err = repo.GetByUUID(ctx, uuid.New())
+	require.Error(t, err)
+}
+
+func TestSQLOrderRepository_UpdatePaymentDetails(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestSQLRepository(t)
+
+	order := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), Status: model.StatusPendingPayment, CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, order))
+
+	transactionUUID := uuid.New()
+	paidAt := time.Now()
+	order.Status = model.StatusPaid
+	order.TransactionUUID = &transactionUUID
+	order.PaymentMethod = model.PaymentMethodCard
+	order.PaidAt = &paidAt
+	require.NoError(t, repo.Update(ctx, order))
+	require.Equal(t, int64(1), order.Version)
+
+	stored, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, model.StatusPaid, stored.Status)
+	require.Equal(t, &transactionUUID, stored.TransactionUUID)
+	require.Equal(t, model.PaymentMethodCard, stored.PaymentMethod)
+	require.NotNil(t, stored.PaidAt)
+	require.True(t, paidAt.Truncate(time.Microsecond).Equal(*stored.PaidAt))
+	require.Equal(t, int64(1), stored.Version)
+	require.Nil(t, stored.RefundedAt)
+
+	refundedAt := time.Now()
+	stored.Status = model.StatusRefunded
+	stored.RefundedAmount = 12.5
+	stored.RefundedAt = &refundedAt
+	require.NoError(t, repo.Update(ctx, stored))
+
+	stored, err = repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, model.StatusRefunded, stored.Status)
+	require.Equal(t, 12.5, stored.RefundedAmount)
+	require.NotNil(t, stored.RefundedAt)
+	require.True(t, refundedAt.Truncate(time.Microsecond).Equal(*stored.RefundedAt))
+
+	missing := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), CreatedAt: time.Now()}
+	err = repo.Update(ctx, missing)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, model.ErrVersionConflict)
+}
+
+func TestSQLOrderRepository_UpdateVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestSQLRepository(t)
+
+	order := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), Status: model.StatusPendingPayment, CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, order))
+
+	first, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	second, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+
+	first.Status = model.StatusPaymentInProgress
+	require.NoError(t, repo.Update(ctx, first))
+
+	cancelledAt := time.Now()
+	second.Status = model.StatusCancelled
+	second.CancellationReason = model.CancellationReasonExpired
+	second.CancelledAt = &cancelledAt
+	require.ErrorIs(t, repo.Update(ctx, second), model.ErrVersionConflict)
+
+	stored, err := repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, model.StatusPaymentInProgress, stored.Status)
+	require.Equal(t, int64(1), stored.Version)
+	require.Empty(t, stored.CancellationReason)
+
+	stored.Status = model.StatusCancelled
+	stored.CancellationReason = model.CancellationReasonExpired
+	stored.CancelledAt = &cancelledAt
+	require.NoError(t, repo.Update(ctx, stored))
+
+	stored, err = repo.GetByUUID(ctx, order.UUID)
+	require.NoError(t, err)
+	require.Equal(t, model.CancellationReasonExpired, stored.CancellationReason)
+	require.NotNil(t, stored.CancelledAt)
+}
+
+func TestSQLOrderRepository_DeleteCascadesItems(t *testing.T) {
+	ctx := context.Background()
+	repo, txManager := newTestSQLRepository(t)
+
+	partUUID := uuid.New()
+	order := &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: partUUID, Quantity: 3, UnitPrice: 10.0, TotalPrice: 30.0}},
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, order))
+	require.NoError(t, repo.Delete(ctx, order.UUID))
+	require.Error(t, repo.Delete(ctx, order.UUID))
+
+	var items int
+	require.NoError(t, txManager.Executor(ctx).QueryRowContext(ctx, `SELECT COUNT(*) FROM order_items`).Scan(&items))
+	require.Zero(t, items)
+}
+
+func TestSQLOrderRepository_TransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	repo, txManager := newTestSQLRepository(t)
+
+	order := &model.Order{UUID: uuid.New(), UserUUID: uuid.New(), CreatedAt: time.Now()}
+	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, repo.Create(ctx, order))
+		// The second insert fails and must roll back the first one
+		return repo.Create(ctx, order)
+	})
+	require.Error(t, err)
+
+	_, err = repo.GetByUUID(ctx, order.UUID)
+	require.Error(t, err)
+}
+
+func TestSQLOrderRepository_ListPaginationAndFilter(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestSQLRepository(t)
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	userUUID := uuid.New()
+	partUUID := uuid.New()
+
+	// Two orders share a timestamp to exercise the UUID tie-breaker
+	var created []*model.Order
+	for _, offset := range []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		order := &model.Order{
+			UUID:      uuid.New(),
+			UserUUID:  userUUID,
+			Items:     []model.OrderItem{{PartUUID: partUUID, Quantity: 1, UnitPrice: 1.0, TotalPrice: 1.0}},
+			Status:    model.StatusPendingPayment,
+			CreatedAt: base.Add(offset),
+		}
+		require.NoError(t, repo.Create(ctx, order))
+		created = append(created, order)
+	}
+	require.NoError(t, repo.Create(ctx, &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  uuid.New(),
+		Status:    model.StatusPaid,
+		CreatedAt: base.Add(time.Minute),
+	}))
+
+	// The memory repository defines the expected order
+	memory := NewMemoryOrderRepository()
+	for _, order := range created {
+		require.NoError(t, memory.Create(ctx, order))
+	}
+	expected, err := memory.List(ctx, &model.ListOrdersQuery{Limit: 10})
+	require.NoError(t, err)
+
+	var seen []uuid.UUID
+	query := &model.ListOrdersQuery{Filter: model.OrderFilter{UserUUID: &userUUID, PartUUID: &partUUID}, Limit: 2}
+	for {
+		page, err := repo.List(ctx, query)
+		require.NoError(t, err)
+		for _, order := range page {
+			require.Len(t, order.Items, 1)
+			require.Equal(t, partUUID, order.Items[0].PartUUID)
+			seen = append(seen, order.UUID)
+		}
+		if len(page) < query.Limit {
+			break
+		}
+		last := page[len(page)-1]
+		query.After = &model.OrderCursor{CreatedAt: last.CreatedAt, UUID: last.UUID}
+	}
+
+	require.Len(t, seen, len(expected))
+	for i, order := range expected {
+		require.Equal(t, order.UUID, seen[i])
+	}
+
+	status := model.StatusPaid
+	createdFrom := base.Add(time.Minute)
+	createdTo := base.Add(2 * time.Minute)
+	result, err := repo.List(ctx, &model.ListOrdersQuery{
+		Filter: model.OrderFilter{Status: &status, CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, model.StatusPaid, result[0].Status)
+}
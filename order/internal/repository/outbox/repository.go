@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+// MemoryOutboxRepository implements OutboxRepository using in-memory storage
+type MemoryOutboxRepository struct {
+	mu     sync.Mutex
+	events map[uuid.UUID]*model.Event
+	// order keeps event UUIDs in the order they were added
+	order []uuid.UUID
+}
+
+// NewMemoryOutboxRepository creates a new in-memory outbox repository
+func NewMemoryOutboxRepository() *MemoryOutboxRepository {
+	return &MemoryOutboxRepository{
+		events: make(map[uuid.UUID]*model.Event),
+	}
+}
+
+// Add stores the events as unpublished
+func (r *MemoryOutboxRepository) Add(ctx context.Context, events ...*model.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		if event == nil {
+			return fmt.Errorf("event cannot be nil")
+		}
+		if _, exists := r.events[event.UUID]; exists {
+			return fmt.Errorf("event with UUID %s already exists", event.UUID)
+		}
+	}
+
+	for _, event := range events {
+		// Create a copy to avoid external modifications
+		eventCopy := *event
+		r.events[event.UUID] = &eventCopy
+		r.order = append(r.order, event.UUID)
+	}
+
+	return nil
+}
+
+// ListUnpublished returns up to limit unpublished events, oldest first
+func (r *MemoryOutboxRepository) ListUnpublished(ctx context.Context, limit int) ([]*model.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*model.Event, 0, limit)
+	for _, eventUUID := range r.order {
+		if len(result) >= limit {
+			break
+		}
+
+		event := r.events[eventUUID]
+		if event.PublishedAt != nil {
+			continue
+		}
+
+		// Return copies to avoid external modifications
+		eventCopy := *event
+		result = append(result, &eventCopy)
+	}
+
+	return result, nil
+}
+
+// MarkPublished records when the events were published; unknown UUIDs are ignored
+func (r *MemoryOutboxRepository) MarkPublished(ctx context.Context, eventUUIDs []uuid.UUID, publishedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, eventUUID := range eventUUIDs {
+		if event, exists := r.events[eventUUID]; exists {
+			published := publishedAt
+			event.PublishedAt = &published
+		}
+	}
+
+	return nil
+}
+
+// DeletePublished drops events published before publishedBefore
+func (r *MemoryOutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.order[:0]
+	deleted := 0
+	for _, eventUUID := range r.order {
+		event := r.events[eventUUID]
+		if event.PublishedAt != nil && event.PublishedAt.Before(publishedBefore) {
+			delete(r.events, eventUUID)
+			deleted++
+			continue
+		}
+		kept = append(kept, eventUUID)
+	}
+	r.order = kept
+
+	return deleted, nil
+}
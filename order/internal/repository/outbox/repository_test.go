@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+)
+
+func newTestEvent(occurredAt time.Time) *model.Event {
+	return &model.Event{
+		UUID:       uuid.New(),
+		Type:       model.EventTypeOrderCreated,
+		Version:    model.EventVersion,
+		OrderUUID:  uuid.New(),
+		Payload:    []byte{0x0a, 0x01, 0x02},
+		OccurredAt: occurredAt,
+	}
+}
+
+func TestMemoryOutboxRepository_PublishLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryOutboxRepository()
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	first := newTestEvent(now)
+	second := newTestEvent(now.Add(time.Second))
+	third := newTestEvent(now.Add(2 * time.Second))
+
+	require.NoError(t, repo.Add(ctx, first, second))
+	require.NoError(t, repo.Add(ctx, third))
+	require.Error(t, repo.Add(ctx, first), "duplicate UUID must be rejected")
+
+	events, err := repo.ListUnpublished(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, first.UUID, events[0].UUID)
+	require.Equal(t, second.UUID, events[1].UUID)
+	require.Equal(t, first.Payload, events[0].Payload)
+
+	require.NoError(t, repo.MarkPublished(ctx, []uuid.UUID{first.UUID, second.UUID}, now.Add(time.Minute)))
+
+	events, err = repo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, third.UUID, events[0].UUID)
+
+	deleted, err := repo.DeletePublished(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Zero(t, deleted, "events published exactly at the bound are kept")
+
+	deleted, err = repo.DeletePublished(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	events, err = repo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
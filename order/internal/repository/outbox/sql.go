@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/converter"
+	repomodel "github.com/nimbodex/microservices-factory/order/internal/repository/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/sqlutil"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+// SQLOutboxRepository implements OutboxRepository on top of PostgreSQL. It
+// joins the transaction carried by the context, so events are committed
+// together with the order change they describe.
+type SQLOutboxRepository struct {
+	txManager *txmanager.Manager
+}
+
+// NewSQLOutboxRepository creates a new SQL outbox repository
+func NewSQLOutboxRepository(txManager *txmanager.Manager) *SQLOutboxRepository {
+	return &SQLOutboxRepository{
+		txManager: txManager,
+	}
+}
+
+// Add inserts the events as unpublished
+func (r *SQLOutboxRepository) Add(ctx context.Context, events ...*model.Event) error {
+	return r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		db := r.txManager.Executor(ctx)
+
+		for _, event := range events {
+			if event == nil {
+				return fmt.Errorf("event cannot be nil")
+			}
+
+			repoEvent := converter.ToRepoEvent(event)
+
+			_, err := db.ExecContext(ctx, `
+				INSERT INTO outbox_events (uuid, event_type, event_version, order_uuid, payload, occurred_at)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+				repoEvent.UUID,
+				repoEvent.Type,
+				repoEvent.Version,
+				repoEvent.OrderUUID,
+				repoEvent.Payload,
+				sqlutil.Time(repoEvent.OccurredAt),
+			)
+			if err != nil {
+				return fmt.Errorf("insert event %s: %w", event.UUID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListUnpublished returns up to limit unpublished events, oldest first
+func (r *SQLOutboxRepository) ListUnpublished(ctx context.Context, limit int) ([]*model.Event, error) {
+	db := r.txManager.Executor(ctx)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT uuid, event_type, event_version, order_uuid, payload, occurred_at
+		FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY occurred_at, uuid
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select unpublished events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*model.Event
+	for rows.Next() {
+		var (
+			repoEvent  repomodel.Event
+			occurredAt sqlutil.Timestamp
+		)
+		err := rows.Scan(
+			&repoEvent.UUID,
+			&repoEvent.Type,
+			&repoEvent.Version,
+			&repoEvent.OrderUUID,
+			&repoEvent.Payload,
+			&occurredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		repoEvent.OccurredAt = occurredAt.Time
+
+		event, err := converter.FromRepoEvent(&repoEvent)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("select unpublished events: %w", err)
+	}
+
+	return result, nil
+}
+
+// MarkPublished records when the events were published
+func (r *SQLOutboxRepository) MarkPublished(ctx context.Context, eventUUIDs []uuid.UUID, publishedAt time.Time) error {
+	if len(eventUUIDs) == 0 {
+		return nil
+	}
+
+	args := []any{sqlutil.Time(publishedAt)}
+	placeholders := make([]string, len(eventUUIDs))
+	for i, eventUUID := range eventUUIDs {
+		args = append(args, eventUUID.String())
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	db := r.txManager.Executor(ctx)
+
+	_, err := db.ExecContext(ctx, `
+		UPDATE outbox_events
+		SET published_at = $1
+		WHERE uuid IN (`+strings.Join(placeholders, ", ")+`)`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("mark events published: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePublished drops events published before publishedBefore
+func (r *SQLOutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	db := r.txManager.Executor(ctx)
+
+	result, err := db.ExecContext(ctx, `
+		DELETE FROM outbox_events
+		WHERE published_at IS NOT NULL AND published_at < $1`,
+		sqlutil.Time(publishedBefore),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete published events: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("delete published events: %w", err)
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,89 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/order/internal/migrations"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/txmanager"
+)
+
+// newTestSQLRepository runs the embedded migrations on an in-memory SQLite database
+func newTestSQLRepository(t *testing.T) (*SQLOutboxRepository, *txmanager.Manager) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", "file::memory:?_foreign_keys=on")
+	require.NoError(t, err)
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, migrations.Up(context.Background(), db, goose.DialectSQLite3))
+
+	txManager := txmanager.NewManager(db)
+	return NewSQLOutboxRepository(txManager), txManager
+}
+
+func TestSQLOutboxRepository_PublishLifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newTestSQLRepository(t)
+
+	now := time.Date(2024, 1, 15, 10, 0, 0, 123456789, time.UTC)
+	first := newTestEvent(now)
+	second := newTestEvent(now.Add(time.Second))
+	third := newTestEvent(now.Add(2 * time.Second))
+
+	require.NoError(t, repo.Add(ctx, third, first))
+	require.NoError(t, repo.Add(ctx, second))
+	require.Error(t, repo.Add(ctx, first), "duplicate UUID must be rejected")
+
+	events, err := repo.ListUnpublished(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, first.UUID, events[0].UUID)
+	require.Equal(t, second.UUID, events[1].UUID)
+	require.Equal(t, first.Type, events[0].Type)
+	require.Equal(t, first.Version, events[0].Version)
+	require.Equal(t, first.OrderUUID, events[0].OrderUUID)
+	require.Equal(t, first.Payload, events[0].Payload)
+	require.True(t, now.Truncate(time.Microsecond).Equal(events[0].OccurredAt))
+
+	require.NoError(t, repo.MarkPublished(ctx, []uuid.UUID{first.UUID, second.UUID}, now.Add(time.Minute)))
+
+	events, err = repo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, third.UUID, events[0].UUID)
+
+	deleted, err := repo.DeletePublished(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	events, err = repo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+}
+
+func TestSQLOutboxRepository_AddRollsBackWithTransaction(t *testing.T) {
+	ctx := context.Background()
+	repo, txManager := newTestSQLRepository(t)
+
+	errAbort := errors.New("order update failed")
+	err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, repo.Add(ctx, newTestEvent(time.Now())))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	events, err := repo.ListUnpublished(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,7 +13,38 @@ import (
 type OrderRepository interface {
 	Create(ctx context.Context, order *model.Order) error
 	GetByUUID(ctx context.Context, uuid uuid.UUID) (*model.Order, error)
+	// Update stores the order only if its Version matches the stored one and
+	// increments Version on success; otherwise it returns model.ErrVersionConflict
 	Update(ctx context.Context, order *model.Order) error
 	Delete(ctx context.Context, uuid uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*model.Order, error)
+	List(ctx context.Context, query *model.ListOrdersQuery) ([]*model.Order, error)
+}
+
+// IdempotencyRepository stores Idempotency-Key records for a retention window
+type IdempotencyRepository interface {
+	// Reserve stores the record as in progress unless a live record already
+	// exists for the same operation and key, in which case that record is
+	// returned and nothing is stored
+	Reserve(ctx context.Context, record *model.IdempotencyRecord) (*model.IdempotencyRecord, error)
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, operation, key string, statusCode int, response []byte) error
+	// Release drops a reserved record so the request can be retried with the same key
+	Release(ctx context.Context, operation, key string) error
+}
+
+// OutboxRepository stores domain events until they are published. Events are
+// added in the same transaction as the order change they describe.
+type OutboxRepository interface {
+	Add(ctx context.Context, events ...*model.Event) error
+	// ListUnpublished returns up to limit events not published yet, oldest first
+	ListUnpublished(ctx context.Context, limit int) ([]*model.Event, error)
+	MarkPublished(ctx context.Context, eventUUIDs []uuid.UUID, publishedAt time.Time) error
+	// DeletePublished drops events published before the given time and returns how many were dropped
+	DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error)
+}
+
+// TxManager runs a function in a transaction shared by repositories that
+// pick it up from the context
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
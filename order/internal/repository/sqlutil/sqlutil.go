@@ -0,0 +1,67 @@
+package sqlutil
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Time normalises a time before it is written: TIMESTAMPTZ keeps microseconds,
+// and storing UTC keeps text timestamps comparable on engines without a time type
+func Time(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// NullTime converts an optional time to a nullable column value
+func NullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: Time(*t), Valid: true}
+}
+
+// NullString stores an empty string as NULL
+func NullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// timestampLayouts are the text forms of timestamps returned by drivers that
+// don't decode TIMESTAMPTZ columns themselves
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+// Timestamp scans a nullable TIMESTAMPTZ column
+type Timestamp struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner
+func (t *Timestamp) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = Timestamp{}
+		return nil
+	case time.Time:
+		*t = Timestamp{Time: v.UTC(), Valid: true}
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("cannot scan %T into timestamp", src)
+	}
+}
+
+func (t *Timestamp) parse(s string) error {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = Timestamp{Time: parsed.UTC(), Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse timestamp %q", s)
+}
@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+const instrumentation = "github.com/nimbodex/microservices-factory/order/internal/repository"
+
+// OrderRepository wraps an OrderRepository and records a span for every operation
+type OrderRepository struct {
+	next repository.OrderRepository
+}
+
+// NewOrderRepository creates a new tracing order repository on top of next
+func NewOrderRepository(next repository.OrderRepository) *OrderRepository {
+	return &OrderRepository{next: next}
+}
+
+// Create stores a new order
+func (r *OrderRepository) Create(ctx context.Context, order *model.Order) error {
+	ctx, span := start(ctx, "OrderRepository.Create", attribute.String("order_uuid", order.UUID.String()))
+	err := r.next.Create(ctx, order)
+	tracing.End(span, err)
+	return err
+}
+
+// GetByUUID retrieves an order by its UUID
+func (r *OrderRepository) GetByUUID(ctx context.Context, orderUUID uuid.UUID) (*model.Order, error) {
+	ctx, span := start(ctx, "OrderRepository.GetByUUID", attribute.String("order_uuid", orderUUID.String()))
+	order, err := r.next.GetByUUID(ctx, orderUUID)
+	tracing.End(span, err)
+	return order, err
+}
+
+// Update stores changes of an existing order
+func (r *OrderRepository) Update(ctx context.Context, order *model.Order) error {
+	ctx, span := start(ctx, "OrderRepository.Update", attribute.String("order_uuid", order.UUID.String()))
+	err := r.next.Update(ctx, order)
+	tracing.End(span, err)
+	return err
+}
+
+// Delete removes an order
+func (r *OrderRepository) Delete(ctx context.Context, orderUUID uuid.UUID) error {
+	ctx, span := start(ctx, "OrderRepository.Delete", attribute.String("order_uuid", orderUUID.String()))
+	err := r.next.Delete(ctx, orderUUID)
+	tracing.End(span, err)
+	return err
+}
+
+// List returns orders matching the query
+func (r *OrderRepository) List(ctx context.Context, query *model.ListOrdersQuery) ([]*model.Order, error) {
+	ctx, span := start(ctx, "OrderRepository.List", attribute.Int("limit", query.Limit))
+	orders, err := r.next.List(ctx, query)
+	span.SetAttributes(attribute.Int("orders", len(orders)))
+	tracing.End(span, err)
+	return orders, err
+}
+
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, instrumentation, name, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+// OutboxRepository wraps an OutboxRepository and records a span for every operation
+type OutboxRepository struct {
+	next repository.OutboxRepository
+}
+
+// NewOutboxRepository creates a new tracing outbox repository on top of next
+func NewOutboxRepository(next repository.OutboxRepository) *OutboxRepository {
+	return &OutboxRepository{next: next}
+}
+
+// Add stores events to publish
+func (r *OutboxRepository) Add(ctx context.Context, events ...*model.Event) error {
+	ctx, span := start(ctx, "OutboxRepository.Add", attribute.Int("events", len(events)))
+	err := r.next.Add(ctx, events...)
+	tracing.End(span, err)
+	return err
+}
+
+// ListUnpublished returns up to limit events not published yet
+func (r *OutboxRepository) ListUnpublished(ctx context.Context, limit int) ([]*model.Event, error) {
+	ctx, span := start(ctx, "OutboxRepository.ListUnpublished", attribute.Int("limit", limit))
+	events, err := r.next.ListUnpublished(ctx, limit)
+	span.SetAttributes(attribute.Int("events", len(events)))
+	tracing.End(span, err)
+	return events, err
+}
+
+// MarkPublished records that events were published
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventUUIDs []uuid.UUID, publishedAt time.Time) error {
+	ctx, span := start(ctx, "OutboxRepository.MarkPublished", attribute.Int("events", len(eventUUIDs)))
+	err := r.next.MarkPublished(ctx, eventUUIDs, publishedAt)
+	tracing.End(span, err)
+	return err
+}
+
+// DeletePublished drops events published before the given time
+func (r *OutboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int, error) {
+	ctx, span := start(ctx, "OutboxRepository.DeletePublished")
+	deleted, err := r.next.DeletePublished(ctx, publishedBefore)
+	span.SetAttributes(attribute.Int("events", deleted))
+	tracing.End(span, err)
+	return deleted, err
+}
@@ -0,0 +1,67 @@
+package txmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// txKey is the context key of the current transaction
+type txKey struct{}
+
+// Executor is the subset of *sql.DB and *sql.Tx used by SQL repositories
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Manager runs functions in a database transaction. Repositories pick the
+// transaction up from the context, so several repository calls made inside
+// WithinTransaction are committed or rolled back together.
+type Manager struct {
+	db *sql.DB
+}
+
+// NewManager creates a new transaction manager
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn in a transaction. If ctx already carries a
+// transaction, fn joins it and the outermost call commits.
+func (m *Manager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			logger.FromContext(ctx).Error("Failed to rollback transaction", logger.Err(rollbackErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Executor returns the transaction carried by ctx, or db outside of a transaction
+func (m *Manager) Executor(ctx context.Context) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return m.db
+}
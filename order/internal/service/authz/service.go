@@ -0,0 +1,138 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/order/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+// denial is a response refusing access to an order; every operation on a
+// single order can return it
+type denial interface {
+	orderv1.GetOrderRes
+	orderv1.PayOrderRes
+	orderv1.CancelOrderRes
+	orderv1.RefundOrderRes
+}
+
+// OrderService wraps an OrderService and lets users act only on their own
+// orders, while admins may act on all of them. Orders of other users are
+// reported as not found so that their existence does not leak.
+type OrderService struct {
+	service.OrderService
+	orderRepo repository.OrderRepository
+}
+
+// NewOrderService creates a new authorizing order service on top of next
+func NewOrderService(next service.OrderService, orderRepo repository.OrderRepository) *OrderService {
+	return &OrderService{
+		OrderService: next,
+		orderRepo:    orderRepo,
+	}
+}
+
+// GetOrder returns the order if the caller may see it
+func (s *OrderService) GetOrder(ctx context.Context, params orderv1.GetOrderParams) (orderv1.GetOrderRes, error) {
+	if res := s.authorize(ctx, params.OrderUUID); res != nil {
+		return res, nil
+	}
+	return s.OrderService.GetOrder(ctx, params)
+}
+
+// ListOrders lists the orders of the caller; admins may list orders of anyone
+func (s *OrderService) ListOrders(ctx context.Context, params orderv1.ListOrdersParams) (orderv1.ListOrdersRes, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return unauthenticated(), nil
+	}
+
+	if !user.Admin {
+		if userUUID, set := params.UserUUID.Get(); set && userUUID != user.UUID {
+			// Orders of another user are not visible, as if there were none
+			return &orderv1.ListOrdersResponse{Orders: []orderv1.GetOrderResponse{}}, nil
+		}
+		params.UserUUID = orderv1.NewOptUUID(user.UUID)
+	}
+
+	return s.OrderService.ListOrders(ctx, params)
+}
+
+// PayOrder pays the order if the caller may act on it
+func (s *OrderService) PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, params orderv1.PayOrderParams) (orderv1.PayOrderRes, error) {
+	if res := s.authorize(ctx, params.OrderUUID); res != nil {
+		return res, nil
+	}
+	return s.OrderService.PayOrder(ctx, req, params)
+}
+
+// CancelOrder cancels the order if the caller may act on it
+func (s *OrderService) CancelOrder(ctx context.Context, params orderv1.CancelOrderParams) (orderv1.CancelOrderRes, error) {
+	if res := s.authorize(ctx, params.OrderUUID); res != nil {
+		return res, nil
+	}
+	return s.OrderService.CancelOrder(ctx, params)
+}
+
+// RefundOrder refunds the order if the caller may act on it
+func (s *OrderService) RefundOrder(ctx context.Context, req orderv1.OptRefundOrderRequest, params orderv1.RefundOrderParams) (orderv1.RefundOrderRes, error) {
+	if res := s.authorize(ctx, params.OrderUUID); res != nil {
+		return res, nil
+	}
+	return s.OrderService.RefundOrder(ctx, req, params)
+}
+
+// authorize returns the response to send instead of running the operation,
+// or nil when the caller may act on the order
+func (s *OrderService) authorize(ctx context.Context, orderUUID uuid.UUID) denial {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return unauthenticated()
+	}
+
+	order, err := s.orderRepo.GetByUUID(ctx, orderUUID)
+	if err != nil {
+		if errors.Is(err, model.ErrOrderNotFound) {
+			return notFound()
+		}
+		logger.FromContext(ctx).Error("Failed to get order to check access", slog.String("order_uuid", orderUUID.String()), logger.Err(err))
+		return &orderv1.InternalServerError{
+			Error:   "get_failed",
+			Message: "failed to get order",
+		}
+	}
+
+	if !user.CanAccess(order.UserUUID) {
+		logger.FromContext(ctx).Warn("Access to order denied",
+			slog.String("order_uuid", orderUUID.String()),
+			slog.String("user_uuid", user.UUID.String()),
+			slog.String("owner_uuid", order.UserUUID.String()),
+		)
+		return notFound()
+	}
+
+	return nil
+}
+
+// notFound matches the response for orders that do not exist
+func notFound() *orderv1.NotFoundError {
+	return &orderv1.NotFoundError{
+		Error:   "order_not_found",
+		Message: "order not found",
+	}
+}
+
+func unauthenticated() *orderv1.UnauthorizedError {
+	return &orderv1.UnauthorizedError{
+		Error:   "missing_session",
+		Message: "request is not authenticated",
+	}
+}
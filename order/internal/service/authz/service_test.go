@@ -0,0 +1,142 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+// newOrder stores a pending order of ownerUUID
+func (s *AuthzServiceTestSuite) newOrder(repo *orderrepo.MemoryOrderRepository, ownerUUID uuid.UUID) *model.Order {
+	order := &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  ownerUUID,
+		Status:    model.StatusPendingPayment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	s.Require().NoError(repo.Create(context.Background(), order))
+
+	return order
+}
+
+func (s *AuthzServiceTestSuite) TestGetOrder_Owner() {
+	repo := orderrepo.NewMemoryOrderRepository()
+	owner := uuid.New()
+	order := s.newOrder(repo, owner)
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: owner})
+	params := orderv1.GetOrderParams{OrderUUID: order.UUID}
+	expected := &orderv1.GetOrderResponse{OrderUUID: order.UUID}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("GetOrder", ctx, params).Return(expected, nil)
+
+	result, err := NewOrderService(next, repo).GetOrder(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, result)
+}
+
+func (s *AuthzServiceTestSuite) TestOtherUserGetsNotFound() {
+	repo := orderrepo.NewMemoryOrderRepository()
+	order := s.newOrder(repo, uuid.New())
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+
+	// The wrapped service must not be reached
+	service := NewOrderService(servicemocks.NewOrderService(s.T()), repo)
+
+	getRes, err := service.GetOrder(ctx, orderv1.GetOrderParams{OrderUUID: order.UUID})
+	s.NoError(err)
+	s.Equal(notFound(), getRes)
+
+	payRes, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: order.UUID})
+	s.NoError(err)
+	s.Equal(notFound(), payRes)
+
+	cancelRes, err := service.CancelOrder(ctx, orderv1.CancelOrderParams{OrderUUID: order.UUID})
+	s.NoError(err)
+	s.Equal(notFound(), cancelRes)
+
+	refundRes, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+	s.NoError(err)
+	s.Equal(notFound(), refundRes)
+}
+
+func (s *AuthzServiceTestSuite) TestAdminMayActOnAnyOrder() {
+	repo := orderrepo.NewMemoryOrderRepository()
+	order := s.newOrder(repo, uuid.New())
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New(), Admin: true})
+	params := orderv1.CancelOrderParams{OrderUUID: order.UUID}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CancelOrder", ctx, params).Return(&orderv1.CancelOrderNoContent{}, nil)
+
+	result, err := NewOrderService(next, repo).CancelOrder(ctx, params)
+
+	s.NoError(err)
+	s.Equal(&orderv1.CancelOrderNoContent{}, result)
+}
+
+func (s *AuthzServiceTestSuite) TestUnknownOrderIsNotFound() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New(), Admin: true})
+
+	result, err := NewOrderService(servicemocks.NewOrderService(s.T()), orderrepo.NewMemoryOrderRepository()).
+		GetOrder(ctx, orderv1.GetOrderParams{OrderUUID: uuid.New()})
+
+	s.NoError(err)
+	s.Equal(notFound(), result)
+}
+
+func (s *AuthzServiceTestSuite) TestUnauthenticated() {
+	result, err := NewOrderService(servicemocks.NewOrderService(s.T()), orderrepo.NewMemoryOrderRepository()).
+		GetOrder(context.Background(), orderv1.GetOrderParams{OrderUUID: uuid.New()})
+
+	s.NoError(err)
+	_, ok := result.(*orderv1.UnauthorizedError)
+	s.True(ok)
+}
+
+func (s *AuthzServiceTestSuite) TestListOrders_ScopedToCaller() {
+	caller := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: caller})
+	expected := &orderv1.ListOrdersResponse{}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("ListOrders", ctx, mock.MatchedBy(func(params orderv1.ListOrdersParams) bool {
+		userUUID, ok := params.UserUUID.Get()
+		return ok && userUUID == caller
+	})).Return(expected, nil)
+
+	service := NewOrderService(next, orderrepo.NewMemoryOrderRepository())
+
+	result, err := service.ListOrders(ctx, orderv1.ListOrdersParams{})
+	s.NoError(err)
+	s.Equal(expected, result)
+
+	// Asking for orders of someone else yields nothing
+	result, err = service.ListOrders(ctx, orderv1.ListOrdersParams{UserUUID: orderv1.NewOptUUID(uuid.New())})
+	s.NoError(err)
+	s.Equal(&orderv1.ListOrdersResponse{Orders: []orderv1.GetOrderResponse{}}, result)
+}
+
+func (s *AuthzServiceTestSuite) TestListOrders_AdminSeesEveryone() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New(), Admin: true})
+	params := orderv1.ListOrdersParams{}
+	expected := &orderv1.ListOrdersResponse{}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("ListOrders", ctx, params).Return(expected, nil)
+
+	result, err := NewOrderService(next, orderrepo.NewMemoryOrderRepository()).ListOrders(ctx, params)
+
+	s.NoError(err)
+	s.Equal(expected, result)
+}
@@ -0,0 +1,15 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthzServiceTestSuite struct {
+	suite.Suite
+}
+
+func TestAuthzServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthzServiceTestSuite))
+}
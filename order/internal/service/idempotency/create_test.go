@@ -0,0 +1,181 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	idempotencyrepo "github.com/nimbodex/microservices-factory/order/internal/repository/idempotency"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_WithoutKeyIsPassedThrough() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{}
+	expected := &orderv1.CreateOrderResponse{OrderUUID: uuid.New(), TotalPrice: 100.0}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).Return(expected, nil).Twice()
+
+	service := NewOrderService(next, repomocks.NewIdempotencyRepository(s.T()))
+
+	for i := 0; i < 2; i++ {
+		result, err := service.CreateOrder(ctx, req, params)
+		s.NoError(err)
+		s.Equal(expected, result)
+	}
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_RetryReplaysStoredResponse() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+	expected := &orderv1.CreateOrderResponse{OrderUUID: uuid.New(), TotalPrice: 100.0}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).Return(expected, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	first, err := service.CreateOrder(ctx, req, params)
+	s.NoError(err)
+	s.Equal(expected, first)
+
+	// A retry decodes an equal body, even if the client re-serialised it
+	retryReq := &orderv1.CreateOrderRequest{Items: req.Items}
+	second, err := service.CreateOrder(ctx, retryReq, params)
+	s.NoError(err)
+	s.Equal(expected, second)
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_KeyReusedWithDifferentBody() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	otherReq := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).
+		Return(&orderv1.CreateOrderResponse{OrderUUID: uuid.New()}, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	_, err := service.CreateOrder(ctx, req, params)
+	s.NoError(err)
+
+	result, err := service.CreateOrder(ctx, otherReq, params)
+	s.NoError(err)
+
+	unprocessable, ok := result.(*orderv1.UnprocessableEntityError)
+	s.True(ok)
+	s.Equal("idempotency_key_reused", unprocessable.Error)
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_KeyInProgress() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).
+		Run(func(mock.Arguments) {
+			close(started)
+			<-finish
+		}).
+		Return(&orderv1.CreateOrderResponse{OrderUUID: uuid.New()}, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = service.CreateOrder(ctx, req, params)
+	}()
+
+	<-started
+	result, err := service.CreateOrder(ctx, req, params)
+	close(finish)
+	<-done
+
+	s.NoError(err)
+	conflict, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("idempotency_key_in_use", conflict.Error)
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_ServerErrorReleasesKey() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+	expected := &orderv1.CreateOrderResponse{OrderUUID: uuid.New()}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).
+		Return(&orderv1.InternalServerError{Error: "creation_failed", Message: "failed to create order"}, nil).Once()
+	next.On("CreateOrder", mock.Anything, req, params).Return(expected, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	first, err := service.CreateOrder(ctx, req, params)
+	s.NoError(err)
+	_, ok := first.(*orderv1.InternalServerError)
+	s.True(ok)
+
+	second, err := service.CreateOrder(ctx, req, params)
+	s.NoError(err)
+	s.Equal(expected, second)
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_ReserveFailed() {
+	userUUID := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: userUUID})
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+
+	repo := repomocks.NewIdempotencyRepository(s.T())
+	repo.On("Reserve", mock.Anything, mock.MatchedBy(func(record *model.IdempotencyRecord) bool {
+		return record.Operation == OperationCreateOrder && record.Key == userUUID.String()+"/key-1"
+	})).Return(nil, errors.New("storage unavailable"))
+
+	service := NewOrderService(servicemocks.NewOrderService(s.T()), repo)
+
+	result, err := service.CreateOrder(ctx, req, params)
+
+	s.NoError(err)
+	internalErr, ok := result.(*orderv1.InternalServerError)
+	s.True(ok)
+	s.Equal("idempotency_failed", internalErr.Error)
+}
+
+func (s *IdempotencyServiceTestSuite) TestCreateOrder_KeysAreScopedToUser() {
+	req := &orderv1.CreateOrderRequest{Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}}}
+	params := orderv1.CreateOrderParams{IdempotencyKey: orderv1.NewOptString("key-1")}
+	first := &orderv1.CreateOrderResponse{OrderUUID: uuid.New(), TotalPrice: 100.0}
+	second := &orderv1.CreateOrderResponse{OrderUUID: uuid.New(), TotalPrice: 100.0}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("CreateOrder", mock.Anything, req, params).Return(first, nil).Once()
+	next.On("CreateOrder", mock.Anything, req, params).Return(second, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	result, err := service.CreateOrder(auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()}), req, params)
+	s.NoError(err)
+	s.Equal(first, result)
+
+	// Another user picking the same key gets a new order rather than a replay
+	result, err = service.CreateOrder(auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()}), req, params)
+	s.NoError(err)
+	s.Equal(second, result)
+}
@@ -0,0 +1,95 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	idempotencyrepo "github.com/nimbodex/microservices-factory/order/internal/repository/idempotency"
+	servicemocks "github.com/nimbodex/microservices-factory/order/internal/service/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func (s *IdempotencyServiceTestSuite) TestPayOrder_RetryReplaysStoredResponse() {
+	ctx := context.Background()
+	req := &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}
+	params := orderv1.PayOrderParams{OrderUUID: uuid.New(), IdempotencyKey: orderv1.NewOptString("key-1")}
+	expected := &orderv1.PayOrderResponse{TransactionUUID: uuid.New()}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("PayOrder", mock.Anything, req, params).Return(expected, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	for i := 0; i < 3; i++ {
+		result, err := service.PayOrder(ctx, req, params)
+		s.NoError(err)
+		s.Equal(expected, result)
+	}
+}
+
+func (s *IdempotencyServiceTestSuite) TestPayOrder_KeyReusedForAnotherOrder() {
+	ctx := context.Background()
+	req := &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}
+	params := orderv1.PayOrderParams{OrderUUID: uuid.New(), IdempotencyKey: orderv1.NewOptString("key-1")}
+	otherParams := orderv1.PayOrderParams{OrderUUID: uuid.New(), IdempotencyKey: params.IdempotencyKey}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("PayOrder", mock.Anything, req, params).
+		Return(&orderv1.PayOrderResponse{TransactionUUID: uuid.New()}, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	_, err := service.PayOrder(ctx, req, params)
+	s.NoError(err)
+
+	result, err := service.PayOrder(ctx, req, otherParams)
+	s.NoError(err)
+
+	unprocessable, ok := result.(*orderv1.UnprocessableEntityError)
+	s.True(ok)
+	s.Equal("idempotency_key_reused", unprocessable.Error)
+}
+
+func (s *IdempotencyServiceTestSuite) TestPayOrder_ConflictIsNotStored() {
+	ctx := context.Background()
+	req := &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}
+	params := orderv1.PayOrderParams{OrderUUID: uuid.New(), IdempotencyKey: orderv1.NewOptString("key-1")}
+	expected := &orderv1.PayOrderResponse{TransactionUUID: uuid.New()}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("PayOrder", mock.Anything, req, params).
+		Return(&orderv1.ConflictError{Error: "concurrent_modification", Message: "order cannot be paid"}, nil).Once()
+	next.On("PayOrder", mock.Anything, req, params).Return(expected, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	first, err := service.PayOrder(ctx, req, params)
+	s.NoError(err)
+	_, ok := first.(*orderv1.ConflictError)
+	s.True(ok)
+
+	second, err := service.PayOrder(ctx, req, params)
+	s.NoError(err)
+	s.Equal(expected, second)
+}
+
+func (s *IdempotencyServiceTestSuite) TestPayOrder_RetryReplaysUnauthorized() {
+	ctx := context.Background()
+	req := &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}
+	params := orderv1.PayOrderParams{OrderUUID: uuid.New(), IdempotencyKey: orderv1.NewOptString("key-1")}
+	expected := &orderv1.UnauthorizedError{Error: "unauthorized", Message: "session is not valid"}
+
+	next := servicemocks.NewOrderService(s.T())
+	next.On("PayOrder", mock.Anything, req, params).Return(expected, nil).Once()
+
+	service := NewOrderService(next, idempotencyrepo.NewMemoryIdempotencyRepository(time.Hour))
+
+	for i := 0; i < 2; i++ {
+		result, err := service.PayOrder(ctx, req, params)
+		s.NoError(err)
+		s.Equal(expected, result)
+	}
+}
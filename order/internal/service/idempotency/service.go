@@ -0,0 +1,333 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/order/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+// Operations used to scope idempotency keys
+const (
+	OperationCreateOrder = "createOrder"
+	OperationPayOrder    = "payOrder"
+)
+
+// OrderService wraps an OrderService and makes CreateOrder and PayOrder safe
+// to retry with an Idempotency-Key. Keys are scoped to the authenticated user.
+// The first request with a key is executed and its response stored; identical
+// retries get the stored response replayed, a retry while the first request
+// is still running gets 409 and a request reusing the key with a different
+// body gets 422.
+type OrderService struct {
+	service.OrderService
+	idempotencyRepo repository.IdempotencyRepository
+}
+
+// NewOrderService creates a new idempotent order service on top of next
+func NewOrderService(next service.OrderService, idempotencyRepo repository.IdempotencyRepository) *OrderService {
+	return &OrderService{
+		OrderService:    next,
+		idempotencyRepo: idempotencyRepo,
+	}
+}
+
+// CreateOrder creates an order at most once per idempotency key
+func (s *OrderService) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, params orderv1.CreateOrderParams) (orderv1.CreateOrderRes, error) {
+	key, ok := params.IdempotencyKey.Get()
+	if !ok {
+		return s.OrderService.CreateOrder(ctx, req, params)
+	}
+
+	body, err := req.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encode create order request: %w", err)
+	}
+
+	res, replayed, err := guard(ctx, s.idempotencyRepo, OperationCreateOrder, userScopedKey(ctx, key), fingerprint(body), createOrderCodec,
+		func() (orderv1.CreateOrderRes, error) {
+			return s.OrderService.CreateOrder(ctx, req, params)
+		})
+	if replayed {
+		logger.FromContext(ctx).Info("Replayed create order response", slog.String("idempotency_key", key))
+	}
+
+	return res, err
+}
+
+// PayOrder pays an order at most once per idempotency key
+func (s *OrderService) PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, params orderv1.PayOrderParams) (orderv1.PayOrderRes, error) {
+	key, ok := params.IdempotencyKey.Get()
+	if !ok {
+		return s.OrderService.PayOrder(ctx, req, params)
+	}
+
+	body, err := req.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encode pay order request: %w", err)
+	}
+
+	res, replayed, err := guard(ctx, s.idempotencyRepo, OperationPayOrder, userScopedKey(ctx, key), fingerprint([]byte(params.OrderUUID.String()), body), payOrderCodec,
+		func() (orderv1.PayOrderRes, error) {
+			return s.OrderService.PayOrder(ctx, req, params)
+		})
+	if replayed {
+		logger.FromContext(ctx).Info("Replayed pay order response", slog.String("order_uuid", params.OrderUUID.String()), slog.String("idempotency_key", key))
+	}
+
+	return res, err
+}
+
+// codec converts the responses of one operation to and from their stored form
+type codec[R any] struct {
+	encode        func(res R) (statusCode int, body []byte, err error)
+	decode        func(statusCode int, body []byte) (R, error)
+	inProgress    func() R
+	unprocessable func() R
+	internalError func() R
+}
+
+// guard runs call at most once for the operation and key and stores its response.
+// It reports whether the response was replayed from a previous request.
+func guard[R any](
+	ctx context.Context,
+	repo repository.IdempotencyRepository,
+	operation, key, fingerprint string,
+	c codec[R],
+	call func() (R, error),
+) (R, bool, error) {
+	log := logger.FromContext(ctx).With(slog.String("operation", operation), slog.String("idempotency_key", key))
+
+	existing, err := repo.Reserve(ctx, &model.IdempotencyRecord{
+		Operation:   operation,
+		Key:         key,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		log.Error("Failed to reserve idempotency key", logger.Err(err))
+		return c.internalError(), false, nil
+	}
+
+	if existing != nil {
+		switch {
+		case existing.Fingerprint != fingerprint:
+			log.Warn("Idempotency key reused with a different request")
+			return c.unprocessable(), false, nil
+		case !existing.Completed:
+			log.Warn("Idempotency key is still in progress")
+			return c.inProgress(), false, nil
+		}
+
+		res, err := c.decode(existing.StatusCode, existing.Response)
+		if err != nil {
+			log.Error("Failed to decode stored response", logger.Err(err))
+			return c.internalError(), false, nil
+		}
+		return res, true, nil
+	}
+
+	res, err := call()
+	if err != nil {
+		release(ctx, repo, operation, key)
+		return res, false, err
+	}
+
+	statusCode, body, err := c.encode(res)
+	if err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		release(ctx, repo, operation, key)
+		return res, false, nil
+	}
+
+	// Server errors, conflicts and pending outcomes depend on transient state,
+	// so the client should be able to retry them with the same key
+	if statusCode >= http.StatusInternalServerError || statusCode == http.StatusConflict || statusCode == http.StatusAccepted {
+		release(ctx, repo, operation, key)
+		return res, false, nil
+	}
+
+	if err := repo.Complete(ctx, operation, key, statusCode, body); err != nil {
+		log.Error("Failed to store response", logger.Err(err))
+	}
+
+	return res, false, nil
+}
+
+// release drops a reservation so the request can be retried with the same key
+func release(ctx context.Context, repo repository.IdempotencyRepository, operation, key string) {
+	if err := repo.Release(ctx, operation, key); err != nil {
+		logger.FromContext(ctx).Error("Failed to release idempotency key", slog.String("operation", operation), slog.String("idempotency_key", key), logger.Err(err))
+	}
+}
+
+// userScopedKey prefixes key with the authenticated user, so that keys chosen
+// by different users never collide and responses are not replayed to others
+func userScopedKey(ctx context.Context, key string) string {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		return user.UUID.String() + "/" + key
+	}
+	return key
+}
+
+// fingerprint hashes the parts of a request that must match on a retry
+func fingerprint(parts ...[]byte) string {
+	sum := sha256.Sum256(bytes.Join(parts, []byte{0}))
+	return hex.EncodeToString(sum[:])
+}
+
+var createOrderCodec = codec[orderv1.CreateOrderRes]{
+	encode: func(res orderv1.CreateOrderRes) (int, []byte, error) {
+		switch r := res.(type) {
+		case *orderv1.CreateOrderResponse:
+			body, err := r.MarshalJSON()
+			return http.StatusCreated, body, err
+		case *orderv1.BadRequestError:
+			body, err := r.MarshalJSON()
+			return http.StatusBadRequest, body, err
+		case *orderv1.UnauthorizedError:
+			body, err := r.MarshalJSON()
+			return http.StatusUnauthorized, body, err
+		case *orderv1.ConflictError:
+			body, err := r.MarshalJSON()
+			return http.StatusConflict, body, err
+		case *orderv1.UnprocessableEntityError:
+			body, err := r.MarshalJSON()
+			return http.StatusUnprocessableEntity, body, err
+		case *orderv1.InternalServerError:
+			body, err := r.MarshalJSON()
+			return http.StatusInternalServerError, body, err
+		case *orderv1.BadGatewayError:
+			body, err := r.MarshalJSON()
+			return http.StatusBadGateway, body, err
+		case *orderv1.ServiceUnavailableError:
+			body, err := r.MarshalJSON()
+			return http.StatusServiceUnavailable, body, err
+		default:
+			return 0, nil, fmt.Errorf("unexpected create order response %T", res)
+		}
+	},
+	decode: func(statusCode int, body []byte) (orderv1.CreateOrderRes, error) {
+		switch statusCode {
+		case http.StatusCreated:
+			res := &orderv1.CreateOrderResponse{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusBadRequest:
+			res := &orderv1.BadRequestError{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusUnauthorized:
+			res := &orderv1.UnauthorizedError{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusUnprocessableEntity:
+			res := &orderv1.UnprocessableEntityError{}
+			return res, res.UnmarshalJSON(body)
+		default:
+			return nil, fmt.Errorf("unexpected create order status code %d", statusCode)
+		}
+	},
+	inProgress: func() orderv1.CreateOrderRes {
+		return &orderv1.ConflictError{
+			Error:   "idempotency_key_in_use",
+			Message: "a request with this idempotency key is still being processed",
+		}
+	},
+	unprocessable: func() orderv1.CreateOrderRes {
+		return &orderv1.UnprocessableEntityError{
+			Error:   "idempotency_key_reused",
+			Message: "idempotency key was already used with a different request",
+		}
+	},
+	internalError: func() orderv1.CreateOrderRes {
+		return &orderv1.InternalServerError{
+			Error:   "idempotency_failed",
+			Message: "failed to process idempotency key",
+		}
+	},
+}
+
+var payOrderCodec = codec[orderv1.PayOrderRes]{
+	encode: func(res orderv1.PayOrderRes) (int, []byte, error) {
+		switch r := res.(type) {
+		case *orderv1.PayOrderResponse:
+			body, err := r.MarshalJSON()
+			return http.StatusOK, body, err
+		case *orderv1.PaymentPendingResponse:
+			body, err := r.MarshalJSON()
+			return http.StatusAccepted, body, err
+		case *orderv1.BadRequestError:
+			body, err := r.MarshalJSON()
+			return http.StatusBadRequest, body, err
+		case *orderv1.UnauthorizedError:
+			body, err := r.MarshalJSON()
+			return http.StatusUnauthorized, body, err
+		case *orderv1.NotFoundError:
+			body, err := r.MarshalJSON()
+			return http.StatusNotFound, body, err
+		case *orderv1.ConflictError:
+			body, err := r.MarshalJSON()
+			return http.StatusConflict, body, err
+		case *orderv1.UnprocessableEntityError:
+			body, err := r.MarshalJSON()
+			return http.StatusUnprocessableEntity, body, err
+		case *orderv1.InternalServerError:
+			body, err := r.MarshalJSON()
+			return http.StatusInternalServerError, body, err
+		case *orderv1.BadGatewayError:
+			body, err := r.MarshalJSON()
+			return http.StatusBadGateway, body, err
+		case *orderv1.ServiceUnavailableError:
+			body, err := r.MarshalJSON()
+			return http.StatusServiceUnavailable, body, err
+		default:
+			return 0, nil, fmt.Errorf("unexpected pay order response %T", res)
+		}
+	},
+	decode: func(statusCode int, body []byte) (orderv1.PayOrderRes, error) {
+		switch statusCode {
+		case http.StatusOK:
+			res := &orderv1.PayOrderResponse{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusBadRequest:
+			res := &orderv1.BadRequestError{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusUnauthorized:
+			res := &orderv1.UnauthorizedError{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusNotFound:
+			res := &orderv1.NotFoundError{}
+			return res, res.UnmarshalJSON(body)
+		case http.StatusUnprocessableEntity:
+			res := &orderv1.UnprocessableEntityError{}
+			return res, res.UnmarshalJSON(body)
+		default:
+			return nil, fmt.Errorf("unexpected pay order status code %d", statusCode)
+		}
+	},
+	inProgress: func() orderv1.PayOrderRes {
+		return &orderv1.ConflictError{
+			Error:   "idempotency_key_in_use",
+			Message: "a request with this idempotency key is still being processed",
+		}
+	},
+	unprocessable: func() orderv1.PayOrderRes {
+		return &orderv1.UnprocessableEntityError{
+			Error:   "idempotency_key_reused",
+			Message: "idempotency key was already used with a different request",
+		}
+	},
+	internalError: func() orderv1.PayOrderRes {
+		return &orderv1.InternalServerError{
+			Error:   "idempotency_failed",
+			Message: "failed to process idempotency key",
+		}
+	},
+}
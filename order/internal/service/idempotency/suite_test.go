@@ -0,0 +1,15 @@
+package idempotency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyServiceTestSuite struct {
+	suite.Suite
+}
+
+func TestIdempotencyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceTestSuite))
+}
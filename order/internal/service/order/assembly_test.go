@@ -0,0 +1,169 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+)
+
+func newAssemblyTestOrder(status model.OrderStatus) *model.Order {
+	return &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		Status:    status,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (s *OrderServiceTestSuite) TestStartAssembly_Success() {
+	order := newAssemblyTestOrder(model.StatusPaid)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(o *model.Order) bool {
+		return o.UUID == order.UUID && o.Status == model.StatusAssembling
+	})).Return(nil)
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.StartAssembly(context.Background(), order.UUID)
+
+	s.NoError(err)
+}
+
+func (s *OrderServiceTestSuite) TestStartAssembly_AlreadyStarted() {
+	for _, status := range []model.OrderStatus{model.StatusAssembling, model.StatusCompleted} {
+		order := newAssemblyTestOrder(status)
+
+		mockRepo := repomocks.NewOrderRepository(s.T())
+		mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+		service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+		err := service.StartAssembly(context.Background(), order.UUID)
+
+		s.NoError(err, status)
+	}
+}
+
+func (s *OrderServiceTestSuite) TestStartAssembly_InvalidStatus() {
+	order := newAssemblyTestOrder(model.StatusCancelled)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.StartAssembly(context.Background(), order.UUID)
+
+	var serviceErr *model.ServiceError
+	s.Require().ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodeInvalidStatus, serviceErr.Code)
+}
+
+func (s *OrderServiceTestSuite) TestAssembly_OrderSettlingIsRetried() {
+	for _, status := range []model.OrderStatus{model.StatusPaymentInProgress, model.StatusRefundInProgress} {
+		order := newAssemblyTestOrder(status)
+
+		mockRepo := repomocks.NewOrderRepository(s.T())
+		mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+		service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+		var serviceErr *model.ServiceError
+		err := service.StartAssembly(context.Background(), order.UUID)
+		s.ErrorIs(err, model.ErrOrderSettling, status)
+		s.False(errors.As(err, &serviceErr), "settling orders must be retried")
+
+		err = service.CompleteAssembly(context.Background(), order.UUID)
+		s.ErrorIs(err, model.ErrOrderSettling, status)
+		s.False(errors.As(err, &serviceErr), "settling orders must be retried")
+	}
+}
+
+func (s *OrderServiceTestSuite) TestStartAssembly_OrderNotFound() {
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).
+		Return(nil, fmt.Errorf("order with UUID %s: %w", orderUUID, model.ErrOrderNotFound))
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.StartAssembly(context.Background(), orderUUID)
+
+	var serviceErr *model.ServiceError
+	s.Require().ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodeOrderNotFound, serviceErr.Code)
+}
+
+func (s *OrderServiceTestSuite) TestStartAssembly_StorageError() {
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(nil, assert.AnError)
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.StartAssembly(context.Background(), orderUUID)
+
+	s.ErrorIs(err, assert.AnError)
+	var serviceErr *model.ServiceError
+	s.False(errors.As(err, &serviceErr), "storage errors must be retried")
+}
+
+func (s *OrderServiceTestSuite) TestCompleteAssembly_Success() {
+	for _, status := range []model.OrderStatus{model.StatusPaid, model.StatusAssembling} {
+		order := newAssemblyTestOrder(status)
+
+		mockRepo := repomocks.NewOrderRepository(s.T())
+		mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(o *model.Order) bool {
+			return o.UUID == order.UUID && o.Status == model.StatusCompleted
+		})).Return(nil)
+
+		service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+		err := service.CompleteAssembly(context.Background(), order.UUID)
+
+		s.NoError(err, status)
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCompleteAssembly_AlreadyCompleted() {
+	order := newAssemblyTestOrder(model.StatusCompleted)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.CompleteAssembly(context.Background(), order.UUID)
+
+	s.NoError(err)
+}
+
+func (s *OrderServiceTestSuite) TestCompleteAssembly_VersionConflict() {
+	order := newAssemblyTestOrder(model.StatusAssembling)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("order with UUID %s: %w", order.UUID, model.ErrVersionConflict))
+
+	service := NewOrderService(mockRepo, nil, nil, nil, nil, nil, 0)
+
+	err := service.CompleteAssembly(context.Background(), order.UUID)
+
+	s.ErrorIs(err, model.ErrVersionConflict)
+}
@@ -26,7 +26,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_Success() {
 	existingOrder := &model.Order{
 		UUID:      orderUUID,
 		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
 		Status:    model.StatusPendingPayment,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -35,13 +35,17 @@ func (s *OrderServiceTestSuite) TestCancelOrder_Success() {
 	mockRepo := repomocks.NewOrderRepository(s.T())
 	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
 	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
-		return order.UUID == orderUUID && order.Status == model.StatusCancelled
+		return order.UUID == orderUUID &&
+			order.Status == model.StatusCancelled &&
+			order.CancellationReason == model.CancellationReasonUserCancelled &&
+			order.CancelledAt != nil
 	})).Return(nil)
 
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReleaseReservation", mock.Anything, orderUUID).Return(nil)
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.CancelOrder(ctx, params)
 
@@ -54,6 +58,30 @@ func (s *OrderServiceTestSuite) TestCancelOrder_Success() {
 	mockRepo.AssertExpectations(s.T())
 }
 
+func (s *OrderServiceTestSuite) TestCancelOrder_ReturnsCommittedReservation() {
+	ctx := context.Background()
+	existingOrder := pendingOrder(time.Now())
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, existingOrder.UUID).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusCancelled
+	})).Return(nil)
+
+	// The reservation was committed for a payment that then failed
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReleaseReservation", mock.Anything, existingOrder.UUID).
+		Return(&model.ServiceError{Code: model.ErrCodeConflict, Message: "reservation is committed"}).Once()
+	mockInventoryClient.On("ReturnParts", mock.Anything, existingOrder.UUID, toReservationItems(existingOrder.Items)).Return(nil).Once()
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, nil, nil, 0)
+
+	result, err := service.CancelOrder(ctx, orderv1.CancelOrderParams{OrderUUID: existingOrder.UUID})
+
+	s.NoError(err)
+	s.IsType(&orderv1.CancelOrderNoContent{}, result)
+}
+
 func (s *OrderServiceTestSuite) TestCancelOrder_OrderNotFound() {
 	ctx := context.Background()
 	orderUUID := uuid.New()
@@ -68,7 +96,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_OrderNotFound() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.CancelOrder(ctx, params)
 
@@ -95,7 +123,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_InvalidStatus() {
 	existingOrder := &model.Order{
 		UUID:      orderUUID,
 		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
 		Status:    model.StatusPaid,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -107,7 +135,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_InvalidStatus() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.CancelOrder(ctx, params)
 
@@ -134,7 +162,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_UpdateFailed() {
 	existingOrder := &model.Order{
 		UUID:      orderUUID,
 		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
 		Status:    model.StatusPendingPayment,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -147,7 +175,7 @@ func (s *OrderServiceTestSuite) TestCancelOrder_UpdateFailed() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.CancelOrder(ctx, params)
 
@@ -161,3 +189,40 @@ func (s *OrderServiceTestSuite) TestCancelOrder_UpdateFailed() {
 
 	mockRepo.AssertExpectations(s.T())
 }
+
+func (s *OrderServiceTestSuite) TestCancelOrder_ConcurrentModification() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	params := orderv1.CancelOrderParams{
+		OrderUUID: orderUUID,
+	}
+
+	existingOrder := &model.Order{
+		UUID:      orderUUID,
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		Status:    model.StatusPendingPayment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(model.ErrVersionConflict)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.CancelOrder(ctx, params)
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("concurrent_modification", conflictErr.Error)
+
+	mockRepo.AssertExpectations(s.T())
+}
@@ -2,11 +2,14 @@ package order
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
 	"github.com/nimbodex/microservices-factory/order/internal/client"
 	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
 	"github.com/nimbodex/microservices-factory/order/internal/model"
@@ -14,41 +17,54 @@ import (
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
+// partsLookup reports every part as found
+func partsLookup(parts ...*client.Part) *client.PartsLookup {
+	lookup := &client.PartsLookup{Parts: make(map[uuid.UUID]*client.Part, len(parts))}
+	for _, part := range parts {
+		lookup.Parts[part.UUID] = part
+	}
+	return lookup
+}
+
 func (s *OrderServiceTestSuite) TestCreateOrder_Success() {
-	ctx := context.Background()
 	userUUID := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: userUUID})
 	partUUID1 := uuid.New()
 	partUUID2 := uuid.New()
 
 	req := &orderv1.CreateOrderRequest{
-		UserUUID:  userUUID,
-		PartUuids: []uuid.UUID{partUUID1, partUUID2},
+		Items: []orderv1.CreateOrderItem{
+			{PartUUID: partUUID1, Quantity: 3},
+			{PartUUID: partUUID2, Quantity: 1},
+		},
 	}
 
 	mockRepo := repomocks.NewOrderRepository(s.T())
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
 		return order.UserUUID == userUUID &&
-			len(order.PartUUIDs) == 2 &&
+			len(order.Items) == 2 &&
+			order.Items[0] == model.OrderItem{PartUUID: partUUID1, Quantity: 3, UnitPrice: 100.0, TotalPrice: 300.0} &&
+			order.Items[1] == model.OrderItem{PartUUID: partUUID2, Quantity: 1, UnitPrice: 200.0, TotalPrice: 200.0} &&
+			order.TotalPrice == 500.0 &&
 			order.Status == model.StatusPendingPayment
 	})).Return(nil)
 
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
-	mockInventoryClient.On("GetPart", mock.Anything, partUUID1).Return(&client.Part{
-		UUID:  partUUID1,
-		Name:  "Part 1",
-		Price: 100.0,
-	}, nil)
-	mockInventoryClient.On("GetPart", mock.Anything, partUUID2).Return(&client.Part{
-		UUID:  partUUID2,
-		Name:  "Part 2",
-		Price: 200.0,
-	}, nil)
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID1, partUUID2}).Return(partsLookup(
+		&client.Part{UUID: partUUID1, Name: "Part 1", Price: 100.0, StockQuantity: 3},
+		&client.Part{UUID: partUUID2, Name: "Part 2", Price: 200.0, StockQuantity: 10},
+	), nil).Once()
+	mockInventoryClient.On("ReserveParts", mock.Anything, mock.Anything, []client.ReservationItem{
+		{PartUUID: partUUID1, Quantity: 3},
+		{PartUUID: partUUID2, Quantity: 1},
+	}, 16*time.Minute).
+		Return(&client.Reservation{Reserved: true, ExpiresAt: time.Now().Add(time.Minute)}, nil)
 
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 16*time.Minute)
 
-	result, err := service.CreateOrder(ctx, req)
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
 
 	s.NoError(err)
 	s.NotNil(result)
@@ -56,32 +72,32 @@ func (s *OrderServiceTestSuite) TestCreateOrder_Success() {
 	createResp, ok := result.(*orderv1.CreateOrderResponse)
 	s.True(ok)
 	s.NotEmpty(createResp.OrderUUID)
-	s.Equal(0.0, createResp.TotalPrice)
+	s.Equal(500.0, createResp.TotalPrice)
 
 	mockRepo.AssertExpectations(s.T())
 	mockInventoryClient.AssertExpectations(s.T())
 }
 
 func (s *OrderServiceTestSuite) TestCreateOrder_PartNotFound() {
-	ctx := context.Background()
 	userUUID := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: userUUID})
 	partUUID := uuid.New()
 
 	req := &orderv1.CreateOrderRequest{
-		UserUUID:  userUUID,
-		PartUuids: []uuid.UUID{partUUID},
+		Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 1}},
 	}
 
 	mockRepo := repomocks.NewOrderRepository(s.T())
 
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
-	mockInventoryClient.On("GetPart", mock.Anything, partUUID).Return(nil, assert.AnError)
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID}).
+		Return(&client.PartsLookup{Parts: map[uuid.UUID]*client.Part{}, MissingPartUUIDs: []uuid.UUID{partUUID}}, nil)
 
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
-	result, err := service.CreateOrder(ctx, req)
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
 
 	s.NoError(err)
 	s.NotNil(result)
@@ -95,31 +111,103 @@ func (s *OrderServiceTestSuite) TestCreateOrder_PartNotFound() {
 	mockInventoryClient.AssertExpectations(s.T())
 }
 
+func (s *OrderServiceTestSuite) TestCreateOrder_InventoryErrors() {
+	tests := []struct {
+		code      string
+		wantType  any
+		wantError string
+	}{
+		{code: model.ErrCodeInvalidArgument, wantType: &orderv1.BadRequestError{}, wantError: "inventory_rejected"},
+		{code: model.ErrCodeConflict, wantType: &orderv1.ConflictError{}, wantError: "inventory_conflict"},
+		{code: model.ErrCodeUnavailable, wantType: &orderv1.ServiceUnavailableError{}, wantError: "inventory_unavailable"},
+		{code: model.ErrCodeDeadlineExceeded, wantType: &orderv1.ServiceUnavailableError{}, wantError: "inventory_timeout"},
+		{code: model.ErrCodeExternalServiceErr, wantType: &orderv1.BadGatewayError{}, wantError: "inventory_error"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.code, func() {
+			ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+			partUUID := uuid.New()
+
+			req := &orderv1.CreateOrderRequest{
+				Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 1}},
+			}
+
+			mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+			mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID}).
+				Return(nil, fmt.Errorf("failed to list parts: %w", &model.ServiceError{Code: tt.code, Message: "inventory says no"}))
+
+			service := NewOrderService(repomocks.NewOrderRepository(s.T()), nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+			result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
+
+			s.NoError(err)
+			s.IsType(tt.wantType, result)
+
+			errorOf, ok := result.(interface{ GetError() string })
+			s.Require().True(ok)
+			s.Equal(tt.wantError, errorOf.GetError())
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_InventoryRejectionDetails() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	partUUID := uuid.New()
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID}).
+		Return(nil, &model.ServiceError{
+			Code:    model.ErrCodeInvalidArgument,
+			Message: "invalid UUID: x",
+			Reason:  "INVALID_UUID",
+			Fields:  map[string]string{"filter.uuids[0]": "invalid UUID: x"},
+		})
+
+	service := NewOrderService(repomocks.NewOrderRepository(s.T()), nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 1}},
+	}, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+	badReqErr, ok := result.(*orderv1.BadRequestError)
+	s.Require().True(ok)
+	s.Equal("inventory_rejected", badReqErr.Error)
+	s.Require().True(badReqErr.Details.Set)
+	s.JSONEq(`"INVALID_UUID"`, string(badReqErr.Details.Value["reason"]))
+	s.JSONEq(`{"filter.uuids[0]": "invalid UUID: x"}`, string(badReqErr.Details.Value["fields"]))
+}
+
 func (s *OrderServiceTestSuite) TestCreateOrder_RepositoryError() {
-	ctx := context.Background()
 	userUUID := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: userUUID})
 	partUUID := uuid.New()
 
 	req := &orderv1.CreateOrderRequest{
-		UserUUID:  userUUID,
-		PartUuids: []uuid.UUID{partUUID},
+		Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 1}},
 	}
 
+	var orderUUID uuid.UUID
 	mockRepo := repomocks.NewOrderRepository(s.T())
-	mockRepo.On("Create", mock.Anything, mock.Anything).Return(assert.AnError)
+	mockRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { orderUUID = args.Get(1).(*model.Order).UUID }).
+		Return(assert.AnError)
 
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
-	mockInventoryClient.On("GetPart", mock.Anything, partUUID).Return(&client.Part{
-		UUID:  partUUID,
-		Name:  "Part 1",
-		Price: 100.0,
-	}, nil)
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID}).
+		Return(partsLookup(&client.Part{UUID: partUUID, Name: "Part 1", Price: 100.0, StockQuantity: 1}), nil)
+	mockInventoryClient.On("ReserveParts", mock.Anything, mock.Anything, []client.ReservationItem{{PartUUID: partUUID, Quantity: 1}}, mock.Anything).
+		Return(&client.Reservation{Reserved: true, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	mockInventoryClient.On("ReleaseReservation", mock.Anything, mock.MatchedBy(func(reservedFor uuid.UUID) bool {
+		return reservedFor == orderUUID
+	})).Return(nil)
 
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
-	result, err := service.CreateOrder(ctx, req)
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
 
 	s.NoError(err)
 	s.NotNil(result)
@@ -132,3 +220,145 @@ func (s *OrderServiceTestSuite) TestCreateOrder_RepositoryError() {
 	mockRepo.AssertExpectations(s.T())
 	mockInventoryClient.AssertExpectations(s.T())
 }
+
+func (s *OrderServiceTestSuite) TestCreateOrder_InsufficientStock() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	partUUID1 := uuid.New()
+	partUUID2 := uuid.New()
+	partUUID3 := uuid.New()
+
+	req := &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{
+			{PartUUID: partUUID1, Quantity: 2},
+			{PartUUID: partUUID2, Quantity: 5},
+			{PartUUID: partUUID3, Quantity: 1},
+		},
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID1, partUUID2, partUUID3}).Return(partsLookup(
+		&client.Part{UUID: partUUID1, Price: 100.0, StockQuantity: 1},
+		&client.Part{UUID: partUUID2, Price: 200.0, StockQuantity: 5},
+		&client.Part{UUID: partUUID3, Price: 50.0},
+	), nil)
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("insufficient_stock", conflictErr.Error)
+	s.Equal([]uuid.UUID{partUUID1, partUUID3}, conflictErr.UnavailablePartUuids)
+
+	mockInventoryClient.AssertNotCalled(s.T(), "ReserveParts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_ReservationRejected() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	partUUID1 := uuid.New()
+	partUUID2 := uuid.New()
+
+	req := &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{
+			{PartUUID: partUUID1, Quantity: 1},
+			{PartUUID: partUUID2, Quantity: 1},
+		},
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+
+	// The catalogue shows enough stock but a concurrent order takes it before the reservation
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID1, partUUID2}).Return(partsLookup(
+		&client.Part{UUID: partUUID1, Price: 100.0, StockQuantity: 1},
+		&client.Part{UUID: partUUID2, Price: 200.0, StockQuantity: 1},
+	), nil)
+	mockInventoryClient.On("ReserveParts", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&client.Reservation{Reserved: false, UnavailablePartUUIDs: []uuid.UUID{partUUID2}}, nil)
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("insufficient_stock", conflictErr.Error)
+	s.Equal([]uuid.UUID{partUUID2}, conflictErr.UnavailablePartUuids)
+
+	mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_ReservationFailed() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	partUUID := uuid.New()
+
+	req := &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 1}},
+	}
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("GetParts", mock.Anything, []uuid.UUID{partUUID}).
+		Return(partsLookup(&client.Part{UUID: partUUID, Price: 100.0, StockQuantity: 1}), nil)
+	mockInventoryClient.On("ReserveParts", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	service := NewOrderService(repomocks.NewOrderRepository(s.T()), nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+
+	badGatewayErr, ok := result.(*orderv1.BadGatewayError)
+	s.True(ok)
+	s.Equal("inventory_error", badGatewayErr.Error)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_DuplicatePart() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	partUUID := uuid.New()
+
+	req := &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{
+			{PartUUID: partUUID, Quantity: 1},
+			{PartUUID: partUUID, Quantity: 2},
+		},
+	}
+
+	service := NewOrderService(
+		repomocks.NewOrderRepository(s.T()),
+		nil,
+		nil,
+		clientmocks.NewInventoryClient(s.T()),
+		clientmocks.NewPaymentClient(s.T()),
+		nil,
+		0,
+	)
+
+	result, err := service.CreateOrder(ctx, req, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+
+	badReqErr, ok := result.(*orderv1.BadRequestError)
+	s.True(ok)
+	s.Equal("duplicate_part", badReqErr.Error)
+}
+
+func (s *OrderServiceTestSuite) TestCreateOrder_Unauthenticated() {
+	service := NewOrderService(repomocks.NewOrderRepository(s.T()), nil, nil, nil, nil, nil, 0)
+
+	result, err := service.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+	}, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+	unauthorized, ok := result.(*orderv1.UnauthorizedError)
+	s.True(ok)
+	s.Equal("missing_session", unauthorized.Error)
+}
@@ -0,0 +1,188 @@
+package order
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
+	"github.com/nimbodex/microservices-factory/order/internal/repository/outbox"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+	eventsv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/events/v1"
+)
+
+func (s *OrderServiceTestSuite) TestEvents_RecordedForOrderLifecycle() {
+	userUUID := uuid.New()
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: userUUID})
+	partUUID := uuid.New()
+
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+	service := NewOrderService(orderrepo.NewMemoryOrderRepository(), outboxRepo, nil, nil, nil, nil, 0)
+
+	createRes, err := service.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: partUUID, Quantity: 2}},
+	}, orderv1.CreateOrderParams{})
+	s.Require().NoError(err)
+	created, ok := createRes.(*orderv1.CreateOrderResponse)
+	s.Require().True(ok)
+
+	payRes, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodSBP},
+		orderv1.PayOrderParams{OrderUUID: created.OrderUUID})
+	s.Require().NoError(err)
+	paid, ok := payRes.(*orderv1.PayOrderResponse)
+	s.Require().True(ok)
+
+	events, err := outboxRepo.ListUnpublished(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+
+	s.Equal(model.EventTypeOrderCreated, events[0].Type)
+	s.Equal(model.EventVersion, events[0].Version)
+	s.Equal(created.OrderUUID, events[0].OrderUUID)
+	var orderCreated eventsv1.OrderCreated
+	s.Require().NoError(proto.Unmarshal(events[0].Payload, &orderCreated))
+	s.Equal(userUUID.String(), orderCreated.UserUuid)
+	s.Require().Len(orderCreated.Items, 1)
+	s.Equal(partUUID.String(), orderCreated.Items[0].PartUuid)
+	s.Equal(int32(2), orderCreated.Items[0].Quantity)
+
+	s.Equal(model.EventTypeOrderPaid, events[1].Type)
+	var orderPaid eventsv1.OrderPaid
+	s.Require().NoError(proto.Unmarshal(events[1].Payload, &orderPaid))
+	s.Equal(created.OrderUUID.String(), orderPaid.OrderUuid)
+	s.Equal(paid.TransactionUUID.String(), orderPaid.TransactionUuid)
+	s.Equal(string(model.PaymentMethodSBP), orderPaid.PaymentMethod)
+	s.NotNil(orderPaid.PaidAt)
+	s.NotEqual(events[0].UUID, events[1].UUID)
+}
+
+func (s *OrderServiceTestSuite) TestEvents_RecordedForCancellation() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+	service := NewOrderService(orderrepo.NewMemoryOrderRepository(), outboxRepo, nil, nil, nil, nil, 0)
+
+	createRes, err := service.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+	}, orderv1.CreateOrderParams{})
+	s.Require().NoError(err)
+	created, ok := createRes.(*orderv1.CreateOrderResponse)
+	s.Require().True(ok)
+
+	cancelRes, err := service.CancelOrder(ctx, orderv1.CancelOrderParams{OrderUUID: created.OrderUUID})
+	s.Require().NoError(err)
+	s.IsType(&orderv1.CancelOrderNoContent{}, cancelRes)
+
+	events, err := outboxRepo.ListUnpublished(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2)
+
+	s.Equal(model.EventTypeOrderCancelled, events[1].Type)
+	var orderCancelled eventsv1.OrderCancelled
+	s.Require().NoError(proto.Unmarshal(events[1].Payload, &orderCancelled))
+	s.Equal(created.OrderUUID.String(), orderCancelled.OrderUuid)
+	s.Equal(string(model.CancellationReasonUserCancelled), orderCancelled.Reason)
+	s.NotNil(orderCancelled.CancelledAt)
+}
+
+func (s *OrderServiceTestSuite) TestEvents_RecordedForRefundBeforePartsReturn() {
+	ctx := context.Background()
+	order := paidOrder(200.0)
+
+	orderRepo := orderrepo.NewMemoryOrderRepository()
+	s.Require().NoError(orderRepo.Create(ctx, order))
+	outboxRepo := outbox.NewMemoryOutboxRepository()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 200.0, "").
+		Return(&client.RefundResult{RefundUUID: uuid.New(), RefundedAmount: 200.0}, nil)
+
+	// Parts go back only once the refunded order and its event are stored
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReturnParts", mock.Anything, order.UUID, toReservationItems(order.Items)).
+		Run(func(mock.Arguments) {
+			stored, err := orderRepo.GetByUUID(ctx, order.UUID)
+			s.Require().NoError(err)
+			s.Equal(model.StatusRefunded, stored.Status)
+
+			events, err := outboxRepo.ListUnpublished(ctx, 10)
+			s.Require().NoError(err)
+			s.Len(events, 1)
+		}).
+		Return(nil).Once()
+
+	service := NewOrderService(orderRepo, outboxRepo, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	_, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+	s.Require().NoError(err)
+
+	events, err := outboxRepo.ListUnpublished(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1)
+	s.Equal(model.EventTypeOrderRefunded, events[0].Type)
+
+	var orderRefunded eventsv1.OrderRefunded
+	s.Require().NoError(proto.Unmarshal(events[0].Payload, &orderRefunded))
+	s.Equal(order.UUID.String(), orderRefunded.OrderUuid)
+	s.Equal(order.TransactionUUID.String(), orderRefunded.TransactionUuid)
+	s.Equal(200.0, orderRefunded.RefundedAmount)
+	s.True(orderRefunded.FullyRefunded)
+}
+
+func (s *OrderServiceTestSuite) TestEvents_RefundNotStoredKeepsParts() {
+	ctx := context.Background()
+	order := paidOrder(200.0)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefundInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefunded
+	})).Return(assert.AnError).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 200.0, "").
+		Return(&client.RefundResult{RefundUUID: uuid.New(), RefundedAmount: 200.0}, nil)
+
+	// The order stays claimed for the reconciler, which returns the parts once it settles the refund
+	service := NewOrderService(mockRepo, outbox.NewMemoryOutboxRepository(), nil, clientmocks.NewInventoryClient(s.T()), mockPaymentClient, nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+	s.IsType(&orderv1.InternalServerError{}, result)
+}
+
+func (s *OrderServiceTestSuite) TestEvents_OutboxFailureFailsCreation() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	mockOutbox := repomocks.NewOutboxRepository(s.T())
+	mockOutbox.On("Add", mock.Anything, mock.MatchedBy(func(event *model.Event) bool {
+		return event.Type == model.EventTypeOrderCreated
+	})).Return(assert.AnError)
+
+	service := NewOrderService(mockRepo, mockOutbox, nil, nil, nil, nil, 0)
+
+	result, err := service.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+	}, orderv1.CreateOrderParams{})
+
+	s.NoError(err)
+
+	internalErr, ok := result.(*orderv1.InternalServerError)
+	s.True(ok)
+	s.Equal("creation_failed", internalErr.Error)
+}
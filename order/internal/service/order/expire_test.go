@@ -0,0 +1,97 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+)
+
+func pendingOrder(createdAt time.Time) *model.Order {
+	return &model.Order{
+		UUID:      uuid.New(),
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		Status:    model.StatusPendingPayment,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func (s *OrderServiceTestSuite) TestExpireOrders_CancelsAndReleases() {
+	ctx := context.Background()
+	cutoff := time.Now().Add(-15 * time.Minute)
+	first := pendingOrder(cutoff.Add(-time.Minute))
+	second := pendingOrder(cutoff.Add(-2 * time.Minute))
+	third := pendingOrder(cutoff.Add(-3 * time.Minute))
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query *model.ListOrdersQuery) bool {
+		return query.After == nil &&
+			*query.Filter.Status == model.StatusPendingPayment &&
+			query.Filter.CreatedTo.Equal(cutoff) &&
+			query.Limit == 2
+	})).Return([]*model.Order{first, second}, nil).Once()
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query *model.ListOrdersQuery) bool {
+		return query.After != nil && query.After.UUID == second.UUID
+	})).Return([]*model.Order{third}, nil).Once()
+
+	// The second order gets paid while expiring and must be skipped
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.UUID == second.UUID
+	})).Return(model.ErrVersionConflict).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.UUID != second.UUID &&
+			order.Status == model.StatusCancelled &&
+			order.CancellationReason == model.CancellationReasonExpired &&
+			order.CancelledAt != nil
+	})).Return(nil).Twice()
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReleaseReservation", mock.Anything, first.UUID).Return(nil).Once()
+	mockInventoryClient.On("ReleaseReservation", mock.Anything, third.UUID).Return(nil).Once()
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	expired, err := service.ExpireOrders(ctx, cutoff, 2)
+
+	s.NoError(err)
+	s.Equal(2, expired)
+	mockInventoryClient.AssertNotCalled(s.T(), "ReleaseReservation", mock.Anything, second.UUID)
+}
+
+func (s *OrderServiceTestSuite) TestExpireOrders_ListError() {
+	ctx := context.Background()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	expired, err := service.ExpireOrders(ctx, time.Now(), 10)
+
+	s.ErrorIs(err, assert.AnError)
+	s.Zero(expired)
+}
+
+func (s *OrderServiceTestSuite) TestExpireOrders_UpdateError() {
+	ctx := context.Background()
+	order := pendingOrder(time.Now().Add(-time.Hour))
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.Anything).Return([]*model.Order{order}, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	expired, err := service.ExpireOrders(ctx, time.Now(), 10)
+
+	s.ErrorIs(err, assert.AnError)
+	s.Zero(expired)
+}
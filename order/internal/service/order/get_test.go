@@ -26,12 +26,16 @@ func (s *OrderServiceTestSuite) TestGetOrder_Success() {
 	}
 
 	expectedOrder := &model.Order{
-		UUID:      orderUUID,
-		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{partUUID1, partUUID2},
-		Status:    model.StatusPendingPayment,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UUID:     orderUUID,
+		UserUUID: userUUID,
+		Items: []model.OrderItem{
+			{PartUUID: partUUID1, Quantity: 2, UnitPrice: 100.0, TotalPrice: 200.0},
+			{PartUUID: partUUID2, Quantity: 1, UnitPrice: 250.5, TotalPrice: 250.5},
+		},
+		TotalPrice: 450.5,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	mockRepo := repomocks.NewOrderRepository(s.T())
@@ -40,7 +44,7 @@ func (s *OrderServiceTestSuite) TestGetOrder_Success() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.GetOrder(ctx, params)
 
@@ -51,9 +55,95 @@ func (s *OrderServiceTestSuite) TestGetOrder_Success() {
 	s.True(ok)
 	s.Equal(orderUUID, getResp.OrderUUID)
 	s.Equal(userUUID, getResp.UserUUID)
-	s.Len(getResp.PartUuids, 2)
+	s.Equal([]orderv1.OrderItemDto{
+		{PartUUID: partUUID1, Quantity: 2, UnitPrice: 100.0, TotalPrice: 200.0},
+		{PartUUID: partUUID2, Quantity: 1, UnitPrice: 250.5, TotalPrice: 250.5},
+	}, getResp.Items)
 	s.Equal(orderv1.OrderStatus(model.StatusPendingPayment), getResp.Status)
-	s.Equal(0.0, getResp.TotalPrice)
+	s.Equal(450.5, getResp.TotalPrice)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestGetOrder_PaidOrder() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+	transactionUUID := uuid.New()
+	paidAt := time.Now()
+
+	params := orderv1.GetOrderParams{
+		OrderUUID: orderUUID,
+	}
+
+	expectedOrder := &model.Order{
+		UUID:            orderUUID,
+		UserUUID:        uuid.New(),
+		Items:           []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		TotalPrice:      100.0,
+		Status:          model.StatusPaid,
+		TransactionUUID: &transactionUUID,
+		PaymentMethod:   model.PaymentMethodSBP,
+		PaidAt:          &paidAt,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       paidAt,
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(expectedOrder, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.GetOrder(ctx, params)
+
+	s.NoError(err)
+
+	getResp, ok := result.(*orderv1.GetOrderResponse)
+	s.True(ok)
+	s.Equal(orderv1.OrderStatusPAID, getResp.Status)
+	s.Equal(orderv1.NewOptNilUUID(transactionUUID), getResp.TransactionUUID)
+	s.Equal(orderv1.NewOptPaymentMethod(orderv1.PaymentMethodSBP), getResp.PaymentMethod)
+	s.Equal(orderv1.NewOptNilDateTime(paidAt), getResp.PaidAt)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestGetOrder_PendingOrderHasNoPaymentDetails() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	params := orderv1.GetOrderParams{
+		OrderUUID: orderUUID,
+	}
+
+	expectedOrder := &model.Order{
+		UUID:      orderUUID,
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		Status:    model.StatusPendingPayment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(expectedOrder, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.GetOrder(ctx, params)
+
+	s.NoError(err)
+
+	getResp, ok := result.(*orderv1.GetOrderResponse)
+	s.True(ok)
+	s.False(getResp.TransactionUUID.IsSet())
+	s.False(getResp.PaymentMethod.IsSet())
+	s.False(getResp.PaidAt.IsSet())
 
 	mockRepo.AssertExpectations(s.T())
 }
@@ -72,7 +162,7 @@ func (s *OrderServiceTestSuite) TestGetOrder_NotFound() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.GetOrder(ctx, params)
 
@@ -0,0 +1,150 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	"github.com/nimbodex/microservices-factory/order/internal/converter"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func (s *OrderServiceTestSuite) TestListOrders_Success() {
+	ctx := context.Background()
+	userUUID := uuid.New()
+	now := time.Now()
+
+	params := orderv1.ListOrdersParams{
+		UserUUID: orderv1.NewOptUUID(userUUID),
+		Status:   orderv1.NewOptOrderStatus(orderv1.OrderStatusPENDINGPAYMENT),
+		Limit:    orderv1.NewOptInt(2),
+	}
+
+	orders := []*model.Order{
+		{UUID: uuid.New(), UserUUID: userUUID, Status: model.StatusPendingPayment, CreatedAt: now},
+		{UUID: uuid.New(), UserUUID: userUUID, Status: model.StatusPendingPayment, CreatedAt: now.Add(-time.Minute)},
+		{UUID: uuid.New(), UserUUID: userUUID, Status: model.StatusPendingPayment, CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query *model.ListOrdersQuery) bool {
+		return query.Limit == 3 &&
+			query.After == nil &&
+			*query.Filter.UserUUID == userUUID &&
+			*query.Filter.Status == model.StatusPendingPayment
+	})).Return(orders, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.ListOrders(ctx, params)
+
+	s.NoError(err)
+
+	listResp, ok := result.(*orderv1.ListOrdersResponse)
+	s.True(ok)
+	s.Len(listResp.Orders, 2)
+	s.Equal(orders[0].UUID, listResp.Orders[0].OrderUUID)
+	s.Equal(orders[1].UUID, listResp.Orders[1].OrderUUID)
+
+	nextCursor, ok := listResp.NextCursor.Get()
+	s.True(ok)
+
+	cursor, err := converter.DecodeOrderCursor(nextCursor)
+	s.NoError(err)
+	s.Equal(orders[1].UUID, cursor.UUID)
+	s.True(orders[1].CreatedAt.Equal(cursor.CreatedAt))
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestListOrders_LastPage() {
+	ctx := context.Background()
+	cursor := &model.OrderCursor{CreatedAt: time.Now(), UUID: uuid.New()}
+
+	params := orderv1.ListOrdersParams{
+		Cursor: orderv1.NewOptString(converter.EncodeOrderCursor(cursor)),
+	}
+
+	orders := []*model.Order{
+		{UUID: uuid.New(), Status: model.StatusPaid, CreatedAt: cursor.CreatedAt.Add(-time.Hour)},
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(query *model.ListOrdersQuery) bool {
+		return query.Limit == converter.DefaultListLimit+1 &&
+			query.After != nil &&
+			query.After.UUID == cursor.UUID &&
+			query.After.CreatedAt.Equal(cursor.CreatedAt)
+	})).Return(orders, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.ListOrders(ctx, params)
+
+	s.NoError(err)
+
+	listResp, ok := result.(*orderv1.ListOrdersResponse)
+	s.True(ok)
+	s.Len(listResp.Orders, 1)
+	s.False(listResp.NextCursor.IsSet())
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestListOrders_InvalidCursor() {
+	ctx := context.Background()
+
+	params := orderv1.ListOrdersParams{
+		Cursor: orderv1.NewOptString("not a cursor"),
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.ListOrders(ctx, params)
+
+	s.NoError(err)
+
+	badReqErr, ok := result.(*orderv1.BadRequestError)
+	s.True(ok)
+	s.Equal("invalid_cursor", badReqErr.Error)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestListOrders_RepositoryError() {
+	ctx := context.Background()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("List", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.ListOrders(ctx, orderv1.ListOrdersParams{})
+
+	s.NoError(err)
+
+	internalErr, ok := result.(*orderv1.InternalServerError)
+	s.True(ok)
+	s.Equal("list_failed", internalErr.Error)
+
+	mockRepo.AssertExpectations(s.T())
+}
@@ -0,0 +1,68 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
+	"github.com/nimbodex/microservices-factory/order/internal/metrics"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func (s *OrderServiceTestSuite) TestMetrics_CountOrderLifecycle() {
+	ctx := auth.WithUser(context.Background(), &auth.User{UUID: uuid.New()})
+	repo := orderrepo.NewMemoryOrderRepository()
+	reg := prometheus.NewRegistry()
+	service := NewOrderService(repo, nil, nil, nil, nil, metrics.New(reg), 0)
+
+	_, err := service.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: []orderv1.CreateOrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+	}, orderv1.CreateOrderParams{})
+	s.Require().NoError(err)
+
+	pending := func(totalPrice float64) uuid.UUID {
+		order := &model.Order{
+			UUID:       uuid.New(),
+			TotalPrice: totalPrice,
+			Status:     model.StatusPendingPayment,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		s.Require().NoError(repo.Create(ctx, order))
+		return order.UUID
+	}
+
+	for _, price := range []float64{1500, 250.5} {
+		res, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD},
+			orderv1.PayOrderParams{OrderUUID: pending(price)})
+		s.Require().NoError(err)
+		s.Require().IsType(&orderv1.PayOrderResponse{}, res)
+	}
+
+	res, err := service.CancelOrder(ctx, orderv1.CancelOrderParams{OrderUUID: pending(100)})
+	s.Require().NoError(err)
+	s.Require().IsType(&orderv1.CancelOrderNoContent{}, res)
+
+	want := `
+# HELP orders_cancelled_total Total number of orders cancelled, by payment method and reason.
+# TYPE orders_cancelled_total counter
+orders_cancelled_total{payment_method="NONE",reason="USER_CANCELLED"} 1
+# HELP orders_created_total Total number of orders created.
+# TYPE orders_created_total counter
+orders_created_total 1
+# HELP orders_paid_total Total number of orders paid, by payment method.
+# TYPE orders_paid_total counter
+orders_paid_total{payment_method="CARD"} 2
+# HELP orders_revenue_total Total price of paid orders, by payment method.
+# TYPE orders_revenue_total counter
+orders_revenue_total{payment_method="CARD"} 1750.5
+`
+	s.NoError(testutil.GatherAndCompare(reg, strings.NewReader(want)))
+}
@@ -2,6 +2,9 @@ package order
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +15,7 @@ import (
 	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
 	"github.com/nimbodex/microservices-factory/order/internal/model"
 	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
@@ -30,12 +34,13 @@ func (s *OrderServiceTestSuite) TestPayOrder_Success() {
 	}
 
 	existingOrder := &model.Order{
-		UUID:      orderUUID,
-		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
-		Status:    model.StatusPendingPayment,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UUID:       orderUUID,
+		UserUUID:   userUUID,
+		Items:      []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		TotalPrice: 1500.0,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	updatedOrder := *existingOrder
@@ -45,18 +50,26 @@ func (s *OrderServiceTestSuite) TestPayOrder_Success() {
 	mockRepo := repomocks.NewOrderRepository(s.T())
 	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
 	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
-		return order.UUID == orderUUID && order.Status == model.StatusPaid
+		return order.UUID == orderUUID && order.Status == model.StatusPaymentInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.UUID == orderUUID &&
+			order.Status == model.StatusPaid &&
+			order.TransactionUUID != nil && *order.TransactionUUID == transactionUUID &&
+			order.PaymentMethod == model.PaymentMethodCard &&
+			order.PaidAt != nil
 	})).Return(nil)
 
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
-	mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard).Return(&client.PaymentResult{
+	mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard, 1500.0).Return(&client.PaymentResult{
 		TransactionUUID: transactionUUID,
 		Success:         true,
 	}, nil)
 
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("CommitReservation", mock.Anything, orderUUID).Return(nil)
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.PayOrder(ctx, req, params)
 
@@ -89,7 +102,7 @@ func (s *OrderServiceTestSuite) TestPayOrder_OrderNotFound() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.PayOrder(ctx, req, params)
 
@@ -120,7 +133,7 @@ func (s *OrderServiceTestSuite) TestPayOrder_InvalidStatus() {
 	existingOrder := &model.Order{
 		UUID:      orderUUID,
 		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
 		Status:    model.StatusPaid,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -132,7 +145,7 @@ func (s *OrderServiceTestSuite) TestPayOrder_InvalidStatus() {
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.PayOrder(ctx, req, params)
 
@@ -161,34 +174,283 @@ func (s *OrderServiceTestSuite) TestPayOrder_PaymentFailed() {
 	}
 
 	existingOrder := &model.Order{
-		UUID:      orderUUID,
-		UserUUID:  userUUID,
-		PartUUIDs: []uuid.UUID{uuid.New()},
-		Status:    model.StatusPendingPayment,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UUID:       orderUUID,
+		UserUUID:   userUUID,
+		Items:      []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		TotalPrice: 1500.0,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	mockRepo := repomocks.NewOrderRepository(s.T())
 	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPaymentInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPendingPayment
+	})).Return(nil).Once()
 
 	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
-	mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard).Return(nil, assert.AnError)
+	mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard, 1500.0).
+		Return(nil, &model.ServiceError{Code: model.ErrCodeConflict, Message: "card declined"})
 
+	// The reservation stays committed; cancelling the order gives the parts back
 	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("CommitReservation", mock.Anything, orderUUID).Return(nil).Once()
 
-	service := NewOrderService(mockRepo, mockInventoryClient, mockPaymentClient)
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
 
 	result, err := service.PayOrder(ctx, req, params)
 
 	s.NoError(err)
 	s.NotNil(result)
 
-	internalErr, ok := result.(*orderv1.InternalServerError)
+	conflictErr, ok := result.(*orderv1.ConflictError)
 	s.True(ok)
-	s.Equal("payment_failed", internalErr.Error)
-	s.Equal("payment processing failed", internalErr.Message)
+	s.Equal("payment_conflict", conflictErr.Error)
+	s.Equal("card declined", conflictErr.Message)
 
 	mockRepo.AssertExpectations(s.T())
 	mockPaymentClient.AssertExpectations(s.T())
 }
+
+func (s *OrderServiceTestSuite) TestPayOrder_PaymentUnavailableReleasesClaim() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	existingOrder := &model.Order{
+		UUID:       orderUUID,
+		UserUUID:   uuid.New(),
+		Items:      []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		TotalPrice: 1500.0,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPaymentInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPendingPayment
+	})).Return(nil).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard, 1500.0).
+		Return(nil, fmt.Errorf("failed to process payment for order %s: %w", orderUUID, &model.ServiceError{Code: model.ErrCodeUnavailable}))
+
+	service := NewOrderService(mockRepo, nil, nil, nil, mockPaymentClient, nil, 0)
+
+	result, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: orderUUID})
+
+	s.NoError(err)
+
+	unavailableErr, ok := result.(*orderv1.ServiceUnavailableError)
+	s.True(ok)
+	s.Equal("payment_unavailable", unavailableErr.Error)
+	s.Equal(model.StatusPendingPayment, existingOrder.Status)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestPayOrder_OutcomeUnknownKeepsClaim() {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "deadline exceeded", err: &model.ServiceError{Code: model.ErrCodeDeadlineExceeded, Message: "payment service did not answer in time"}},
+		{name: "upstream failed", err: &model.ServiceError{Code: model.ErrCodeExternalServiceErr, Message: "payment service failed"}},
+		{name: "unexpected error", err: assert.AnError},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			orderUUID := uuid.New()
+			existingOrder := &model.Order{
+				UUID:       orderUUID,
+				UserUUID:   uuid.New(),
+				Items:      []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+				TotalPrice: 1500.0,
+				Status:     model.StatusPendingPayment,
+			}
+
+			// Only the claim is stored; the order is not released for another payment
+			mockRepo := repomocks.NewOrderRepository(s.T())
+			mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+				return order.Status == model.StatusPaymentInProgress
+			})).Return(nil).Once()
+
+			mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+			mockPaymentClient.On("PayOrder", mock.Anything, orderUUID, client.PaymentMethodCard, 1500.0).
+				Return(nil, fmt.Errorf("failed to process payment for order %s: %w", orderUUID, tt.err))
+
+			service := NewOrderService(mockRepo, nil, nil, nil, mockPaymentClient, nil, 0)
+
+			result, err := service.PayOrder(context.Background(), &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: orderUUID})
+
+			s.NoError(err)
+			pending, ok := result.(*orderv1.PaymentPendingResponse)
+			s.Require().True(ok, "got %T", result)
+			s.Equal("payment_pending", pending.Error)
+			s.Equal(model.StatusPaymentInProgress, existingOrder.Status)
+			mockRepo.AssertNumberOfCalls(s.T(), "Update", 1)
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestPayOrder_ConcurrentModification() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	params := orderv1.PayOrderParams{
+		OrderUUID: orderUUID,
+	}
+
+	req := &orderv1.PayOrderRequest{
+		PaymentMethod: orderv1.PaymentMethodCARD,
+	}
+
+	existingOrder := &model.Order{
+		UUID:      orderUUID,
+		UserUUID:  uuid.New(),
+		Items:     []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		Status:    model.StatusPendingPayment,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(existingOrder, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(fmt.Errorf("wrapped: %w", model.ErrVersionConflict))
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.PayOrder(ctx, req, params)
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("concurrent_modification", conflictErr.Error)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestPayOrder_ConcurrentRequestsChargeOnce() {
+	ctx := context.Background()
+	repo := orderrepo.NewMemoryOrderRepository()
+
+	order := &model.Order{
+		UUID:       uuid.New(),
+		UserUUID:   uuid.New(),
+		Items:      []model.OrderItem{{PartUUID: uuid.New(), Quantity: 1}},
+		TotalPrice: 100.0,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	s.Require().NoError(repo.Create(ctx, order))
+
+	var charges atomic.Int32
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("PayOrder", mock.Anything, order.UUID, client.PaymentMethodCard, 100.0).
+		Run(func(mock.Arguments) { charges.Add(1) }).
+		Return(&client.PaymentResult{TransactionUUID: uuid.New(), Success: true}, nil).
+		Maybe()
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("CommitReservation", mock.Anything, order.UUID).Return(nil).Maybe()
+
+	service := NewOrderService(repo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	const requests = 10
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: order.UUID})
+			if err == nil {
+				if _, ok := result.(*orderv1.PayOrderResponse); ok {
+					succeeded.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int32(1), charges.Load())
+	s.Equal(int32(1), succeeded.Load())
+
+	stored, err := repo.GetByUUID(ctx, order.UUID)
+	s.NoError(err)
+	s.Equal(model.StatusPaid, stored.Status)
+}
+
+func (s *OrderServiceTestSuite) TestPayOrder_CommitReservationFailed() {
+	tests := []struct {
+		name      string
+		err       error
+		wantError string
+	}{
+		{
+			name:      "reservation expired",
+			err:       &model.ServiceError{Code: model.ErrCodeReservationNotFound, Message: "reservation not found"},
+			wantError: "reservation_expired",
+		},
+		{
+			name:      "inventory unavailable",
+			err:       &model.ServiceError{Code: model.ErrCodeUnavailable, Message: "inventory service is unavailable"},
+			wantError: "inventory_unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			existingOrder := pendingOrder(time.Now())
+			existingOrder.TotalPrice = 100.0
+
+			mockRepo := repomocks.NewOrderRepository(s.T())
+			mockRepo.On("GetByUUID", mock.Anything, existingOrder.UUID).Return(existingOrder, nil)
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+				return order.Status == model.StatusPaymentInProgress
+			})).Return(nil).Once()
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+				return order.Status == model.StatusPendingPayment
+			})).Return(nil).Once()
+
+			mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+			mockInventoryClient.On("CommitReservation", mock.Anything, existingOrder.UUID).
+				Return(fmt.Errorf("failed to commit reservation for order %s: %w", existingOrder.UUID, tt.err))
+
+			// The customer is not charged for parts that are not held
+			mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+
+			service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+			result, err := service.PayOrder(context.Background(), &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: existingOrder.UUID})
+
+			s.NoError(err)
+			switch resp := result.(type) {
+			case *orderv1.ConflictError:
+				s.Equal(tt.wantError, resp.Error)
+			case *orderv1.ServiceUnavailableError:
+				s.Equal(tt.wantError, resp.Error)
+			default:
+				s.Failf("unexpected response", "got %T", result)
+			}
+			s.Equal(model.StatusPendingPayment, existingOrder.Status)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	orderrepo "github.com/nimbodex/microservices-factory/order/internal/repository/order"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func claimedOrder(status model.OrderStatus, updatedAt time.Time) *model.Order {
+	order := pendingOrder(updatedAt.Add(-time.Minute))
+	order.Status = status
+	order.TotalPrice = 100.0
+	order.UpdatedAt = updatedAt
+	if status == model.StatusRefundInProgress {
+		transactionUUID := uuid.New()
+		order.TransactionUUID = &transactionUUID
+	}
+	return order
+}
+
+func (s *OrderServiceTestSuite) TestReconcileOrders_SettlesStuckOrders() {
+	ctx := context.Background()
+	cutoff := time.Now().Add(-5 * time.Minute)
+	stuckAt := cutoff.Add(-time.Minute)
+
+	charged := claimedOrder(model.StatusPaymentInProgress, stuckAt)
+	notCharged := claimedOrder(model.StatusPaymentInProgress, stuckAt)
+	unknown := claimedOrder(model.StatusPaymentInProgress, stuckAt)
+	inFlight := claimedOrder(model.StatusPaymentInProgress, time.Now())
+	refunded := claimedOrder(model.StatusRefundInProgress, stuckAt)
+	notRefunded := claimedOrder(model.StatusRefundInProgress, stuckAt)
+
+	repo := orderrepo.NewMemoryOrderRepository()
+	for _, order := range []*model.Order{charged, notCharged, unknown, inFlight, refunded, notRefunded} {
+		s.Require().NoError(repo.Create(ctx, order))
+	}
+
+	transactionUUID := uuid.New()
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("GetPayment", mock.Anything, charged.UUID).Return(&client.PaymentInfo{
+		TransactionUUID: transactionUUID,
+		PaymentMethod:   client.PaymentMethodSBP,
+		Amount:          100.0,
+	}, nil)
+	mockPaymentClient.On("GetPayment", mock.Anything, notCharged.UUID).Return(nil, &model.ServiceError{Code: model.ErrCodePaymentNotFound})
+	mockPaymentClient.On("GetPayment", mock.Anything, unknown.UUID).Return(nil, &model.ServiceError{Code: model.ErrCodeUnavailable})
+	mockPaymentClient.On("GetPayment", mock.Anything, refunded.UUID).Return(&client.PaymentInfo{
+		TransactionUUID: *refunded.TransactionUUID,
+		Amount:          100.0,
+		RefundedAmount:  100.0,
+	}, nil)
+	mockPaymentClient.On("GetPayment", mock.Anything, notRefunded.UUID).Return(&client.PaymentInfo{
+		TransactionUUID: *notRefunded.TransactionUUID,
+		Amount:          100.0,
+	}, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReturnParts", mock.Anything, refunded.UUID, toReservationItems(refunded.Items)).Return(nil).Once()
+
+	service := NewOrderService(repo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	settled, err := service.ReconcileOrders(ctx, cutoff, 2)
+
+	s.Require().NoError(err)
+	s.Equal(4, settled)
+
+	wantStatuses := map[*model.Order]model.OrderStatus{
+		charged:     model.StatusPaid,
+		notCharged:  model.StatusPendingPayment,
+		unknown:     model.StatusPaymentInProgress,
+		inFlight:    model.StatusPaymentInProgress,
+		refunded:    model.StatusRefunded,
+		notRefunded: model.StatusPaid,
+	}
+	for order, want := range wantStatuses {
+		stored, err := repo.GetByUUID(ctx, order.UUID)
+		s.Require().NoError(err)
+		s.Equal(want, stored.Status, "order %s", order.UUID)
+	}
+
+	stored, err := repo.GetByUUID(ctx, charged.UUID)
+	s.Require().NoError(err)
+	s.Equal(transactionUUID, *stored.TransactionUUID)
+	s.Equal(model.PaymentMethodSBP, stored.PaymentMethod)
+
+	stored, err = repo.GetByUUID(ctx, refunded.UUID)
+	s.Require().NoError(err)
+	s.Equal(100.0, stored.RefundedAmount)
+	mockPaymentClient.AssertNotCalled(s.T(), "GetPayment", mock.Anything, inFlight.UUID)
+}
+
+func (s *OrderServiceTestSuite) TestPayOrder_ReleasesClaimAfterCallerWentAway() {
+	ctx, cancel := context.WithCancel(context.Background())
+	order := pendingOrder(time.Now())
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPaymentInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), mock.MatchedBy(func(order *model.Order) bool {
+		return order.Status == model.StatusPendingPayment
+	})).Return(nil).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("PayOrder", mock.Anything, order.UUID, client.PaymentMethodCard, order.TotalPrice).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, &model.ServiceError{Code: model.ErrCodeUnavailable})
+
+	service := NewOrderService(mockRepo, nil, nil, nil, mockPaymentClient, nil, 0)
+
+	_, err := service.PayOrder(ctx, &orderv1.PayOrderRequest{PaymentMethod: orderv1.PaymentMethodCARD}, orderv1.PayOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+}
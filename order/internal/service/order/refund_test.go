@@ -0,0 +1,286 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/order/internal/client"
+	clientmocks "github.com/nimbodex/microservices-factory/order/internal/client/mocks"
+	"github.com/nimbodex/microservices-factory/order/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/order/internal/repository/mocks"
+	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
+)
+
+func paidOrder(totalPrice float64) *model.Order {
+	transactionUUID := uuid.New()
+	paidAt := time.Now()
+
+	return &model.Order{
+		UUID:            uuid.New(),
+		UserUUID:        uuid.New(),
+		Items:           []model.OrderItem{{PartUUID: uuid.New(), Quantity: 2, UnitPrice: totalPrice / 2, TotalPrice: totalPrice}},
+		TotalPrice:      totalPrice,
+		Status:          model.StatusPaid,
+		TransactionUUID: &transactionUUID,
+		PaymentMethod:   model.PaymentMethodCard,
+		PaidAt:          &paidAt,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_Full() {
+	ctx := context.Background()
+	order := paidOrder(200.0)
+	refundUUID := uuid.New()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefundInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefunded &&
+			updated.RefundedAmount == 200.0 &&
+			updated.RefundedAt != nil
+	})).Return(nil).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 200.0, "").
+		Return(&client.RefundResult{RefundUUID: refundUUID, RefundedAmount: 200.0}, nil)
+
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+	mockInventoryClient.On("ReturnParts", mock.Anything, order.UUID, []client.ReservationItem{
+		{PartUUID: order.Items[0].PartUUID, Quantity: 2},
+	}).Return(nil)
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	refundResp, ok := result.(*orderv1.RefundOrderResponse)
+	s.True(ok)
+	s.Equal(refundUUID, refundResp.RefundUUID)
+	s.Equal(200.0, refundResp.RefundedAmount)
+	s.Equal(orderv1.OrderStatusREFUNDED, refundResp.Status)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_Partial() {
+	ctx := context.Background()
+	order := paidOrder(200.0)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefundInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusPaid &&
+			updated.RefundedAmount == 50.0 &&
+			updated.RefundedAt == nil
+	})).Return(nil).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 50.0, "damaged").
+		Return(&client.RefundResult{RefundUUID: uuid.New(), RefundedAmount: 50.0, RemainingAmount: 150.0}, nil)
+
+	// Parts stay sold until the whole amount is refunded
+	mockInventoryClient := clientmocks.NewInventoryClient(s.T())
+
+	service := NewOrderService(mockRepo, nil, nil, mockInventoryClient, mockPaymentClient, nil, 0)
+
+	req := orderv1.NewOptRefundOrderRequest(orderv1.RefundOrderRequest{
+		Amount: orderv1.NewOptFloat64(50.0),
+		Reason: orderv1.NewOptString("damaged"),
+	})
+	result, err := service.RefundOrder(ctx, req, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	refundResp, ok := result.(*orderv1.RefundOrderResponse)
+	s.True(ok)
+	s.Equal(50.0, refundResp.RefundedAmount)
+	s.Equal(orderv1.OrderStatusPAID, refundResp.Status)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_AmountExceedsRemaining() {
+	ctx := context.Background()
+	order := paidOrder(200.0)
+	order.RefundedAmount = 150.0
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	req := orderv1.NewOptRefundOrderRequest(orderv1.RefundOrderRequest{Amount: orderv1.NewOptFloat64(60.0)})
+	result, err := service.RefundOrder(ctx, req, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	badReqErr, ok := result.(*orderv1.BadRequestError)
+	s.True(ok)
+	s.Equal("invalid_refund_amount", badReqErr.Error)
+
+	mockRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_NotPaid() {
+	ctx := context.Background()
+
+	for _, status := range []model.OrderStatus{model.StatusPendingPayment, model.StatusCancelled, model.StatusRefundInProgress, model.StatusRefunded} {
+		order := paidOrder(100.0)
+		order.Status = status
+
+		mockRepo := repomocks.NewOrderRepository(s.T())
+		mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+
+		service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+		result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+		s.NoError(err)
+
+		conflictErr, ok := result.(*orderv1.ConflictError)
+		s.True(ok, "status %s", status)
+		s.Equal("invalid_status", conflictErr.Error)
+	}
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_NotFound() {
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, orderUUID).Return(nil, assert.AnError)
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: orderUUID})
+
+	s.NoError(err)
+
+	_, ok := result.(*orderv1.NotFoundError)
+	s.True(ok)
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_ConcurrentModification() {
+	ctx := context.Background()
+	order := paidOrder(100.0)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(model.ErrVersionConflict)
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), clientmocks.NewPaymentClient(s.T()), nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("concurrent_modification", conflictErr.Error)
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_PaymentFailedReleasesClaim() {
+	ctx := context.Background()
+	order := paidOrder(100.0)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusRefundInProgress
+	})).Return(nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+		return updated.Status == model.StatusPaid && updated.RefundedAmount == 0
+	})).Return(nil).Once()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 100.0, "").
+		Return(nil, &model.ServiceError{Code: model.ErrCodeConflict, Message: "payment is already refunded"})
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), mockPaymentClient, nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	conflictErr, ok := result.(*orderv1.ConflictError)
+	s.True(ok)
+	s.Equal("payment_conflict", conflictErr.Error)
+
+	mockRepo.AssertExpectations(s.T())
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_OutcomeUnknownKeepsClaim() {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "deadline exceeded", err: &model.ServiceError{Code: model.ErrCodeDeadlineExceeded, Message: "payment service did not answer in time"}},
+		{name: "unavailable", err: &model.ServiceError{Code: model.ErrCodeUnavailable, Message: "payment service is unavailable"}},
+		{name: "unexpected error", err: assert.AnError},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			order := paidOrder(100.0)
+
+			// Only the claim is stored; the order is not released for another refund
+			mockRepo := repomocks.NewOrderRepository(s.T())
+			mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Order) bool {
+				return updated.Status == model.StatusRefundInProgress
+			})).Return(nil).Once()
+
+			mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+			mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 100.0, "").Return(nil, tt.err)
+
+			service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), mockPaymentClient, nil, 0)
+
+			result, err := service.RefundOrder(context.Background(), orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+			s.NoError(err)
+			pending, ok := result.(*orderv1.RefundPendingResponse)
+			s.Require().True(ok, "got %T", result)
+			s.Equal("refund_pending", pending.Error)
+			s.Equal(model.StatusRefundInProgress, order.Status)
+			s.Zero(order.RefundedAmount)
+		})
+	}
+}
+
+func (s *OrderServiceTestSuite) TestRefundOrder_PaymentNotFound() {
+	ctx := context.Background()
+	order := paidOrder(100.0)
+
+	mockRepo := repomocks.NewOrderRepository(s.T())
+	mockRepo.On("GetByUUID", mock.Anything, order.UUID).Return(order, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	mockPaymentClient := clientmocks.NewPaymentClient(s.T())
+	mockPaymentClient.On("RefundPayment", mock.Anything, *order.TransactionUUID, 100.0, "").
+		Return(nil, &model.ServiceError{Code: model.ErrCodePaymentNotFound, Message: "payment not found"})
+
+	service := NewOrderService(mockRepo, nil, nil, clientmocks.NewInventoryClient(s.T()), mockPaymentClient, nil, 0)
+
+	result, err := service.RefundOrder(ctx, orderv1.OptRefundOrderRequest{}, orderv1.RefundOrderParams{OrderUUID: order.UUID})
+
+	s.NoError(err)
+
+	notFoundErr, ok := result.(*orderv1.NotFoundError)
+	s.True(ok)
+	s.Equal("payment_not_found", notFoundErr.Error)
+	s.Equal(model.StatusPaid, order.Status)
+}
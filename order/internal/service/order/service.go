@@ -2,108 +2,273 @@ package order
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/go-faster/jx"
 	"github.com/google/uuid"
 
+	"github.com/nimbodex/microservices-factory/order/internal/auth"
 	"github.com/nimbodex/microservices-factory/order/internal/client"
 	"github.com/nimbodex/microservices-factory/order/internal/converter"
+	"github.com/nimbodex/microservices-factory/order/internal/metrics"
 	"github.com/nimbodex/microservices-factory/order/internal/model"
 	"github.com/nimbodex/microservices-factory/order/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
-// OrderServiceImpl implements OrderService interface
+// OrderServiceImpl implements OrderService interface. Order changes other
+// services care about are recorded as domain events in the outbox within the
+// same transaction as the change itself.
 type OrderServiceImpl struct {
 	orderRepo       repository.OrderRepository
+	outboxRepo      repository.OutboxRepository
+	txManager       repository.TxManager
 	inventoryClient client.InventoryClient
 	paymentClient   client.PaymentClient
+	metrics         *metrics.Metrics
+	// reservationTTL is how long inventory holds stock of a new order
+	reservationTTL time.Duration
 }
 
-// NewOrderService creates a new order service instance
+// NewOrderService creates a new order service instance. Without an outbox no
+// events are recorded; without a transaction manager the order change and its
+// event are stored one after another; without metrics nothing is counted.
+// Stock of new orders is reserved for reservationTTL, which has to outlast
+// the time an order may stay unpaid; zero keeps the inventory default.
 func NewOrderService(
 	orderRepo repository.OrderRepository,
+	outboxRepo repository.OutboxRepository,
+	txManager repository.TxManager,
 	inventoryClient client.InventoryClient,
 	paymentClient client.PaymentClient,
+	metrics *metrics.Metrics,
+	reservationTTL time.Duration,
 ) *OrderServiceImpl {
 	return &OrderServiceImpl{
 		orderRepo:       orderRepo,
+		outboxRepo:      outboxRepo,
+		txManager:       txManager,
 		inventoryClient: inventoryClient,
 		paymentClient:   paymentClient,
+		metrics:         metrics,
+		reservationTTL:  reservationTTL,
 	}
 }
 
-// CreateOrder creates a new order with the specified parts for a user
-func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (orderv1.CreateOrderRes, error) {
-	log.Printf("Creating order for user %s with parts %v", req.UserUUID, req.PartUuids)
+// CreateOrder creates a new order with the requested quantities of parts for a user
+func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, params orderv1.CreateOrderParams) (orderv1.CreateOrderRes, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return &orderv1.UnauthorizedError{
+			Error:   "missing_session",
+			Message: "order can only be created by an authenticated user",
+		}, nil
+	}
+
+	orderUUID := uuid.New()
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", orderUUID.String()))
+	log.Info("Creating order", slog.String("user_uuid", user.UUID.String()), slog.Int("items", len(req.Items)))
+
+	createReq := converter.ToCreateOrderRequest(req, user.UUID)
+
+	seen := make(map[uuid.UUID]struct{}, len(createReq.Items))
+	for _, item := range createReq.Items {
+		if _, ok := seen[item.PartUUID]; ok {
+			return &orderv1.BadRequestError{
+				Error:   "duplicate_part",
+				Message: fmt.Sprintf("part %s is listed more than once", item.PartUUID),
+			}, nil
+		}
+		seen[item.PartUUID] = struct{}{}
+	}
+
+	items := make([]model.OrderItem, len(createReq.Items))
+	totalPrice := 0.0
 
-	createReq := converter.ToCreateOrderRequest(req)
+	for i, item := range createReq.Items {
+		items[i] = model.OrderItem{
+			PartUUID: item.PartUUID,
+			Quantity: item.Quantity,
+		}
+	}
 
 	if s.inventoryClient != nil {
-		for _, partUUID := range createReq.PartUUIDs {
-			_, err := s.inventoryClient.GetPart(ctx, partUUID)
-			if err != nil {
-				log.Printf("Part %s not found in inventory: %v", partUUID, err)
-				return &orderv1.BadRequestError{
-					Error:   "part_not_found",
-					Message: fmt.Sprintf("part %s not found", partUUID),
-				}, nil
+		partUUIDs := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			partUUIDs[i] = item.PartUUID
+		}
+
+		lookup, err := s.inventoryClient.GetParts(ctx, partUUIDs)
+		if err != nil {
+			log.Error("Failed to get parts", logger.Err(err))
+			return upstreamError("inventory", err), nil
+		}
+
+		if len(lookup.MissingPartUUIDs) > 0 {
+			log.Warn("Parts not found in inventory", slog.Any("part_uuids", lookup.MissingPartUUIDs))
+			return &orderv1.BadRequestError{
+				Error:   "part_not_found",
+				Message: fmt.Sprintf("parts not found: %s", joinUUIDs(lookup.MissingPartUUIDs)),
+			}, nil
+		}
+
+		var unavailable []uuid.UUID
+		for i := range items {
+			item := &items[i]
+			part := lookup.Parts[item.PartUUID]
+
+			if part.StockQuantity < int64(item.Quantity) {
+				unavailable = append(unavailable, item.PartUUID)
+				continue
 			}
+
+			// Snapshot the unit price so later catalogue changes don't affect this order
+			item.UnitPrice = part.Price
+			item.TotalPrice = part.Price * float64(item.Quantity)
+			totalPrice += item.TotalPrice
+		}
+
+		// Reject early when the catalogue already shows a shortage; the reservation re-checks atomically
+		if len(unavailable) > 0 {
+			log.Info("Not enough stock", slog.Any("unavailable_part_uuids", unavailable))
+			return &orderv1.ConflictError{
+				Error:                "insufficient_stock",
+				Message:              "not enough stock for some parts",
+				UnavailablePartUuids: unavailable,
+			}, nil
+		}
+
+		reservation, err := s.inventoryClient.ReserveParts(ctx, orderUUID, toReservationItems(items), s.reservationTTL)
+		if err != nil {
+			log.Error("Failed to reserve parts", logger.Err(err))
+			return upstreamError("inventory", err), nil
+		}
+
+		if !reservation.Reserved {
+			log.Info("Not enough stock", slog.Any("unavailable_part_uuids", reservation.UnavailablePartUUIDs))
+			return &orderv1.ConflictError{
+				Error:                "insufficient_stock",
+				Message:              "not enough stock for some parts",
+				UnavailablePartUuids: reservation.UnavailablePartUUIDs,
+			}, nil
 		}
 	}
 
-	orderUUID := uuid.New()
 	order := &model.Order{
-		UUID:      orderUUID,
-		UserUUID:  createReq.UserUUID,
-		PartUUIDs: createReq.PartUUIDs,
-		Status:    model.StatusPendingPayment,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		UUID:       orderUUID,
+		UserUUID:   createReq.UserUUID,
+		Items:      items,
+		TotalPrice: totalPrice,
+		Status:     model.StatusPendingPayment,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
-	if err := s.orderRepo.Create(ctx, order); err != nil {
-		log.Printf("Failed to create order: %v", err)
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, order, converter.ToOrderCreatedEvent)
+	})
+	if err != nil {
+		log.Error("Failed to create order", logger.Err(err))
+		s.releaseReservation(ctx, order)
 		return &orderv1.InternalServerError{
 			Error:   "creation_failed",
 			Message: "failed to create order",
 		}, nil
 	}
 
-	log.Printf("Order %s created successfully", orderUUID)
+	s.metrics.OrderCreated()
+	log.Info("Order created", slog.Float64("total_price", totalPrice))
 
-	totalPrice := 0.0
-	return converter.ToCreateOrderResponse(order, totalPrice), nil
+	return converter.ToCreateOrderResponse(order), nil
 }
 
 // GetOrder retrieves an order by its UUID
 func (s *OrderServiceImpl) GetOrder(ctx context.Context, params orderv1.GetOrderParams) (orderv1.GetOrderRes, error) {
-	log.Printf("Getting order %s", params.OrderUUID)
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", params.OrderUUID.String()))
+	log.Debug("Getting order")
 
 	order, err := s.orderRepo.GetByUUID(ctx, params.OrderUUID)
 	if err != nil {
-		log.Printf("Order %s not found: %v", params.OrderUUID, err)
+		log.Warn("Order not found", logger.Err(err))
 		return &orderv1.NotFoundError{
 			Error:   "order_not_found",
 			Message: "order not found",
 		}, nil
 	}
 
-	log.Printf("Order %s found with status %s", params.OrderUUID, order.Status)
+	log.Debug("Order found", slog.String("status", string(order.Status)))
 
-	totalPrice := 0.0
-	return converter.ToGetOrderResponse(order, totalPrice), nil
+	return converter.ToGetOrderResponse(order), nil
 }
 
-// PayOrder processes payment for an order using the specified payment method
+// ListOrders returns a page of orders matching the filter, newest first
+func (s *OrderServiceImpl) ListOrders(ctx context.Context, params orderv1.ListOrdersParams) (orderv1.ListOrdersRes, error) {
+	log := logger.FromContext(ctx)
+	log.Debug("Listing orders", slog.Any("params", params))
+
+	var after *model.OrderCursor
+	if cursor, ok := params.Cursor.Get(); ok {
+		decoded, err := converter.DecodeOrderCursor(cursor)
+		if err != nil {
+			log.Warn("Invalid cursor", slog.String("cursor", cursor), logger.Err(err))
+			return &orderv1.BadRequestError{
+				Error:   "invalid_cursor",
+				Message: "cursor is malformed",
+			}, nil
+		}
+		after = decoded
+	}
+
+	query := converter.ToListOrdersQuery(params, after)
+	pageSize := query.Limit
+
+	// Fetch one extra order to find out whether there is a next page
+	query.Limit = pageSize + 1
+
+	orders, err := s.orderRepo.List(ctx, query)
+	if err != nil {
+		log.Error("Failed to list orders", logger.Err(err))
+		return &orderv1.InternalServerError{
+			Error:   "list_failed",
+			Message: "failed to list orders",
+		}, nil
+	}
+
+	var next *model.OrderCursor
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		last := orders[pageSize-1]
+		next = &model.OrderCursor{
+			CreatedAt: last.CreatedAt,
+			UUID:      last.UUID,
+		}
+	}
+
+	log.Debug("Orders listed", slog.Int("count", len(orders)), slog.Bool("has_next", next != nil))
+
+	return converter.ToListOrdersResponse(orders, next), nil
+}
+
+// PayOrder processes payment for an order using the specified payment method.
+// The order is first moved to StatusPaymentInProgress with a compare-and-swap
+// update, so concurrent pay or cancel requests cannot charge the customer twice.
 func (s *OrderServiceImpl) PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, params orderv1.PayOrderParams) (orderv1.PayOrderRes, error) {
-	log.Printf("Processing payment for order %s with method %s", params.OrderUUID, req.PaymentMethod)
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", params.OrderUUID.String()))
+	log.Info("Processing payment", slog.String("payment_method", string(req.PaymentMethod)))
 
 	order, err := s.orderRepo.GetByUUID(ctx, params.OrderUUID)
 	if err != nil {
-		log.Printf("Order %s not found: %v", params.OrderUUID, err)
+		log.Warn("Order not found", logger.Err(err))
 		return &orderv1.NotFoundError{
 			Error:   "order_not_found",
 			Message: "order not found",
@@ -111,25 +276,67 @@ func (s *OrderServiceImpl) PayOrder(ctx context.Context, req *orderv1.PayOrderRe
 	}
 
 	if order.Status != model.StatusPendingPayment {
-		log.Printf("Order %s cannot be paid, current status: %s", params.OrderUUID, order.Status)
+		log.Warn("Order cannot be paid", slog.String("status", string(order.Status)))
 		return &orderv1.ConflictError{
 			Error:   "invalid_status",
 			Message: "order cannot be paid",
 		}, nil
 	}
 
+	order.Status = model.StatusPaymentInProgress
+	order.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		if errors.Is(err, model.ErrVersionConflict) {
+			log.Warn("Order was modified concurrently", logger.Err(err))
+			return &orderv1.ConflictError{
+				Error:   "concurrent_modification",
+				Message: "order cannot be paid",
+			}, nil
+		}
+		log.Error("Failed to update order", logger.Err(err))
+		return &orderv1.InternalServerError{
+			Error:   "update_failed",
+			Message: "failed to update order status",
+		}, nil
+	}
+
+	if s.inventoryClient != nil {
+		// Stock is made sold before the customer is charged, so an order is
+		// never paid for parts inventory no longer holds for it
+		if err := s.inventoryClient.CommitReservation(ctx, order.UUID); err != nil {
+			s.releasePaymentClaim(ctx, order)
+			if hasCode(err, model.ErrCodeReservationNotFound) {
+				log.Warn("Reservation of order expired", logger.Err(err))
+				return &orderv1.ConflictError{
+					Error:   "reservation_expired",
+					Message: "parts are no longer reserved for the order",
+				}, nil
+			}
+			log.Error("Failed to commit reservation", logger.Err(err))
+			return upstreamError("inventory", err), nil
+		}
+	}
+
 	var transactionUUID uuid.UUID
 
-	if s.paymentClient != nil {
-		payReq := converter.ToPayOrderRequest(req)
+	payReq := converter.ToPayOrderRequest(req)
 
-		paymentResult, err := s.paymentClient.PayOrder(ctx, params.OrderUUID, client.PaymentMethod(payReq.PaymentMethod))
+	if s.paymentClient != nil {
+		paymentResult, err := s.paymentClient.PayOrder(ctx, params.OrderUUID, client.PaymentMethod(payReq.PaymentMethod), order.TotalPrice)
 		if err != nil {
-			log.Printf("Payment failed for order %s: %v", params.OrderUUID, err)
-			return &orderv1.InternalServerError{
-				Error:   "payment_failed",
-				Message: "payment processing failed",
-			}, nil
+			if !chargeNotMade(err) {
+				// The charge may have gone through, so paying again is not
+				// offered; the order is left for reconciliation with payment
+				log.Error("Payment outcome unknown", logger.Err(err))
+				return &orderv1.PaymentPendingResponse{
+					Error:   "payment_pending",
+					Message: "payment outcome is not known yet; check the order status later",
+				}, nil
+			}
+			log.Error("Payment failed", logger.Err(err))
+			s.releasePaymentClaim(ctx, order)
+			return upstreamError("payment", err), nil
 		}
 
 		transactionUUID = paymentResult.TransactionUUID
@@ -137,29 +344,27 @@ func (s *OrderServiceImpl) PayOrder(ctx context.Context, req *orderv1.PayOrderRe
 		transactionUUID = uuid.New()
 	}
 
-	order.Status = model.StatusPaid
-	order.UpdatedAt = time.Now()
-
-	if err := s.orderRepo.Update(ctx, order); err != nil {
-		log.Printf("Failed to update order %s: %v", params.OrderUUID, err)
+	if err := s.completePayment(ctx, order, transactionUUID, payReq.PaymentMethod); err != nil {
+		log.Error("Failed to update order after payment", slog.String("transaction_uuid", transactionUUID.String()), logger.Err(err))
 		return &orderv1.InternalServerError{
 			Error:   "update_failed",
 			Message: "failed to update order status",
 		}, nil
 	}
 
-	log.Printf("Payment successful for order %s, transaction: %s", params.OrderUUID, transactionUUID)
+	log.Info("Order paid", slog.String("transaction_uuid", transactionUUID.String()))
 
 	return converter.ToPayOrderResponse(transactionUUID), nil
 }
 
 // CancelOrder cancels an order if it is in pending payment status
 func (s *OrderServiceImpl) CancelOrder(ctx context.Context, params orderv1.CancelOrderParams) (orderv1.CancelOrderRes, error) {
-	log.Printf("Cancelling order %s", params.OrderUUID)
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", params.OrderUUID.String()))
+	log.Info("Cancelling order")
 
 	order, err := s.orderRepo.GetByUUID(ctx, params.OrderUUID)
 	if err != nil {
-		log.Printf("Order %s not found: %v", params.OrderUUID, err)
+		log.Warn("Order not found", logger.Err(err))
 		return &orderv1.NotFoundError{
 			Error:   "order_not_found",
 			Message: "order not found",
@@ -167,32 +372,635 @@ func (s *OrderServiceImpl) CancelOrder(ctx context.Context, params orderv1.Cance
 	}
 
 	if order.Status != model.StatusPendingPayment {
-		log.Printf("Order %s cannot be cancelled, current status: %s", params.OrderUUID, order.Status)
+		log.Warn("Order cannot be cancelled", slog.String("status", string(order.Status)))
 		return &orderv1.ConflictError{
 			Error:   "invalid_status",
 			Message: "order cannot be cancelled",
 		}, nil
 	}
 
+	if err := s.cancelOrder(ctx, order, model.CancellationReasonUserCancelled); err != nil {
+		if errors.Is(err, model.ErrVersionConflict) {
+			log.Warn("Order was modified concurrently", logger.Err(err))
+			return &orderv1.ConflictError{
+				Error:   "concurrent_modification",
+				Message: "order cannot be cancelled",
+			}, nil
+		}
+		log.Error("Failed to update order", logger.Err(err))
+		return &orderv1.InternalServerError{
+			Error:   "update_failed",
+			Message: "failed to update order status",
+		}, nil
+	}
+
+	log.Info("Order cancelled")
+
+	return &orderv1.CancelOrderNoContent{}, nil
+}
+
+// ExpireOrders cancels orders still waiting for payment that were created
+// before createdBefore, walking them in pages of pageSize. Orders paid or
+// cancelled concurrently are skipped. It returns the number of expired orders.
+func (s *OrderServiceImpl) ExpireOrders(ctx context.Context, createdBefore time.Time, pageSize int) (int, error) {
+	status := model.StatusPendingPayment
+	query := &model.ListOrdersQuery{
+		Filter: model.OrderFilter{Status: &status, CreatedTo: &createdBefore},
+		Limit:  pageSize,
+	}
+
+	log := logger.FromContext(ctx)
+	expired := 0
+	for {
+		orders, err := s.orderRepo.List(ctx, query)
+		if err != nil {
+			return expired, fmt.Errorf("list unpaid orders: %w", err)
+		}
+
+		for _, order := range orders {
+			if err := ctx.Err(); err != nil {
+				return expired, err
+			}
+
+			if err := s.cancelOrder(ctx, order, model.CancellationReasonExpired); err != nil {
+				if errors.Is(err, model.ErrVersionConflict) {
+					log.Info("Order changed while expiring, skipping", slog.String("order_uuid", order.UUID.String()), logger.Err(err))
+					continue
+				}
+				return expired, fmt.Errorf("expire order %s: %w", order.UUID, err)
+			}
+
+			log.Info("Order expired unpaid", slog.String("order_uuid", order.UUID.String()))
+			expired++
+		}
+
+		if len(orders) < pageSize {
+			return expired, nil
+		}
+
+		last := orders[len(orders)-1]
+		query.After = &model.OrderCursor{CreatedAt: last.CreatedAt, UUID: last.UUID}
+	}
+}
+
+// cancelOrder moves an order to StatusCancelled with the given reason and
+// releases stock held for it
+func (s *OrderServiceImpl) cancelOrder(ctx context.Context, order *model.Order, reason model.CancellationReason) error {
+	now := time.Now()
 	order.Status = model.StatusCancelled
+	order.CancellationReason = reason
+	order.CancelledAt = &now
+	order.UpdatedAt = now
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, order, converter.ToOrderCancelledEvent)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.metrics.OrderCancelled(order)
+	s.releaseReservation(ctx, order)
+
+	return nil
+}
+
+// RefundOrder returns money of a paid order. A partial refund keeps the order
+// PAID; once the whole amount is refunded the order becomes REFUNDED and its
+// parts go back to inventory. Like PayOrder, the order is claimed with a
+// compare-and-swap update first so concurrent refunds cannot overlap.
+func (s *OrderServiceImpl) RefundOrder(ctx context.Context, req orderv1.OptRefundOrderRequest, params orderv1.RefundOrderParams) (orderv1.RefundOrderRes, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", params.OrderUUID.String()))
+	log.Info("Processing refund")
+
+	order, err := s.orderRepo.GetByUUID(ctx, params.OrderUUID)
+	if err != nil {
+		log.Warn("Order not found", logger.Err(err))
+		return &orderv1.NotFoundError{
+			Error:   "order_not_found",
+			Message: "order not found",
+		}, nil
+	}
+
+	if order.Status != model.StatusPaid || order.TransactionUUID == nil {
+		log.Warn("Order cannot be refunded", slog.String("status", string(order.Status)))
+		return &orderv1.ConflictError{
+			Error:   "invalid_status",
+			Message: "order cannot be refunded",
+		}, nil
+	}
+
+	refundReq := converter.ToRefundOrderRequest(req)
+
+	remaining := roundAmount(order.TotalPrice - order.RefundedAmount)
+	amount := roundAmount(refundReq.Amount)
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		log.Warn("Refund exceeds remaining amount", slog.Float64("amount", amount), slog.Float64("remaining", remaining))
+		return &orderv1.BadRequestError{
+			Error:   "invalid_refund_amount",
+			Message: fmt.Sprintf("refund amount must be positive and at most %.2f", remaining),
+		}, nil
+	}
+
+	order.Status = model.StatusRefundInProgress
 	order.UpdatedAt = time.Now()
 
 	if err := s.orderRepo.Update(ctx, order); err != nil {
-		log.Printf("Failed to update order %s: %v", params.OrderUUID, err)
+		if errors.Is(err, model.ErrVersionConflict) {
+			log.Warn("Order was modified concurrently", logger.Err(err))
+			return &orderv1.ConflictError{
+				Error:   "concurrent_modification",
+				Message: "order cannot be refunded",
+			}, nil
+		}
+		log.Error("Failed to update order", logger.Err(err))
 		return &orderv1.InternalServerError{
 			Error:   "update_failed",
 			Message: "failed to update order status",
 		}, nil
 	}
 
-	log.Printf("Order %s cancelled successfully", params.OrderUUID)
+	refund := &client.RefundResult{
+		RefundUUID:      uuid.New(),
+		RefundedAmount:  roundAmount(order.RefundedAmount + amount),
+		RemainingAmount: roundAmount(remaining - amount),
+	}
 
-	return &orderv1.CancelOrderNoContent{}, nil
+	if s.paymentClient != nil {
+		refund, err = s.paymentClient.RefundPayment(ctx, *order.TransactionUUID, amount, refundReq.Reason)
+		if err != nil {
+			if !refundNotMade(err) {
+				// Money may have been returned, so refunding again is not
+				// offered; the order is left for reconciliation with payment
+				log.Error("Refund outcome unknown", logger.Err(err))
+				return &orderv1.RefundPendingResponse{
+					Error:   "refund_pending",
+					Message: "refund outcome is not known yet; check the order status later",
+				}, nil
+			}
+			log.Error("Refund failed", logger.Err(err))
+			s.releaseRefundClaim(ctx, order)
+			if hasCode(err, model.ErrCodePaymentNotFound) {
+				return &orderv1.NotFoundError{
+					Error:   "payment_not_found",
+					Message: "payment of the order not found",
+				}, nil
+			}
+			return upstreamError("payment", err), nil
+		}
+	}
+
+	if err := s.completeRefund(ctx, order, refund.RefundedAmount, refund.RemainingAmount); err != nil {
+		log.Error("Failed to update order after refund", slog.String("refund_uuid", refund.RefundUUID.String()), logger.Err(err))
+		return &orderv1.InternalServerError{
+			Error:   "update_failed",
+			Message: "failed to update order status",
+		}, nil
+	}
+
+	log.Info("Order refunded",
+		slog.String("refund_uuid", refund.RefundUUID.String()),
+		slog.Float64("amount", amount),
+		slog.String("status", string(order.Status)),
+	)
+
+	return converter.ToRefundOrderResponse(order, refund.RefundUUID), nil
+}
+
+// completePayment stores the charge made for an order claimed for payment,
+// whose stock was committed before the charge; the order becomes StatusPaid.
+// The customer has been charged, so the writes outlive a cancelled ctx.
+func (s *OrderServiceImpl) completePayment(ctx context.Context, order *model.Order, transactionUUID uuid.UUID, paymentMethod model.PaymentMethod) error {
+	ctx = context.WithoutCancel(ctx)
+
+	paidAt := time.Now()
+	order.Status = model.StatusPaid
+	order.TransactionUUID = &transactionUUID
+	order.PaymentMethod = paymentMethod
+	order.PaidAt = &paidAt
+	order.UpdatedAt = paidAt
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, order, converter.ToOrderPaidEvent)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.metrics.OrderPaid(order)
+
+	return nil
+}
+
+// completeRefund stores the total refunded from the payment of an order
+// claimed for refund together with its OrderRefunded event. Once nothing
+// remains the order becomes StatusRefunded and, after that is stored, its
+// parts go back to inventory; otherwise it returns to StatusPaid. Money has
+// been returned, so the writes outlive a cancelled ctx.
+func (s *OrderServiceImpl) completeRefund(ctx context.Context, order *model.Order, refundedAmount, remainingAmount float64) error {
+	ctx = context.WithoutCancel(ctx)
+
+	now := time.Now()
+	order.RefundedAmount = refundedAmount
+	order.Status = model.StatusPaid
+	if remainingAmount <= 0 {
+		order.Status = model.StatusRefunded
+		order.RefundedAt = &now
+	}
+	order.UpdatedAt = now
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return s.recordEvent(ctx, order, converter.ToOrderRefundedEvent)
+	})
+	if err != nil {
+		return err
+	}
+
+	if order.Status == model.StatusRefunded && s.inventoryClient != nil {
+		// A failed restock must not undo the refund
+		if err := s.inventoryClient.ReturnParts(ctx, order.UUID, toReservationItems(order.Items)); err != nil {
+			logger.FromContext(ctx).Error("Failed to return parts of refunded order", slog.String("order_uuid", order.UUID.String()), logger.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// ReconcileOrders settles orders claimed for payment or refund before
+// updatedBefore and still not settled, as left behind by calls whose outcome
+// was lost, by asking payment what became of them. Orders payment cannot tell
+// about now are left for the next run. It returns the number of settled orders.
+func (s *OrderServiceImpl) ReconcileOrders(ctx context.Context, updatedBefore time.Time, pageSize int) (int, error) {
+	if s.paymentClient == nil {
+		return 0, nil
+	}
+
+	settled := 0
+	for _, status := range []model.OrderStatus{model.StatusPaymentInProgress, model.StatusRefundInProgress} {
+		query := &model.ListOrdersQuery{
+			Filter: model.OrderFilter{Status: &status},
+			Limit:  pageSize,
+		}
+
+		for {
+			orders, err := s.orderRepo.List(ctx, query)
+			if err != nil {
+				return settled, fmt.Errorf("list %s orders: %w", status, err)
+			}
+
+			for _, order := range orders {
+				if err := ctx.Err(); err != nil {
+					return settled, err
+				}
+				if order.UpdatedAt.After(updatedBefore) {
+					continue
+				}
+
+				ok, err := s.reconcileOrder(ctx, order)
+				if err != nil {
+					return settled, fmt.Errorf("reconcile order %s: %w", order.UUID, err)
+				}
+				if ok {
+					settled++
+				}
+			}
+
+			if len(orders) < pageSize {
+				break
+			}
+
+			last := orders[len(orders)-1]
+			query.After = &model.OrderCursor{CreatedAt: last.CreatedAt, UUID: last.UUID}
+		}
+	}
+
+	return settled, nil
+}
+
+// reconcileOrder settles one order claimed for payment or refund according
+// to its payment. It reports whether the order was settled; orders changed
+// concurrently or not known to payment for now are skipped.
+func (s *OrderServiceImpl) reconcileOrder(ctx context.Context, order *model.Order) (bool, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", order.UUID.String()), slog.String("status", string(order.Status)))
+
+	payment, err := s.paymentClient.GetPayment(ctx, order.UUID)
+	if err != nil && !hasCode(err, model.ErrCodePaymentNotFound) {
+		log.Warn("Payment of stuck order is not known yet, skipping", logger.Err(err))
+		return false, nil
+	}
+
+	switch {
+	case order.Status == model.StatusPaymentInProgress && payment != nil:
+		err = s.completePayment(ctx, order, payment.TransactionUUID, model.PaymentMethod(payment.PaymentMethod))
+	case order.Status == model.StatusPaymentInProgress:
+		// Nothing was charged, so the customer may pay again
+		order.Status = model.StatusPendingPayment
+		order.UpdatedAt = time.Now()
+		err = s.orderRepo.Update(ctx, order)
+	case payment != nil && payment.RefundedAmount > order.RefundedAmount:
+		err = s.completeRefund(ctx, order, payment.RefundedAmount, roundAmount(payment.Amount-payment.RefundedAmount))
+	default:
+		// Nothing more was refunded, so the order is as paid as before
+		order.Status = model.StatusPaid
+		order.UpdatedAt = time.Now()
+		err = s.orderRepo.Update(ctx, order)
+	}
+	if errors.Is(err, model.ErrVersionConflict) {
+		log.Info("Order changed while reconciling, skipping", logger.Err(err))
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("Stuck order reconciled with payment", slog.String("settled_status", string(order.Status)))
+
+	return true, nil
+}
+
+// StartAssembly moves a paid order to StatusAssembling. Assembly events are
+// delivered at least once, so an order already assembling or completed is
+// left as is. Errors other than *model.ServiceError are worth retrying; an
+// order claimed for payment or refund gives model.ErrOrderSettling, as it may
+// still settle as paid.
+func (s *OrderServiceImpl) StartAssembly(ctx context.Context, orderUUID uuid.UUID) error {
+	order, err := s.getOrder(ctx, orderUUID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status {
+	case model.StatusAssembling, model.StatusCompleted:
+		return nil
+	case model.StatusPaid:
+	case model.StatusPaymentInProgress, model.StatusRefundInProgress:
+		return fmt.Errorf("start assembly of order %s: %w", orderUUID, model.ErrOrderSettling)
+	default:
+		return model.NewInvalidStatusError(order.Status, model.StatusPaid)
+	}
+
+	order.Status = model.StatusAssembling
+	order.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		return fmt.Errorf("update order %s: %w", orderUUID, err)
+	}
+
+	logger.FromContext(ctx).Info("Order is being assembled", slog.String("order_uuid", orderUUID.String()))
+
+	return nil
+}
+
+// CompleteAssembly moves an assembling order to StatusCompleted. A paid order
+// is completed directly in case the assembly start was not seen; an order
+// already completed is left as is.
+func (s *OrderServiceImpl) CompleteAssembly(ctx context.Context, orderUUID uuid.UUID) error {
+	order, err := s.getOrder(ctx, orderUUID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status {
+	case model.StatusCompleted:
+		return nil
+	case model.StatusPaid, model.StatusAssembling:
+	case model.StatusPaymentInProgress, model.StatusRefundInProgress:
+		return fmt.Errorf("complete assembly of order %s: %w", orderUUID, model.ErrOrderSettling)
+	default:
+		return model.NewInvalidStatusError(order.Status, model.StatusAssembling)
+	}
+
+	order.Status = model.StatusCompleted
+	order.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		return fmt.Errorf("update order %s: %w", orderUUID, err)
+	}
+
+	logger.FromContext(ctx).Info("Order completed", slog.String("order_uuid", orderUUID.String()))
+
+	return nil
+}
+
+// getOrder loads an order, reporting a missing one as a *model.ServiceError
+func (s *OrderServiceImpl) getOrder(ctx context.Context, orderUUID uuid.UUID) (*model.Order, error) {
+	order, err := s.orderRepo.GetByUUID(ctx, orderUUID)
+	if errors.Is(err, model.ErrOrderNotFound) {
+		return nil, model.NewOrderNotFoundError(orderUUID.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get order %s: %w", orderUUID, err)
+	}
+
+	return order, nil
+}
+
+// withinTransaction runs fn in a transaction when the service has a transaction manager
+func (s *OrderServiceImpl) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.txManager == nil {
+		return fn(ctx)
+	}
+	return s.txManager.WithinTransaction(ctx, fn)
+}
+
+// recordEvent adds the event built from order to the outbox; it has to run in
+// the transaction storing the order change
+func (s *OrderServiceImpl) recordEvent(ctx context.Context, order *model.Order, toEvent func(*model.Order) (*model.Event, error)) error {
+	if s.outboxRepo == nil {
+		return nil
+	}
+
+	event, err := toEvent(order)
+	if err != nil {
+		return err
+	}
+
+	if err := s.outboxRepo.Add(ctx, event); err != nil {
+		return fmt.Errorf("add %s event of order %s: %w", event.Type, order.UUID, err)
+	}
+
+	return nil
+}
+
+// releaseRefundClaim returns an order to StatusPaid after a failed refund
+func (s *OrderServiceImpl) releaseRefundClaim(ctx context.Context, order *model.Order) {
+	// The claim has to be released even if the caller went away meanwhile
+	ctx = context.WithoutCancel(ctx)
+	order.Status = model.StatusPaid
+	order.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		logger.FromContext(ctx).Error("Failed to release refund claim", slog.String("order_uuid", order.UUID.String()), logger.Err(err))
+	}
+}
+
+// releasePaymentClaim returns an order to StatusPendingPayment after a failed payment
+// so the customer can retry
+func (s *OrderServiceImpl) releasePaymentClaim(ctx context.Context, order *model.Order) {
+	// The claim has to be released even if the caller went away meanwhile
+	ctx = context.WithoutCancel(ctx)
+	order.Status = model.StatusPendingPayment
+	order.UpdatedAt = time.Now()
+
+	if err := s.orderRepo.Update(ctx, order); err != nil {
+		logger.FromContext(ctx).Error("Failed to release payment claim", slog.String("order_uuid", order.UUID.String()), logger.Err(err))
+	}
+}
+
+// releaseReservation returns stock held for an order. A reservation already
+// committed for a payment that then failed is given back as sold parts.
+// Failures are only logged because the reservation expires on its own.
+func (s *OrderServiceImpl) releaseReservation(ctx context.Context, order *model.Order) {
+	if s.inventoryClient == nil {
+		return
+	}
+
+	err := s.inventoryClient.ReleaseReservation(ctx, order.UUID)
+	if hasCode(err, model.ErrCodeConflict) {
+		err = s.inventoryClient.ReturnParts(ctx, order.UUID, toReservationItems(order.Items))
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to release reservation", slog.String("order_uuid", order.UUID.String()), logger.Err(err))
+	}
+}
+
+// upstreamRes is a response reporting a failed call to an upstream; every
+// operation calling upstreams can return it
+type upstreamRes interface {
+	orderv1.CreateOrderRes
+	orderv1.PayOrderRes
+	orderv1.RefundOrderRes
+}
+
+// upstreamError converts an error of a call to upstream into a response telling
+// whether the request must be changed (400, 409), may be retried later (503)
+// or failed upstream (502). Not found errors mean something different to every
+// operation, so callers handle them.
+func upstreamError(upstream string, err error) upstreamRes {
+	var serviceErr *model.ServiceError
+	if !errors.As(err, &serviceErr) {
+		serviceErr = &model.ServiceError{Code: model.ErrCodeExternalServiceErr}
+	}
+
+	switch serviceErr.Code {
+	case model.ErrCodeInvalidArgument:
+		return &orderv1.BadRequestError{
+			Error:   upstream + "_rejected",
+			Message: serviceErr.Message,
+			Details: rejectionDetails(serviceErr),
+		}
+	case model.ErrCodeConflict:
+		return &orderv1.ConflictError{
+			Error:   upstream + "_conflict",
+			Message: serviceErr.Message,
+		}
+	case model.ErrCodeUnavailable:
+		return &orderv1.ServiceUnavailableError{
+			Error:   upstream + "_unavailable",
+			Message: upstream + " service is temporarily unavailable, retry later",
+		}
+	case model.ErrCodeDeadlineExceeded:
+		return &orderv1.ServiceUnavailableError{
+			Error:   upstream + "_timeout",
+			Message: upstream + " service did not answer in time",
+		}
+	default:
+		return &orderv1.BadGatewayError{
+			Error:   upstream + "_error",
+			Message: upstream + " service failed",
+		}
+	}
+}
+
+// rejectionDetails passes on the reason and the fields the upstream rejected
+// the request for, so that clients can tell what to fix
+func rejectionDetails(serviceErr *model.ServiceError) orderv1.OptBadRequestErrorDetails {
+	details := orderv1.BadRequestErrorDetails{}
+	if serviceErr.Reason != "" {
+		details["reason"] = rawJSON(serviceErr.Reason)
+	}
+	if len(serviceErr.Fields) > 0 {
+		details["fields"] = rawJSON(serviceErr.Fields)
+	}
+	if len(details) == 0 {
+		return orderv1.OptBadRequestErrorDetails{}
+	}
+
+	return orderv1.NewOptBadRequestErrorDetails(details)
+}
+
+// rawJSON encodes v, which must not fail to encode
+func rawJSON(v any) jx.Raw {
+	raw, _ := json.Marshal(v)
+	return raw
+}
+
+// joinUUIDs lists UUIDs separated by commas
+func joinUUIDs(uuids []uuid.UUID) string {
+	parts := make([]string, len(uuids))
+	for i, id := range uuids {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// chargeNotMade tells whether a failed PayOrder call provably charged nothing:
+// payment rejected or declined it, or could not be reached, which includes
+// calls stopped by an open circuit breaker. Payment charges an order at most
+// once, so paying again after it could not be reached is safe. Timeouts and
+// other failures leave the outcome unknown.
+func chargeNotMade(err error) bool {
+	return hasCode(err, model.ErrCodeInvalidArgument) ||
+		hasCode(err, model.ErrCodeConflict) ||
+		hasCode(err, model.ErrCodeUnavailable)
+}
+
+// refundNotMade tells whether a failed RefundPayment call provably returned
+// nothing: payment rejected it or does not know the payment. Every refund
+// call moves money anew, so unlike charges, refunds that may have reached
+// payment are never retried; their outcome is left to reconciliation.
+func refundNotMade(err error) bool {
+	return hasCode(err, model.ErrCodeInvalidArgument) ||
+		hasCode(err, model.ErrCodePaymentNotFound) ||
+		hasCode(err, model.ErrCodeConflict)
+}
+
+// hasCode tells whether err is a *model.ServiceError with code
+func hasCode(err error, code string) bool {
+	var serviceErr *model.ServiceError
+	return errors.As(err, &serviceErr) && serviceErr.Code == code
+}
+
+// toReservationItems converts order lines to the quantities inventory works with
+func toReservationItems(items []model.OrderItem) []client.ReservationItem {
+	reservationItems := make([]client.ReservationItem, len(items))
+	for i, item := range items {
+		reservationItems[i] = client.ReservationItem{PartUUID: item.PartUUID, Quantity: item.Quantity}
+	}
+
+	return reservationItems
+}
+
+// roundAmount rounds a money amount to cents
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
 
 // NewError creates a standardized internal server error response
 func (s *OrderServiceImpl) NewError(ctx context.Context, err error) *orderv1.InternalServerErrorStatusCode {
-	log.Printf("Internal error: %v", err)
+	logger.FromContext(ctx).Error("Internal error", logger.Err(err))
 	return &orderv1.InternalServerErrorStatusCode{
 		StatusCode: 500,
 		Response: orderv1.InternalServerError{
@@ -2,15 +2,36 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	orderv1 "github.com/nimbodex/microservices-factory/shared/pkg/openapi/order/v1"
 )
 
 // OrderService defines the interface for order service operations
 type OrderService interface {
-	CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (orderv1.CreateOrderRes, error)
+	CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, params orderv1.CreateOrderParams) (orderv1.CreateOrderRes, error)
 	GetOrder(ctx context.Context, params orderv1.GetOrderParams) (orderv1.GetOrderRes, error)
+	ListOrders(ctx context.Context, params orderv1.ListOrdersParams) (orderv1.ListOrdersRes, error)
 	PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, params orderv1.PayOrderParams) (orderv1.PayOrderRes, error)
 	CancelOrder(ctx context.Context, params orderv1.CancelOrderParams) (orderv1.CancelOrderRes, error)
+	RefundOrder(ctx context.Context, req orderv1.OptRefundOrderRequest, params orderv1.RefundOrderParams) (orderv1.RefundOrderRes, error)
 	NewError(ctx context.Context, err error) *orderv1.InternalServerErrorStatusCode
 }
+
+// OrderExpirer cancels orders left unpaid for too long
+type OrderExpirer interface {
+	ExpireOrders(ctx context.Context, createdBefore time.Time, pageSize int) (int, error)
+}
+
+// OrderReconciler settles orders left claimed for payment or refund
+type OrderReconciler interface {
+	ReconcileOrders(ctx context.Context, updatedBefore time.Time, pageSize int) (int, error)
+}
+
+// OrderAssemblyTracker moves paid orders through the ship assembly
+type OrderAssemblyTracker interface {
+	StartAssembly(ctx context.Context, orderUUID uuid.UUID) error
+	CompleteAssembly(ctx context.Context, orderUUID uuid.UUID) error
+}
@@ -1,37 +1,49 @@
 package main
 
 import (
-	"log"
-	"net"
+	"context"
+	"fmt"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
 	v1 "github.com/nimbodex/microservices-factory/payment/internal/api/payment/v1"
+	"github.com/nimbodex/microservices-factory/payment/internal/config"
 	"github.com/nimbodex/microservices-factory/payment/internal/repository/payment"
+	repotracing "github.com/nimbodex/microservices-factory/payment/internal/repository/tracing"
+	servicemetrics "github.com/nimbodex/microservices-factory/payment/internal/service/metrics"
 	paymentservice "github.com/nimbodex/microservices-factory/payment/internal/service/payment"
+	"github.com/nimbodex/microservices-factory/platform/pkg/app"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/health"
+	"github.com/nimbodex/microservices-factory/platform/pkg/metrics"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
 	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
 )
 
-const (
-	port = "localhost:50052"
-)
-
 func main() {
-	log.Println("Starting Payment Service...")
+	cfg := config.Default()
+	app.Run("payment", cfg, func(ctx context.Context, a *app.App) error {
+		return setup(ctx, a, cfg)
+	})
+}
 
-	lis, err := net.Listen("tcp", port)
+func setup(ctx context.Context, a *app.App, cfg *config.Config) error {
+	shutdownTracing, err := tracing.Init(ctx, "payment", cfg.Tracing)
 	if err != nil {
-		log.Fatalf("Failed to listen on port %s: %v", port, err)
+		return fmt.Errorf("init tracing: %w", err)
 	}
+	// Registered first so that spans of the last requests are flushed after everything else is closed
+	a.OnShutdown("tracer provider", shutdownTracing)
 
-	grpcServer := grpc.NewServer()
+	reg := metrics.NewRegistry()
+	grpcServer := grpc.NewServer(interceptor.ServerOptions(a.Logger(), reg)...)
 
 	// Initialize repository
-	paymentRepo := payment.NewMemoryPaymentRepository()
+	paymentRepo := repotracing.NewPaymentRepository(payment.NewMemoryPaymentRepository())
 
 	// Initialize service layer
-	paymentService := paymentservice.NewPaymentService(paymentRepo)
+	paymentService := servicemetrics.NewPaymentService(paymentservice.NewPaymentService(paymentRepo), reg)
 
 	// Initialize API handler
 	apiHandler := v1.NewAPIHandler(paymentService)
@@ -40,12 +52,18 @@ func main() {
 
 	reflection.Register(grpcServer)
 
-	log.Printf("Payment Service listening on %s", port)
-	log.Println("Available methods:")
-	log.Println("\t - PayOrder: processing the order payment command")
-	log.Println("For testing use grpcurl or any gRPC client")
+	// Probes see NOT_SERVING while in-flight calls finish on shutdown
+	healthServer := health.RegisterGRPC(grpcServer)
+	a.BeforeStop(healthServer.Shutdown)
 
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve gRPC server: %v", err)
-	}
+	a.Go("grpc", app.GRPCServer(cfg.GRPC.Addr, grpcServer))
+	a.Go("admin", app.HTTPServer(metrics.NewAdminServer(cfg.Admin.Addr, reg)))
+
+	a.Logger().Info("Payment Service listening",
+		"addr", cfg.GRPC.Addr,
+		"admin_addr", cfg.Admin.Addr,
+		"methods", []string{"PayOrder", "RefundPayment"},
+	)
+
+	return nil
 }
@@ -3,10 +3,26 @@ package v1
 import (
 	"context"
 
+	"google.golang.org/grpc/codes"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
 	"github.com/nimbodex/microservices-factory/payment/internal/service"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
 	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
 )
 
+// errorMapper sends service errors with the status codes callers can act on
+var errorMapper = grpcerr.NewMapper("payment", map[string]codes.Code{
+	model.ErrCodeInvalidPaymentMethod: codes.InvalidArgument,
+	model.ErrCodeInvalidAmount:        codes.InvalidArgument,
+	model.ErrCodeInvalidUUID:          codes.InvalidArgument,
+	model.ErrCodeValidationError:      codes.InvalidArgument,
+	model.ErrCodePaymentNotFound:      codes.NotFound,
+	model.ErrCodeRefundNotAllowed:     codes.FailedPrecondition,
+	model.ErrCodePaymentFailed:        codes.Aborted,
+	model.ErrCodeInternalError:        codes.Internal,
+})
+
 // APIHandler handles gRPC requests for payment API
 type APIHandler struct {
 	paymentv1.UnimplementedPaymentServiceServer
@@ -22,5 +38,30 @@ func NewAPIHandler(paymentService service.PaymentService) *APIHandler {
 
 // PayOrder handles PayOrder gRPC requests
 func (h *APIHandler) PayOrder(ctx context.Context, req *paymentv1.PayOrderRequest) (*paymentv1.PayOrderResponse, error) {
-	return h.paymentService.PayOrder(ctx, req)
+	resp, err := h.paymentService.PayOrder(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// RefundPayment handles RefundPayment gRPC requests
+func (h *APIHandler) RefundPayment(ctx context.Context, req *paymentv1.RefundPaymentRequest) (*paymentv1.RefundPaymentResponse, error) {
+	resp, err := h.paymentService.RefundPayment(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// GetPayment handles GetPayment gRPC requests
+func (h *APIHandler) GetPayment(ctx context.Context, req *paymentv1.GetPaymentRequest) (*paymentv1.GetPaymentResponse, error) {
+	resp, err := h.paymentService.GetPayment(ctx, req)
+	if err != nil {
+		return nil, errorMapper.Error(ctx, err)
+	}
+
+	return resp, nil
 }
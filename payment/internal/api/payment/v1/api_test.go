@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/payment/internal/repository/mocks"
+	paymentservice "github.com/nimbodex/microservices-factory/payment/internal/service/payment"
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/grpcerr"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+func TestAPIHandler_PayOrder_InvalidAmount(t *testing.T) {
+	handler := NewAPIHandler(paymentservice.NewPaymentService(repomocks.NewPaymentRepository(t)))
+
+	_, err := handler.PayOrder(context.Background(), &paymentv1.PayOrderRequest{
+		OrderUuid:     uuid.NewString(),
+		PaymentMethod: paymentv1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        -1,
+	})
+
+	details := grpcerr.Decode(err)
+	assert.Equal(t, codes.InvalidArgument, details.Code)
+	assert.Equal(t, model.ErrCodeInvalidAmount, details.Reason)
+	assert.Equal(t, "payment", details.Domain)
+	assert.Equal(t, []grpcerr.FieldViolation{{Field: "amount", Description: details.Message}}, details.FieldViolations)
+}
+
+func TestAPIHandler_RefundPayment_ErrorCodes(t *testing.T) {
+	transactionUUID := uuid.New()
+
+	tests := []struct {
+		name       string
+		payment    *model.Payment
+		repoErr    error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "not found", repoErr: assert.AnError, wantCode: codes.NotFound, wantReason: model.ErrCodePaymentNotFound},
+		{name: "already refunded", payment: &model.Payment{UUID: uuid.New(), TransactionUUID: transactionUUID, Status: model.PaymentStatusRefunded}, wantCode: codes.FailedPrecondition, wantReason: model.ErrCodeRefundNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymentRepo := repomocks.NewPaymentRepository(t)
+			paymentRepo.On("GetByTransactionUUID", mock.Anything, transactionUUID).Return(tt.payment, tt.repoErr)
+
+			_, err := NewAPIHandler(paymentservice.NewPaymentService(paymentRepo)).RefundPayment(context.Background(), &paymentv1.RefundPaymentRequest{TransactionUuid: transactionUUID.String()})
+
+			details := grpcerr.Decode(err)
+			assert.Equal(t, tt.wantCode, details.Code)
+			assert.Equal(t, tt.wantReason, details.Reason)
+			assert.Empty(t, details.FieldViolations)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"errors"
+
+	platformconfig "github.com/nimbodex/microservices-factory/platform/pkg/config"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+// Config is the configuration of the payment service
+type Config struct {
+	GRPC    GRPC               `yaml:"grpc"`
+	Admin   Admin              `yaml:"admin"`
+	Tracing tracing.Config     `yaml:"tracing"`
+	Log     platformconfig.Log `yaml:"log"`
+}
+
+// GRPC configures the payment gRPC server
+type GRPC struct {
+	Addr string `yaml:"addr" env:"PAYMENT_GRPC_ADDR"`
+}
+
+// Admin configures the admin HTTP server exposing metrics
+type Admin struct {
+	Addr string `yaml:"addr" env:"PAYMENT_ADMIN_ADDR"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		GRPC:  GRPC{Addr: "localhost:50052"},
+		Admin: Admin{Addr: "localhost:9052"},
+		Log:   platformconfig.Log{Level: "info"},
+	}
+}
+
+// Validate checks that every setting can be used
+func (c *Config) Validate() error {
+	return errors.Join(
+		platformconfig.Required("grpc.addr", c.GRPC.Addr),
+		platformconfig.Required("admin.addr", c.Admin.Addr),
+		c.Log.Validate(),
+	)
+}
+
+// LogLevel returns the configured log level
+func (c *Config) LogLevel() string {
+	return c.Log.Level
+}
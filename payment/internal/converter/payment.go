@@ -47,13 +47,67 @@ func ToServicePayOrderRequest(protoReq *paymentv1.PayOrderRequest) (*model.PayOr
 	return &model.PayOrderRequest{
 		OrderUUID:     orderUUID,
 		PaymentMethod: ToServicePaymentMethod(protoReq.PaymentMethod),
-		Amount:        0.0, // Default amount, could be calculated from order
+		Amount:        protoReq.Amount,
 	}, nil
 }
 
 // ToProtoPayOrderResponse converts service model to protobuf response
-func ToProtoPayOrderResponse(transactionUUID uuid.UUID) *paymentv1.PayOrderResponse {
+func ToProtoPayOrderResponse(transactionUUID uuid.UUID, alreadyPaid bool) *paymentv1.PayOrderResponse {
 	return &paymentv1.PayOrderResponse{
 		TransactionUuid: transactionUUID.String(),
+		AlreadyPaid:     alreadyPaid,
+	}
+}
+
+// ToServiceRefundPaymentRequest converts protobuf request to service model
+func ToServiceRefundPaymentRequest(protoReq *paymentv1.RefundPaymentRequest) (*model.RefundPaymentRequest, error) {
+	if protoReq == nil {
+		return nil, fmt.Errorf("protoReq cannot be nil")
+	}
+
+	transactionUUID, err := uuid.Parse(protoReq.TransactionUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RefundPaymentRequest{
+		TransactionUUID: transactionUUID,
+		Amount:          protoReq.Amount,
+		Reason:          protoReq.Reason,
+	}, nil
+}
+
+// ToProtoPaymentStatus converts service model PaymentStatus to protobuf
+func ToProtoPaymentStatus(status model.PaymentStatus) paymentv1.PaymentStatus {
+	switch status {
+	case model.PaymentStatusCompleted:
+		return paymentv1.PaymentStatus_PAYMENT_STATUS_COMPLETED
+	case model.PaymentStatusPartiallyRefunded:
+		return paymentv1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED
+	case model.PaymentStatusRefunded:
+		return paymentv1.PaymentStatus_PAYMENT_STATUS_REFUNDED
+	default:
+		return paymentv1.PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+	}
+}
+
+// ToProtoRefundPaymentResponse converts a refunded payment to protobuf response
+func ToProtoRefundPaymentResponse(payment *model.Payment, refundUUID uuid.UUID) *paymentv1.RefundPaymentResponse {
+	return &paymentv1.RefundPaymentResponse{
+		RefundUuid:      refundUUID.String(),
+		RefundedAmount:  payment.RefundedAmount,
+		RemainingAmount: payment.RemainingAmount(),
+		Status:          ToProtoPaymentStatus(payment.Status),
+	}
+}
+
+// ToProtoGetPaymentResponse converts a payment to protobuf response
+func ToProtoGetPaymentResponse(payment *model.Payment) *paymentv1.GetPaymentResponse {
+	return &paymentv1.GetPaymentResponse{
+		TransactionUuid: payment.TransactionUUID.String(),
+		PaymentMethod:   ToProtoPaymentMethod(payment.PaymentMethod),
+		Amount:          payment.Amount,
+		RefundedAmount:  payment.RefundedAmount,
+		Status:          ToProtoPaymentStatus(payment.Status),
 	}
 }
@@ -1,12 +1,20 @@
 package model
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPaymentNotFound is returned by repositories when no payment matches the lookup
+var ErrPaymentNotFound = errors.New("payment not found")
 
 // ServiceError represents a service layer error
 type ServiceError struct {
 	Code    string
 	Message string
-	Err     error
+	// Field is the request field that caused the error, if any
+	Field string
+	Err   error
 }
 
 func (e *ServiceError) Error() string {
@@ -16,6 +24,15 @@ func (e *ServiceError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+func (e *ServiceError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode, ErrorMessage and ErrorField let errors be sent over gRPC with details
+func (e *ServiceError) ErrorCode() string    { return e.Code }
+func (e *ServiceError) ErrorMessage() string { return e.Message }
+func (e *ServiceError) ErrorField() string   { return e.Field }
+
 // Common service error codes
 const (
 	ErrCodePaymentNotFound      = "PAYMENT_NOT_FOUND"
@@ -25,6 +42,7 @@ const (
 	ErrCodeInvalidUUID          = "INVALID_UUID"
 	ErrCodeInternalError        = "INTERNAL_ERROR"
 	ErrCodeValidationError      = "VALIDATION_ERROR"
+	ErrCodeRefundNotAllowed     = "REFUND_NOT_ALLOWED"
 )
 
 // Error constructors
@@ -39,6 +57,7 @@ func NewInvalidPaymentMethodError(method PaymentMethod) *ServiceError {
 	return &ServiceError{
 		Code:    ErrCodeInvalidPaymentMethod,
 		Message: fmt.Sprintf("invalid payment method: %s", method),
+		Field:   "payment_method",
 	}
 }
 
@@ -46,6 +65,7 @@ func NewInvalidAmountError(amount float64) *ServiceError {
 	return &ServiceError{
 		Code:    ErrCodeInvalidAmount,
 		Message: fmt.Sprintf("invalid amount: %f", amount),
+		Field:   "amount",
 	}
 }
 
@@ -57,10 +77,11 @@ func NewPaymentFailedError(err error) *ServiceError {
 	}
 }
 
-func NewInvalidUUIDError(uuid string) *ServiceError {
+func NewInvalidUUIDError(field, uuid string) *ServiceError {
 	return &ServiceError{
 		Code:    ErrCodeInvalidUUID,
 		Message: fmt.Sprintf("invalid UUID: %s", uuid),
+		Field:   field,
 	}
 }
 
@@ -78,3 +99,10 @@ func NewValidationError(message string) *ServiceError {
 		Message: message,
 	}
 }
+
+func NewRefundNotAllowedError(status PaymentStatus) *ServiceError {
+	return &ServiceError{
+		Code:    ErrCodeRefundNotAllowed,
+		Message: fmt.Sprintf("payment in status %s cannot be refunded", status),
+	}
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,6 +24,9 @@ const (
 	PaymentStatusCompleted PaymentStatus = "COMPLETED"
 	PaymentStatusFailed    PaymentStatus = "FAILED"
 	PaymentStatusCancelled PaymentStatus = "CANCELLED"
+	// PaymentStatusPartiallyRefunded marks a completed payment with part of the amount returned
+	PaymentStatusPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
+	PaymentStatusRefunded          PaymentStatus = "REFUNDED"
 )
 
 // Payment represents a payment in the service layer
@@ -33,8 +37,37 @@ type Payment struct {
 	Amount          float64       `json:"amount"`
 	Status          PaymentStatus `json:"status"`
 	TransactionUUID uuid.UUID     `json:"transaction_uuid"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	// RefundedAmount is the sum of all refunds and never exceeds Amount
+	RefundedAmount float64   `json:"refunded_amount"`
+	Refunds        []Refund  `json:"refunds,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// RemainingAmount returns the part of the payment that can still be refunded
+func (p *Payment) RemainingAmount() float64 {
+	return RoundAmount(p.Amount - p.RefundedAmount)
+}
+
+// Refund represents money returned from a payment
+type Refund struct {
+	UUID      uuid.UUID `json:"uuid"`
+	Amount    float64   `json:"amount"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RefundPaymentRequest represents request to refund a payment. Zero Amount
+// refunds everything not refunded yet.
+type RefundPaymentRequest struct {
+	TransactionUUID uuid.UUID `json:"transaction_uuid"`
+	Amount          float64   `json:"amount"`
+	Reason          string    `json:"reason,omitempty"`
+}
+
+// RoundAmount rounds a money amount to cents so repeated partial refunds add up exactly
+func RoundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
 
 // PayOrderRequest represents request to pay an order
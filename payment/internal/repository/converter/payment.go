@@ -15,6 +15,16 @@ func ToRepoPayment(servicePayment *model.Payment) *repomodel.Payment {
 		return nil
 	}
 
+	var refunds []repomodel.Refund
+	for _, refund := range servicePayment.Refunds {
+		refunds = append(refunds, repomodel.Refund{
+			UUID:      refund.UUID.String(),
+			Amount:    refund.Amount,
+			Reason:    refund.Reason,
+			CreatedAt: refund.CreatedAt,
+		})
+	}
+
 	return &repomodel.Payment{
 		UUID:            servicePayment.UUID.String(),
 		OrderUUID:       servicePayment.OrderUUID.String(),
@@ -22,6 +32,8 @@ func ToRepoPayment(servicePayment *model.Payment) *repomodel.Payment {
 		Amount:          servicePayment.Amount,
 		Status:          string(servicePayment.Status),
 		TransactionUUID: servicePayment.TransactionUUID.String(),
+		RefundedAmount:  servicePayment.RefundedAmount,
+		Refunds:         refunds,
 		CreatedAt:       servicePayment.CreatedAt,
 		UpdatedAt:       servicePayment.UpdatedAt,
 	}
@@ -48,6 +60,20 @@ func FromRepoPayment(repoPayment *repomodel.Payment) (*model.Payment, error) {
 		return nil, err
 	}
 
+	var refunds []model.Refund
+	for _, refund := range repoPayment.Refunds {
+		refundUUID, err := uuid.Parse(refund.UUID)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, model.Refund{
+			UUID:      refundUUID,
+			Amount:    refund.Amount,
+			Reason:    refund.Reason,
+			CreatedAt: refund.CreatedAt,
+		})
+	}
+
 	return &model.Payment{
 		UUID:            paymentUUID,
 		OrderUUID:       orderUUID,
@@ -55,6 +81,8 @@ func FromRepoPayment(repoPayment *repomodel.Payment) (*model.Payment, error) {
 		Amount:          repoPayment.Amount,
 		Status:          model.PaymentStatus(repoPayment.Status),
 		TransactionUUID: transactionUUID,
+		RefundedAmount:  repoPayment.RefundedAmount,
+		Refunds:         refunds,
 		CreatedAt:       repoPayment.CreatedAt,
 		UpdatedAt:       repoPayment.UpdatedAt,
 	}, nil
@@ -12,6 +12,16 @@ type Payment struct {
 	Amount          float64   `json:"amount"`
 	Status          string    `json:"status"`
 	TransactionUUID string    `json:"transaction_uuid"`
+	RefundedAmount  float64   `json:"refunded_amount"`
+	Refunds         []Refund  `json:"refunds,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
+
+// Refund represents a refund of a payment in the repository layer
+type Refund struct {
+	UUID      string    `json:"uuid"`
+	Amount    float64   `json:"amount"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
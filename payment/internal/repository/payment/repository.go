@@ -51,7 +51,7 @@ func (r *MemoryPaymentRepository) GetByUUID(ctx context.Context, uuid uuid.UUID)
 
 	payment, exists := r.payments[uuid.String()]
 	if !exists {
-		return nil, fmt.Errorf("payment with UUID %s not found", uuid)
+		return nil, fmt.Errorf("payment with UUID %s: %w", uuid, model.ErrPaymentNotFound)
 	}
 
 	// Return a copy to avoid external modifications
@@ -72,7 +72,7 @@ func (r *MemoryPaymentRepository) GetByOrderUUID(ctx context.Context, orderUUID
 		}
 	}
 
-	return nil, fmt.Errorf("payment for order %s not found", orderUUID)
+	return nil, fmt.Errorf("payment for order %s: %w", orderUUID, model.ErrPaymentNotFound)
 }
 
 // GetByTransactionUUID retrieves a payment by transaction UUID
@@ -88,7 +88,7 @@ func (r *MemoryPaymentRepository) GetByTransactionUUID(ctx context.Context, tran
 		}
 	}
 
-	return nil, fmt.Errorf("payment with transaction UUID %s not found", transactionUUID)
+	return nil, fmt.Errorf("payment with transaction UUID %s: %w", transactionUUID, model.ErrPaymentNotFound)
 }
 
 // Update updates an existing payment
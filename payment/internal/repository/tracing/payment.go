@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
+	"github.com/nimbodex/microservices-factory/payment/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/tracing"
+)
+
+const instrumentation = "github.com/nimbodex/microservices-factory/payment/internal/repository"
+
+// PaymentRepository wraps a PaymentRepository and records a span for every operation
+type PaymentRepository struct {
+	next repository.PaymentRepository
+}
+
+// NewPaymentRepository creates a new tracing payment repository on top of next
+func NewPaymentRepository(next repository.PaymentRepository) *PaymentRepository {
+	return &PaymentRepository{next: next}
+}
+
+// Create stores a new payment
+func (r *PaymentRepository) Create(ctx context.Context, payment *model.Payment) error {
+	ctx, span := start(ctx, "PaymentRepository.Create", attribute.String("order_uuid", payment.OrderUUID.String()))
+	err := r.next.Create(ctx, payment)
+	tracing.End(span, err)
+	return err
+}
+
+// GetByUUID retrieves a payment by its UUID
+func (r *PaymentRepository) GetByUUID(ctx context.Context, paymentUUID uuid.UUID) (*model.Payment, error) {
+	ctx, span := start(ctx, "PaymentRepository.GetByUUID", attribute.String("payment_uuid", paymentUUID.String()))
+	payment, err := r.next.GetByUUID(ctx, paymentUUID)
+	tracing.End(span, err)
+	return payment, err
+}
+
+// GetByOrderUUID retrieves the payment of an order
+func (r *PaymentRepository) GetByOrderUUID(ctx context.Context, orderUUID uuid.UUID) (*model.Payment, error) {
+	ctx, span := start(ctx, "PaymentRepository.GetByOrderUUID", attribute.String("order_uuid", orderUUID.String()))
+	payment, err := r.next.GetByOrderUUID(ctx, orderUUID)
+	tracing.End(span, err)
+	return payment, err
+}
+
+// GetByTransactionUUID retrieves a payment by its transaction UUID
+func (r *PaymentRepository) GetByTransactionUUID(ctx context.Context, transactionUUID uuid.UUID) (*model.Payment, error) {
+	ctx, span := start(ctx, "PaymentRepository.GetByTransactionUUID", attribute.String("transaction_uuid", transactionUUID.String()))
+	payment, err := r.next.GetByTransactionUUID(ctx, transactionUUID)
+	tracing.End(span, err)
+	return payment, err
+}
+
+// Update stores changes of an existing payment
+func (r *PaymentRepository) Update(ctx context.Context, payment *model.Payment) error {
+	ctx, span := start(ctx, "PaymentRepository.Update", attribute.String("payment_uuid", payment.UUID.String()))
+	err := r.next.Update(ctx, payment)
+	tracing.End(span, err)
+	return err
+}
+
+// Delete removes a payment
+func (r *PaymentRepository) Delete(ctx context.Context, paymentUUID uuid.UUID) error {
+	ctx, span := start(ctx, "PaymentRepository.Delete", attribute.String("payment_uuid", paymentUUID.String()))
+	err := r.next.Delete(ctx, paymentUUID)
+	tracing.End(span, err)
+	return err
+}
+
+func start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, instrumentation, name, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
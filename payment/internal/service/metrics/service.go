@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/converter"
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
+	"github.com/nimbodex/microservices-factory/payment/internal/service"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+// PaymentService wraps a PaymentService and counts payments, refunds and
+// failures by their error code
+type PaymentService struct {
+	next     service.PaymentService
+	payments *prometheus.CounterVec
+	amount   *prometheus.CounterVec
+	refunds  prometheus.Counter
+	failures *prometheus.CounterVec
+}
+
+// NewPaymentService creates a new metrics collecting payment service on top
+// of next, registering its metrics with reg
+func NewPaymentService(next service.PaymentService, reg prometheus.Registerer) *PaymentService {
+	factory := promauto.With(reg)
+	return &PaymentService{
+		next: next,
+		payments: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "payments_total",
+			Help: "Total number of successful payments, by payment method.",
+		}, []string{"payment_method"}),
+		amount: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "payments_amount_total",
+			Help: "Total amount of successful payments, by payment method.",
+		}, []string{"payment_method"}),
+		refunds: factory.NewCounter(prometheus.CounterOpts{
+			Name: "payment_refunds_total",
+			Help: "Total number of successful refunds.",
+		}),
+		failures: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "payment_failures_total",
+			Help: "Total number of failed payment operations, by operation and error code.",
+		}, []string{"operation", "code"}),
+	}
+}
+
+// PayOrder processes payment for an order. Orders paid again get their
+// existing transaction back and are not counted twice.
+func (s *PaymentService) PayOrder(ctx context.Context, req *paymentv1.PayOrderRequest) (*paymentv1.PayOrderResponse, error) {
+	resp, err := s.next.PayOrder(ctx, req)
+	if err != nil {
+		s.failures.WithLabelValues("PayOrder", errorCode(err)).Inc()
+		return nil, err
+	}
+	if resp.GetAlreadyPaid() {
+		return resp, nil
+	}
+
+	method := string(converter.ToServicePaymentMethod(req.GetPaymentMethod()))
+	s.payments.WithLabelValues(method).Inc()
+	s.amount.WithLabelValues(method).Add(req.GetAmount())
+
+	return resp, nil
+}
+
+// RefundPayment returns money of a completed payment
+func (s *PaymentService) RefundPayment(ctx context.Context, req *paymentv1.RefundPaymentRequest) (*paymentv1.RefundPaymentResponse, error) {
+	resp, err := s.next.RefundPayment(ctx, req)
+	if err != nil {
+		s.failures.WithLabelValues("RefundPayment", errorCode(err)).Inc()
+		return nil, err
+	}
+
+	s.refunds.Inc()
+
+	return resp, nil
+}
+
+// GetPayment returns the payment of an order
+func (s *PaymentService) GetPayment(ctx context.Context, req *paymentv1.GetPaymentRequest) (*paymentv1.GetPaymentResponse, error) {
+	return s.next.GetPayment(ctx, req)
+}
+
+// errorCode returns the ServiceError code of err
+func errorCode(err error) string {
+	var serviceErr *model.ServiceError
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Code
+	}
+	return model.ErrCodeInternalError
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/repository/payment"
+	paymentservice "github.com/nimbodex/microservices-factory/payment/internal/service/payment"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+func TestPaymentService_CountsPaymentsAndFailures(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	service := NewPaymentService(paymentservice.NewPaymentService(payment.NewMemoryPaymentRepository()), reg)
+
+	for _, amount := range []float64{100, 50.25} {
+		req := &paymentv1.PayOrderRequest{
+			OrderUuid:     uuid.NewString(),
+			PaymentMethod: paymentv1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        amount,
+		}
+		_, err := service.PayOrder(ctx, req)
+		require.NoError(t, err)
+
+		// Paying the order again returns its transaction without a new charge
+		_, err = service.PayOrder(ctx, req)
+		require.NoError(t, err)
+	}
+
+	_, err := service.PayOrder(ctx, &paymentv1.PayOrderRequest{
+		OrderUuid:     uuid.NewString(),
+		PaymentMethod: paymentv1.PaymentMethod_PAYMENT_METHOD_UNKNOWN,
+		Amount:        10,
+	})
+	require.Error(t, err)
+
+	_, err = service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{TransactionUuid: uuid.NewString()})
+	require.Error(t, err)
+
+	want := `
+# HELP payment_failures_total Total number of failed payment operations, by operation and error code.
+# TYPE payment_failures_total counter
+payment_failures_total{code="INVALID_PAYMENT_METHOD",operation="PayOrder"} 1
+payment_failures_total{code="PAYMENT_NOT_FOUND",operation="RefundPayment"} 1
+# HELP payments_amount_total Total amount of successful payments, by payment method.
+# TYPE payments_amount_total counter
+payments_amount_total{payment_method="CARD"} 150.25
+# HELP payments_total Total number of successful payments, by payment method.
+# TYPE payments_total counter
+payments_total{payment_method="CARD"} 2
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"payment_failures_total", "payments_amount_total", "payments_total"))
+}
@@ -0,0 +1,57 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/payment/internal/repository/mocks"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+func (s *PaymentServiceTestSuite) TestGetPayment_ReturnsPaymentOfOrder() {
+	ctx := context.Background()
+	payment := completedPayment(300.0)
+	payment.RefundedAmount = 100.0
+	payment.Status = model.PaymentStatusPartiallyRefunded
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByOrderUUID", mock.Anything, payment.OrderUUID).Return(payment, nil)
+
+	resp, err := NewPaymentService(mockRepo).GetPayment(ctx, &paymentv1.GetPaymentRequest{OrderUuid: payment.OrderUUID.String()})
+
+	s.Require().NoError(err)
+	s.Equal(payment.TransactionUUID.String(), resp.TransactionUuid)
+	s.Equal(300.0, resp.Amount)
+	s.Equal(100.0, resp.RefundedAmount)
+	s.Equal(paymentv1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED, resp.Status)
+	s.Equal(paymentv1.PaymentMethod_PAYMENT_METHOD_CARD, resp.PaymentMethod)
+}
+
+func (s *PaymentServiceTestSuite) TestGetPayment_Errors() {
+	payment := completedPayment(300.0)
+
+	tests := []struct {
+		name     string
+		repoErr  error
+		wantCode string
+	}{
+		{name: "not paid", repoErr: model.ErrPaymentNotFound, wantCode: model.ErrCodePaymentNotFound},
+		{name: "repository failure", repoErr: errors.New("db is down"), wantCode: model.ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			mockRepo := repomocks.NewPaymentRepository(s.T())
+			mockRepo.On("GetByOrderUUID", mock.Anything, payment.OrderUUID).Return(nil, tt.repoErr)
+
+			_, err := NewPaymentService(mockRepo).GetPayment(context.Background(), &paymentv1.GetPaymentRequest{OrderUuid: payment.OrderUUID.String()})
+
+			var serviceErr *model.ServiceError
+			s.Require().ErrorAs(err, &serviceErr)
+			s.Equal(tt.wantCode, serviceErr.Code)
+		})
+	}
+}
@@ -22,6 +22,7 @@ func (s *PaymentServiceTestSuite) TestPayOrder_Success() {
 	}
 
 	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByOrderUUID", mock.Anything, orderUUID).Return(nil, model.ErrPaymentNotFound)
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(payment *model.Payment) bool {
 		return payment.OrderUUID == orderUUID &&
 			payment.PaymentMethod == model.PaymentMethodCard &&
@@ -35,6 +36,7 @@ func (s *PaymentServiceTestSuite) TestPayOrder_Success() {
 	s.NoError(err)
 	s.NotNil(result)
 	s.NotEmpty(result.TransactionUuid)
+	s.False(result.AlreadyPaid)
 
 	_, err = uuid.Parse(result.TransactionUuid)
 	s.NoError(err)
@@ -52,6 +54,7 @@ func (s *PaymentServiceTestSuite) TestPayOrder_SBP_Success() {
 	}
 
 	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByOrderUUID", mock.Anything, orderUUID).Return(nil, model.ErrPaymentNotFound)
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(payment *model.Payment) bool {
 		return payment.OrderUUID == orderUUID &&
 			payment.PaymentMethod == model.PaymentMethodSBP &&
@@ -83,8 +86,11 @@ func (s *PaymentServiceTestSuite) TestPayOrder_InvalidOrderUUID() {
 
 	result, err := service.PayOrder(ctx, req)
 
-	s.Error(err)
 	s.Nil(result)
+	var serviceErr *model.ServiceError
+	s.Require().ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodeInvalidUUID, serviceErr.Code)
+	s.Equal("order_uuid", serviceErr.Field)
 
 	mockRepo.AssertExpectations(s.T())
 }
@@ -120,6 +126,7 @@ func (s *PaymentServiceTestSuite) TestPayOrder_RepositoryError() {
 	}
 
 	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByOrderUUID", mock.Anything, orderUUID).Return(nil, model.ErrPaymentNotFound)
 	mockRepo.On("Create", mock.Anything, mock.Anything).Return(assert.AnError)
 
 	service := NewPaymentService(mockRepo)
@@ -151,3 +158,25 @@ func (s *PaymentServiceTestSuite) TestPayOrder_EmptyOrderUUID() {
 
 	mockRepo.AssertExpectations(s.T())
 }
+
+func (s *PaymentServiceTestSuite) TestPayOrder_AlreadyPaidReturnsFirstTransaction() {
+	orderUUID := uuid.New()
+	paid := &model.Payment{UUID: uuid.New(), OrderUUID: orderUUID, TransactionUUID: uuid.New(), Status: model.PaymentStatusCompleted}
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByOrderUUID", mock.Anything, orderUUID).Return(paid, nil)
+
+	service := NewPaymentService(mockRepo)
+
+	// A retry after the answer to the first call was lost must not charge again
+	result, err := service.PayOrder(context.Background(), &paymentv1.PayOrderRequest{
+		OrderUuid:     orderUUID.String(),
+		PaymentMethod: paymentv1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        100,
+	})
+
+	s.NoError(err)
+	s.Equal(paid.TransactionUUID.String(), result.TransactionUuid)
+	s.True(result.AlreadyPaid)
+	mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
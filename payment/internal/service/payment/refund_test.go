@@ -0,0 +1,150 @@
+package payment
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nimbodex/microservices-factory/payment/internal/model"
+	repomocks "github.com/nimbodex/microservices-factory/payment/internal/repository/mocks"
+	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
+)
+
+func completedPayment(amount float64) *model.Payment {
+	return &model.Payment{
+		UUID:            uuid.New(),
+		OrderUUID:       uuid.New(),
+		PaymentMethod:   model.PaymentMethodCard,
+		Amount:          amount,
+		Status:          model.PaymentStatusCompleted,
+		TransactionUUID: uuid.New(),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_Full() {
+	ctx := context.Background()
+	payment := completedPayment(300.0)
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByTransactionUUID", mock.Anything, payment.TransactionUUID).Return(payment, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Payment) bool {
+		return updated.Status == model.PaymentStatusRefunded &&
+			updated.RefundedAmount == 300.0 &&
+			len(updated.Refunds) == 1 &&
+			updated.Refunds[0].Reason == "customer request"
+	})).Return(nil)
+
+	service := NewPaymentService(mockRepo)
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{
+		TransactionUuid: payment.TransactionUUID.String(),
+		Reason:          "customer request",
+	})
+
+	s.NoError(err)
+	s.Equal(300.0, result.RefundedAmount)
+	s.Zero(result.RemainingAmount)
+	s.Equal(paymentv1.PaymentStatus_PAYMENT_STATUS_REFUNDED, result.Status)
+	_, err = uuid.Parse(result.RefundUuid)
+	s.NoError(err)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_Partial() {
+	ctx := context.Background()
+	payment := completedPayment(100.0)
+	payment.Status = model.PaymentStatusPartiallyRefunded
+	payment.RefundedAmount = 30.1
+	payment.Refunds = []model.Refund{{UUID: uuid.New(), Amount: 30.1}}
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByTransactionUUID", mock.Anything, payment.TransactionUUID).Return(payment, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Payment) bool {
+		return updated.Status == model.PaymentStatusPartiallyRefunded && len(updated.Refunds) == 2
+	})).Return(nil)
+
+	service := NewPaymentService(mockRepo)
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{
+		TransactionUuid: payment.TransactionUUID.String(),
+		Amount:          39.7,
+	})
+
+	s.NoError(err)
+	s.Equal(69.8, result.RefundedAmount)
+	s.Equal(30.2, result.RemainingAmount)
+	s.Equal(paymentv1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_REFUNDED, result.Status)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_ExceedsRemaining() {
+	ctx := context.Background()
+	payment := completedPayment(100.0)
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByTransactionUUID", mock.Anything, payment.TransactionUUID).Return(payment, nil)
+
+	service := NewPaymentService(mockRepo)
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{
+		TransactionUuid: payment.TransactionUUID.String(),
+		Amount:          100.01,
+	})
+
+	s.Nil(result)
+	var serviceErr *model.ServiceError
+	s.ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodeInvalidAmount, serviceErr.Code)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_AlreadyRefunded() {
+	ctx := context.Background()
+	payment := completedPayment(100.0)
+	payment.Status = model.PaymentStatusRefunded
+	payment.RefundedAmount = 100.0
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByTransactionUUID", mock.Anything, payment.TransactionUUID).Return(payment, nil)
+
+	service := NewPaymentService(mockRepo)
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{TransactionUuid: payment.TransactionUUID.String()})
+
+	s.Nil(result)
+	var serviceErr *model.ServiceError
+	s.ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodeRefundNotAllowed, serviceErr.Code)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_InvalidRequest() {
+	ctx := context.Background()
+	service := NewPaymentService(repomocks.NewPaymentRepository(s.T()))
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{TransactionUuid: "not-a-uuid"})
+	s.Nil(result)
+	s.Error(err)
+
+	result, err = service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{TransactionUuid: uuid.New().String(), Amount: -1})
+	s.Nil(result)
+	s.Error(err)
+}
+
+func (s *PaymentServiceTestSuite) TestRefundPayment_NotFound() {
+	ctx := context.Background()
+	transactionUUID := uuid.New()
+
+	mockRepo := repomocks.NewPaymentRepository(s.T())
+	mockRepo.On("GetByTransactionUUID", mock.Anything, transactionUUID).Return(nil, assert.AnError)
+
+	service := NewPaymentService(mockRepo)
+
+	result, err := service.RefundPayment(ctx, &paymentv1.RefundPaymentRequest{TransactionUuid: transactionUUID.String()})
+
+	s.Nil(result)
+	var serviceErr *model.ServiceError
+	s.ErrorAs(err, &serviceErr)
+	s.Equal(model.ErrCodePaymentNotFound, serviceErr.Code)
+}
@@ -2,7 +2,9 @@ package payment
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,6 +12,7 @@ import (
 	"github.com/nimbodex/microservices-factory/payment/internal/converter"
 	"github.com/nimbodex/microservices-factory/payment/internal/model"
 	"github.com/nimbodex/microservices-factory/payment/internal/repository"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
 	paymentv1 "github.com/nimbodex/microservices-factory/shared/pkg/proto/payment/v1"
 )
 
@@ -17,6 +20,10 @@ import (
 type PaymentServiceImpl struct {
 	paymentv1.UnimplementedPaymentServiceServer
 	paymentRepo repository.PaymentRepository
+	// payMu serialises payments so concurrent calls for one order cannot both charge it
+	payMu sync.Mutex
+	// refundMu serialises refunds so concurrent partial refunds cannot exceed the paid amount
+	refundMu sync.Mutex
 }
 
 // NewPaymentService creates a new payment service instance
@@ -26,29 +33,45 @@ func NewPaymentService(paymentRepo repository.PaymentRepository) *PaymentService
 	}
 }
 
-// PayOrder processes payment for an order and returns a transaction UUID
+// PayOrder processes payment for an order and returns a transaction UUID. An
+// order is charged once: calls for an order that already has a payment, such
+// as retries after a lost answer, get the transaction of that payment.
 func (s *PaymentServiceImpl) PayOrder(ctx context.Context, req *paymentv1.PayOrderRequest) (*paymentv1.PayOrderResponse, error) {
-	log.Printf("Processing payment for order %s with method %s", req.OrderUuid, req.PaymentMethod)
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.OrderUuid))
+	log.Info("Processing payment", slog.String("payment_method", req.PaymentMethod.String()))
 
 	// Convert request to service model
 	payReq, err := converter.ToServicePayOrderRequest(req)
 	if err != nil {
-		log.Printf("Failed to convert payment request: %v", err)
-		return nil, err
+		log.Warn("Invalid payment request", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.OrderUuid)
 	}
 
 	// Validate payment method
 	if payReq.PaymentMethod == model.PaymentMethodUnknown {
-		log.Printf("Invalid payment method: %s", req.PaymentMethod)
+		log.Warn("Invalid payment method", slog.String("payment_method", req.PaymentMethod.String()))
 		return nil, model.NewInvalidPaymentMethodError(payReq.PaymentMethod)
 	}
 
 	// Validate amount (basic validation)
 	if payReq.Amount < 0 {
-		log.Printf("Invalid amount: %f", payReq.Amount)
+		log.Warn("Invalid amount", slog.Float64("amount", payReq.Amount))
 		return nil, model.NewInvalidAmountError(payReq.Amount)
 	}
 
+	s.payMu.Lock()
+	defer s.payMu.Unlock()
+
+	existing, err := s.paymentRepo.GetByOrderUUID(ctx, payReq.OrderUUID)
+	switch {
+	case err == nil:
+		log.Info("Order already paid", slog.String("transaction_uuid", existing.TransactionUUID.String()))
+		return converter.ToProtoPayOrderResponse(existing.TransactionUUID, true), nil
+	case !errors.Is(err, model.ErrPaymentNotFound):
+		log.Error("Failed to look up payment of order", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
 	// Generate transaction UUID
 	transactionUUID := uuid.New()
 	paymentUUID := uuid.New()
@@ -67,11 +90,107 @@ func (s *PaymentServiceImpl) PayOrder(ctx context.Context, req *paymentv1.PayOrd
 
 	// Save payment to repository
 	if err := s.paymentRepo.Create(ctx, payment); err != nil {
-		log.Printf("Failed to create payment: %v", err)
+		log.Error("Failed to create payment", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
+
+	log.Info("Payment succeeded", slog.String("transaction_uuid", transactionUUID.String()))
+
+	return converter.ToProtoPayOrderResponse(transactionUUID, false), nil
+}
+
+// RefundPayment returns the requested amount of a completed payment. Partial
+// refunds keep the payment refundable until the whole amount is returned.
+func (s *PaymentServiceImpl) RefundPayment(ctx context.Context, req *paymentv1.RefundPaymentRequest) (*paymentv1.RefundPaymentResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("transaction_uuid", req.TransactionUuid))
+	log.Info("Processing refund", slog.Float64("amount", req.Amount))
+
+	refundReq, err := converter.ToServiceRefundPaymentRequest(req)
+	if err != nil {
+		log.Warn("Invalid refund request", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("transaction_uuid", req.TransactionUuid)
+	}
+
+	if refundReq.Amount < 0 {
+		log.Warn("Invalid refund amount", slog.Float64("amount", refundReq.Amount))
+		return nil, model.NewInvalidAmountError(refundReq.Amount)
+	}
+
+	s.refundMu.Lock()
+	defer s.refundMu.Unlock()
+
+	payment, err := s.paymentRepo.GetByTransactionUUID(ctx, refundReq.TransactionUUID)
+	if err != nil {
+		log.Warn("Payment not found", logger.Err(err))
+		return nil, model.NewPaymentNotFoundError(refundReq.TransactionUUID.String())
+	}
+
+	if payment.Status != model.PaymentStatusCompleted && payment.Status != model.PaymentStatusPartiallyRefunded {
+		log.Warn("Payment cannot be refunded", slog.String("payment_uuid", payment.UUID.String()), slog.String("status", string(payment.Status)))
+		return nil, model.NewRefundNotAllowedError(payment.Status)
+	}
+
+	remaining := payment.RemainingAmount()
+	amount := model.RoundAmount(refundReq.Amount)
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount > remaining {
+		log.Warn("Refund exceeds remaining amount", slog.String("payment_uuid", payment.UUID.String()), slog.Float64("amount", amount), slog.Float64("remaining", remaining))
+		return nil, model.NewInvalidAmountError(amount)
+	}
+
+	now := time.Now()
+	refund := model.Refund{
+		UUID:      uuid.New(),
+		Amount:    amount,
+		Reason:    refundReq.Reason,
+		CreatedAt: now,
+	}
+
+	// Copy the refunds so the stored payment is not modified through a shared slice
+	refunds := make([]model.Refund, 0, len(payment.Refunds)+1)
+	payment.Refunds = append(append(refunds, payment.Refunds...), refund)
+	payment.RefundedAmount = model.RoundAmount(payment.RefundedAmount + amount)
+	payment.Status = model.PaymentStatusPartiallyRefunded
+	if payment.RemainingAmount() == 0 {
+		payment.Status = model.PaymentStatusRefunded
+	}
+	payment.UpdatedAt = now
+
+	if err := s.paymentRepo.Update(ctx, payment); err != nil {
+		log.Error("Failed to update payment", slog.String("payment_uuid", payment.UUID.String()), logger.Err(err))
 		return nil, model.NewInternalError(err)
 	}
 
-	log.Printf("Payment was successful, transaction_uuid: %s", transactionUUID)
+	log.Info("Refund succeeded",
+		slog.String("refund_uuid", refund.UUID.String()),
+		slog.String("payment_uuid", payment.UUID.String()),
+		slog.Float64("amount", amount),
+		slog.String("status", string(payment.Status)),
+	)
+
+	return converter.ToProtoRefundPaymentResponse(payment, refund.UUID), nil
+}
+
+// GetPayment returns the payment made for an order
+func (s *PaymentServiceImpl) GetPayment(ctx context.Context, req *paymentv1.GetPaymentRequest) (*paymentv1.GetPaymentResponse, error) {
+	log := logger.FromContext(ctx).With(slog.String("order_uuid", req.GetOrderUuid()))
+
+	orderUUID, err := uuid.Parse(req.GetOrderUuid())
+	if err != nil {
+		log.Warn("Invalid payment lookup", logger.Err(err))
+		return nil, model.NewInvalidUUIDError("order_uuid", req.GetOrderUuid())
+	}
+
+	payment, err := s.paymentRepo.GetByOrderUUID(ctx, orderUUID)
+	switch {
+	case errors.Is(err, model.ErrPaymentNotFound):
+		return nil, model.NewPaymentNotFoundError(orderUUID.String())
+	case err != nil:
+		log.Error("Failed to look up payment of order", logger.Err(err))
+		return nil, model.NewInternalError(err)
+	}
 
-	return converter.ToProtoPayOrderResponse(transactionUUID), nil
+	return converter.ToProtoGetPaymentResponse(payment), nil
 }
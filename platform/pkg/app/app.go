@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/closer"
+	"github.com/nimbodex/microservices-factory/platform/pkg/config"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// Config is the typed configuration of a service, loaded by Run before setup
+type Config interface {
+	// LogLevel is one of debug, info, warn or error
+	LogLevel() string
+}
+
+// Runner is a long running part of a service, such as a server or a
+// background worker. It runs until ctx is done and then stops gracefully;
+// returning an error before that shuts the whole service down.
+type Runner func(ctx context.Context) error
+
+type namedRunner struct {
+	name string
+	run  Runner
+}
+
+// App runs the components of a service and shuts them down together
+type App struct {
+	name            string
+	logger          *slog.Logger
+	logLevel        slog.Level
+	closer          *closer.Closer
+	runners         []namedRunner
+	beforeStop      []func()
+	shutdownTimeout time.Duration
+}
+
+// Option configures an App
+type Option func(*App)
+
+// WithLogLevel sets the minimum level of the logger created by New
+func WithLogLevel(level slog.Level) Option {
+	return func(a *App) {
+		a.logLevel = level
+	}
+}
+
+// WithLogger replaces the logger created by New
+func WithLogger(logger *slog.Logger) Option {
+	return func(a *App) {
+		a.logger = logger
+	}
+}
+
+// WithShutdownTimeout limits how long resources may take to close
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(a *App) {
+		a.shutdownTimeout = timeout
+	}
+}
+
+// New creates an app for the named service
+func New(name string, opts ...Option) *App {
+	a := &App{
+		name:            name,
+		closer:          closer.New(),
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if a.logger == nil {
+		a.logger = logger.New(os.Stdout, a.logLevel).With(slog.String("service", name))
+	}
+
+	return a
+}
+
+// Logger returns the logger of the service
+func (a *App) Logger() *slog.Logger {
+	return a.logger
+}
+
+// Go registers a runner started by Run
+func (a *App) Go(name string, run Runner) {
+	a.runners = append(a.runners, namedRunner{name: name, run: run})
+}
+
+// BeforeStop registers fn to run as soon as shutdown starts, before runners
+// are stopped, such as reporting the service as not serving so that no new
+// traffic is routed to it while in-flight requests finish
+func (a *App) BeforeStop(fn func()) {
+	a.beforeStop = append(a.beforeStop, fn)
+}
+
+// OnShutdown registers fn to release a resource once every runner has stopped.
+// Resources are released in the reverse order they were registered.
+func (a *App) OnShutdown(name string, fn closer.Func) {
+	a.closer.Add(name, fn)
+}
+
+// CloseOnShutdown registers a resource with a plain Close method
+func (a *App) CloseOnShutdown(name string, c interface{ Close() error }) {
+	a.closer.AddCloser(name, c)
+}
+
+// Run starts every runner and blocks until ctx is done, SIGINT or SIGTERM
+// arrives or a runner fails. Then it runs the BeforeStop hooks, stops the
+// runners, waits for them and releases the resources registered with
+// OnShutdown.
+func (a *App) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	// Runners log through the service logger unless a request brings its own.
+	// They are stopped by cancel only, so that BeforeStop hooks run first.
+	runCtx, cancel := context.WithCancel(logger.WithContext(context.WithoutCancel(ctx), a.logger))
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		runErr  error
+	)
+	for _, r := range a.runners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := r.run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+				a.logger.Error("Component failed", slog.String("component", r.name), logger.Err(err))
+				errOnce.Do(func() {
+					runErr = fmt.Errorf("%s: %w", r.name, err)
+				})
+				cancel()
+			}
+		}()
+	}
+
+	a.logger.Info("Service started")
+	select {
+	case <-ctx.Done():
+	case <-runCtx.Done():
+	}
+	a.logger.Info("Shutting down service")
+
+	for _, fn := range a.beforeStop {
+		fn()
+	}
+	cancel()
+	wg.Wait()
+
+	closeCtx, cancelClose := context.WithTimeout(context.WithoutCancel(ctx), a.shutdownTimeout)
+	defer cancelClose()
+
+	closeErr := a.closer.Close(closeCtx)
+	if closeErr != nil {
+		a.logger.Error("Failed to release resources", logger.Err(closeErr))
+	}
+
+	a.logger.Info("Service stopped")
+
+	return errors.Join(runErr, closeErr)
+}
+
+// Run is the entrypoint of a service: it loads cfg from the command line,
+// a YAML file and the environment, creates the app, lets setup wire the
+// components and runs them until shutdown. With --print-config it prints
+// the effective config instead. The process exits with status 2 if the
+// config is invalid and with status 1 if setup or any component fails.
+func Run(name string, cfg Config, setup func(ctx context.Context, a *App) error, opts ...Option) {
+	loaded, err := config.Load(name, cfg, os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		os.Exit(2)
+	}
+
+	if loaded.PrintConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The level was checked when the config was validated
+	level, _ := logger.ParseLevel(cfg.LogLevel())
+	a := New(name, append([]Option{WithLogLevel(level)}, opts...)...)
+
+	// Packages still using the standard log write through the same handler
+	slog.SetDefault(a.logger)
+
+	if loaded.File != "" {
+		a.logger.Info("Config loaded", slog.String("file", loaded.File))
+	}
+
+	ctx := logger.WithContext(context.Background(), a.logger)
+	if err := setup(ctx, a); err != nil {
+		a.logger.Error("Failed to set up service", logger.Err(err))
+		if closeErr := a.closer.Close(ctx); closeErr != nil {
+			a.logger.Error("Failed to release resources", logger.Err(closeErr))
+		}
+		os.Exit(1)
+	}
+
+	if err := a.Run(ctx); err != nil {
+		os.Exit(1)
+	}
+}
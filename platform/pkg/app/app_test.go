@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+
+	return New("test", WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+}
+
+func TestApp_RunStopsRunnersBetweenBeforeStopAndClosingResources(t *testing.T) {
+	a := newTestApp(t)
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	started := make(chan struct{})
+	a.Go("worker", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		record("worker stopped")
+		return ctx.Err()
+	})
+	a.BeforeStop(func() {
+		record("not serving")
+	})
+	a.OnShutdown("db", func(context.Context) error {
+		record("db closed")
+		return nil
+	})
+	a.OnShutdown("client", func(context.Context) error {
+		record("client closed")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx) }()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("app did not stop")
+	}
+
+	if want := []string{"not serving", "worker stopped", "client closed", "db closed"}; !reflect.DeepEqual(events, want) {
+		t.Fatalf("events %v, want %v", events, want)
+	}
+}
+
+func TestApp_FailingRunnerShutsDownTheRest(t *testing.T) {
+	a := newTestApp(t)
+	errBoom := errors.New("boom")
+
+	a.Go("failing", func(context.Context) error {
+		return errBoom
+	})
+	a.Go("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- a.Run(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("run error %v, want %v", err, errBoom)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("app did not stop after a runner failed")
+	}
+}
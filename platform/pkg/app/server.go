@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCServer returns a runner serving srv on addr until ctx is done, then
+// waiting for in-flight calls to finish
+func GRPCServer(addr string, srv *grpc.Server) Runner {
+	return func(ctx context.Context) error {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("listen on %s: %w", addr, err)
+		}
+
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			<-ctx.Done()
+			srv.GracefulStop()
+		}()
+
+		if err := srv.Serve(lis); err != nil {
+			return fmt.Errorf("serve gRPC on %s: %w", addr, err)
+		}
+		<-stopped
+
+		return nil
+	}
+}
+
+// HTTPServer returns a runner serving srv until ctx is done, then waiting
+// for in-flight requests to finish. Its Addr must be set.
+func HTTPServer(srv *http.Server) Runner {
+	return func(ctx context.Context) error {
+		lis, err := net.Listen("tcp", srv.Addr)
+		if err != nil {
+			return fmt.Errorf("listen on %s: %w", srv.Addr, err)
+		}
+
+		shutdownErr := make(chan error, 1)
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultShutdownTimeout)
+			defer cancel()
+			shutdownErr <- srv.Shutdown(shutdownCtx)
+		}()
+
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serve HTTP on %s: %w", srv.Addr, err)
+		}
+
+		if err := <-shutdownErr; err != nil {
+			return fmt.Errorf("shut down HTTP server on %s: %w", srv.Addr, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,177 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of calling a dependency whose breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the state of a breaker
+type State int
+
+const (
+	// StateClosed lets every call through
+	StateClosed State = iota
+	// StateOpen rejects every call until the open timeout passes
+	StateOpen
+	// StateHalfOpen lets a single trial call through to decide whether to close again
+	StateHalfOpen
+)
+
+// String returns the name of the state used in logs
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Outcome is what a call let through tells about the dependency
+type Outcome int
+
+const (
+	// Success means the dependency handled the call
+	Success Outcome = iota
+	// Failure means the dependency could not handle the call
+	Failure
+	// Ignored means the call tells nothing about the dependency, e.g. the
+	// caller gave up on it; the state is left as is
+	Ignored
+)
+
+// Config configures a breaker
+type Config struct {
+	// FailureThreshold is how many consecutive failures open the breaker
+	FailureThreshold int
+	// OpenTimeout is how long the breaker rejects calls before a trial call is let through
+	OpenTimeout time.Duration
+	// OnStateChange is called after every state change when set; it must not block
+	OnStateChange func(from, to State)
+}
+
+// Breaker stops calls to a dependency that keeps failing, so that callers
+// fail fast instead of waiting on it, and lets a trial call through once in a
+// while to find out whether it has recovered
+type Breaker struct {
+	cfg Config
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// generation changes with every state change, so that outcomes of calls
+	// let through in an earlier state are ignored
+	generation uint64
+	// probing is set while the trial call of the half-open state is in flight
+	probing bool
+}
+
+// New creates a closed breaker
+func New(cfg Config) *Breaker {
+	return &Breaker{
+		cfg: cfg,
+		now: time.Now,
+	}
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow returns ErrOpen when the call must not be made. Otherwise the call
+// may proceed and its outcome must be reported with done.
+func (b *Breaker) Allow() (done func(outcome Outcome), err error) {
+	b.mu.Lock()
+	from := b.state
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			b.mu.Unlock()
+			return nil, ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return nil, ErrOpen
+		}
+		b.probing = true
+	}
+
+	to, generation := b.state, b.generation
+	b.mu.Unlock()
+	b.notify(from, to)
+
+	return func(outcome Outcome) {
+		b.record(generation, outcome)
+	}, nil
+}
+
+func (b *Breaker) record(generation uint64, outcome Outcome) {
+	b.mu.Lock()
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	from := b.state
+
+	switch {
+	case outcome == Ignored:
+		// Frees the trial call slot so that the next call probes instead
+	case outcome == Success:
+		b.failures = 0
+		b.setState(StateClosed)
+	case b.state == StateHalfOpen:
+		b.trip()
+	default:
+		b.failures++
+		if b.failures >= b.cfg.FailureThreshold {
+			b.trip()
+		}
+	}
+	b.probing = false
+
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// trip opens the breaker; b.mu must be held
+func (b *Breaker) trip() {
+	b.failures = 0
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+// setState moves the breaker to state; b.mu must be held
+func (b *Breaker) setState(state State) {
+	if b.state != state {
+		b.state = state
+		b.generation++
+	}
+}
+
+func (b *Breaker) notify(from, to State) {
+	if from != to && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, to)
+	}
+}
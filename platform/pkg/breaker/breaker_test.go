@@ -0,0 +1,151 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newBreaker(t *testing.T) (*Breaker, *clock, *[]string) {
+	t.Helper()
+
+	var changes []string
+	c := &clock{now: time.Unix(0, 0)}
+	b := New(Config{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		OnStateChange: func(from, to State) {
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	})
+	b.now = func() time.Time { return c.now }
+
+	return b, c, &changes
+}
+
+func call(t *testing.T, b *Breaker, outcome Outcome) {
+	t.Helper()
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("call rejected in state %s: %v", b.State(), err)
+	}
+	done(outcome)
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _, changes := newBreaker(t)
+
+	call(t, b, Failure)
+	call(t, b, Success)
+	call(t, b, Failure)
+	if b.State() != StateClosed {
+		t.Fatalf("a success must reset failures, state is %s", b.State())
+	}
+
+	call(t, b, Failure)
+	if b.State() != StateOpen {
+		t.Fatalf("expected open after 2 consecutive failures, state is %s", b.State())
+	}
+
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("open breaker must reject calls, got %v", err)
+	}
+	if len(*changes) != 1 || (*changes)[0] != "closed->open" {
+		t.Fatalf("unexpected state changes %v", *changes)
+	}
+}
+
+func TestBreaker_LetsOneTrialCallThroughAfterOpenTimeout(t *testing.T) {
+	b, c, changes := newBreaker(t)
+	call(t, b, Failure)
+	call(t, b, Failure)
+
+	c.advance(time.Second)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open after the open timeout, state is %s", b.State())
+	}
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("trial call rejected: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("only one trial call may be in flight, got %v", err)
+	}
+
+	done(Success)
+	if b.State() != StateClosed {
+		t.Fatalf("successful trial must close the breaker, state is %s", b.State())
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(*changes) != len(want) {
+		t.Fatalf("state changes %v, want %v", *changes, want)
+	}
+	for i := range want {
+		if (*changes)[i] != want[i] {
+			t.Fatalf("state changes %v, want %v", *changes, want)
+		}
+	}
+}
+
+func TestBreaker_FailedTrialReopens(t *testing.T) {
+	b, c, _ := newBreaker(t)
+	call(t, b, Failure)
+	call(t, b, Failure)
+
+	c.advance(time.Second)
+	call(t, b, Failure)
+	if b.State() != StateOpen {
+		t.Fatalf("failed trial must reopen the breaker, state is %s", b.State())
+	}
+
+	c.advance(time.Second / 2)
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("reopened breaker must wait a full open timeout, got %v", err)
+	}
+}
+
+func TestBreaker_IgnoresOutcomesOfCallsFromEarlierState(t *testing.T) {
+	b, _, _ := newBreaker(t)
+
+	slow, err := b.Allow()
+	if err != nil {
+		t.Fatalf("call rejected: %v", err)
+	}
+	call(t, b, Failure)
+	call(t, b, Failure)
+
+	// A call that started before the breaker opened does not close it
+	slow(Success)
+	if b.State() != StateOpen {
+		t.Fatalf("stale success must be ignored, state is %s", b.State())
+	}
+}
+
+func TestBreaker_IgnoredTrialFreesSlotWithoutClosing(t *testing.T) {
+	b, c, _ := newBreaker(t)
+	call(t, b, Failure)
+	call(t, b, Failure)
+	c.advance(time.Second)
+
+	// The caller of the trial call hung up before the dependency answered
+	call(t, b, Ignored)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("ignored trial must not change the state, state is %s", b.State())
+	}
+
+	call(t, b, Success)
+	if b.State() != StateClosed {
+		t.Fatalf("the next trial must decide the state, state is %s", b.State())
+	}
+}
@@ -0,0 +1,41 @@
+package broker
+
+import (
+	"context"
+)
+
+// Headers set on every domain event message. Consumers use HeaderEventID to
+// drop duplicates, since events are delivered at least once.
+const (
+	HeaderEventID      = "event-id"
+	HeaderEventType    = "event-type"
+	HeaderEventVersion = "event-version"
+)
+
+// Message is a record sent through a broker. Messages with the same Key land
+// in the same partition, so they are delivered in the order they were published.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher sends messages to a broker
+type Publisher interface {
+	// Publish returns once all messages are acknowledged by the broker. On error
+	// some of the messages may have been sent, so callers retry the whole batch.
+	Publish(ctx context.Context, msgs ...Message) error
+}
+
+// Handler processes a delivered message. Returning an error makes the message
+// delivered again, so handlers return nil for messages they can never process.
+type Handler func(ctx context.Context, msg Message) error
+
+// Subscriber delivers messages of a topic to a handler
+type Subscriber interface {
+	// Subscribe calls handler for every message of topic, one at a time and in
+	// order, until ctx is cancelled; it returns nil on cancellation. A message
+	// is acknowledged only after handler succeeds, so delivery is at least once.
+	Subscribe(ctx context.Context, topic string, handler Handler) error
+}
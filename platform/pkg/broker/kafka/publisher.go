@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+)
+
+// Publisher implements broker.Publisher on top of Kafka. Messages are
+// partitioned by key and acknowledged by all in-sync replicas.
+type Publisher struct {
+	writer *kafka.Writer
+}
+
+// NewPublisher creates a new Kafka publisher for the given bootstrap brokers
+func NewPublisher(brokers []string) *Publisher {
+	return &Publisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// Publish writes the messages and waits for them to be acknowledged
+func (p *Publisher) Publish(ctx context.Context, msgs ...broker.Message) error {
+	kafkaMsgs := make([]kafka.Message, len(msgs))
+	for i, msg := range msgs {
+		headers := make([]kafka.Header, 0, len(msg.Headers))
+		for k, v := range msg.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+
+		kafkaMsgs[i] = kafka.Message{
+			Topic:   msg.Topic,
+			Key:     msg.Key,
+			Value:   msg.Value,
+			Headers: headers,
+		}
+	}
+
+	if err := p.writer.WriteMessages(ctx, kafkaMsgs...); err != nil {
+		return fmt.Errorf("write messages to kafka: %w", err)
+	}
+
+	return nil
+}
+
+// Close flushes pending writes and closes connections to the brokers
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/broker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// redeliveryDelay is how long the subscriber waits before handing a message
+// to its handler again after a failure
+const redeliveryDelay = time.Second
+
+// Subscriber implements broker.Subscriber on top of a Kafka consumer group.
+// Offsets are committed only after the handler succeeds.
+type Subscriber struct {
+	brokers []string
+	groupID string
+}
+
+// NewSubscriber creates a new Kafka subscriber consuming as groupID
+func NewSubscriber(brokers []string, groupID string) *Subscriber {
+	return &Subscriber{
+		brokers: brokers,
+		groupID: groupID,
+	}
+}
+
+// Subscribe consumes topic until ctx is cancelled. A failed message is retried
+// before the next one is fetched, so messages of a partition stay in order.
+func (s *Subscriber) Subscribe(ctx context.Context, topic string, handler broker.Handler) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     s.brokers,
+		GroupID:     s.groupID,
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.FromContext(ctx).Error("Failed to close kafka reader", slog.String("topic", topic), logger.Err(err))
+		}
+	}()
+
+	for {
+		kafkaMsg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("fetch message from %s: %w", topic, err)
+		}
+
+		msg := toBrokerMessage(kafkaMsg)
+		for {
+			err := handler(ctx, msg)
+			if err == nil {
+				break
+			}
+
+			logger.FromContext(ctx).Warn("Failed to handle message, retrying",
+				slog.String("topic", topic),
+				slog.Int("partition", kafkaMsg.Partition),
+				slog.Int64("offset", kafkaMsg.Offset),
+				logger.Err(err),
+			)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(redeliveryDelay):
+			}
+		}
+
+		if err := reader.CommitMessages(ctx, kafkaMsg); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return fmt.Errorf("commit message of %s: %w", topic, err)
+		}
+	}
+}
+
+// toBrokerMessage converts a consumed Kafka message to a broker message
+func toBrokerMessage(kafkaMsg kafka.Message) broker.Message {
+	headers := make(map[string]string, len(kafkaMsg.Headers))
+	for _, header := range kafkaMsg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+
+	return broker.Message{
+		Topic:   kafkaMsg.Topic,
+		Key:     kafkaMsg.Key,
+		Value:   kafkaMsg.Value,
+		Headers: headers,
+	}
+}
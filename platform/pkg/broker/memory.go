@@ -0,0 +1,118 @@
+package broker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// redeliveryDelay is how long MemoryBroker waits before delivering a message
+// again after its handler failed
+const redeliveryDelay = 100 * time.Millisecond
+
+// MemoryBroker keeps published messages in memory. It is meant for tests and
+// local runs where no real broker is available. Every subscription reads its
+// topic from the first message, like a new consumer group.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	messages map[string][]Message
+	// published is closed and replaced on every publish to wake up subscribers
+	published chan struct{}
+}
+
+// NewMemoryBroker creates a new in-memory broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		messages:  make(map[string][]Message),
+		published: make(chan struct{}),
+	}
+}
+
+// Publish stores the messages under their topics
+func (b *MemoryBroker) Publish(ctx context.Context, msgs ...Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		b.messages[msg.Topic] = append(b.messages[msg.Topic], cloneMessage(msg))
+	}
+
+	close(b.published)
+	b.published = make(chan struct{})
+
+	return nil
+}
+
+// Subscribe delivers messages of topic to handler until ctx is cancelled
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic string, handler Handler) error {
+	offset := 0
+	for {
+		b.mu.RLock()
+		pending := b.messages[topic][offset:]
+		published := b.published
+		b.mu.RUnlock()
+
+		for _, msg := range pending {
+			if !deliver(ctx, handler, msg) {
+				return nil
+			}
+			offset++
+		}
+
+		if len(pending) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-published:
+			}
+		}
+	}
+}
+
+// Messages returns the messages published to topic, oldest first
+func (b *MemoryBroker) Messages(topic string) []Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	msgs := make([]Message, len(b.messages[topic]))
+	for i, msg := range b.messages[topic] {
+		msgs[i] = cloneMessage(msg)
+	}
+
+	return msgs
+}
+
+// deliver calls handler until it succeeds; it returns false if ctx is
+// cancelled first
+func deliver(ctx context.Context, handler Handler, msg Message) bool {
+	for {
+		if err := handler(ctx, cloneMessage(msg)); err == nil {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(redeliveryDelay):
+		}
+	}
+}
+
+// cloneMessage copies a message so callers cannot modify stored ones
+func cloneMessage(msg Message) Message {
+	headers := make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+
+	return Message{
+		Topic:   msg.Topic,
+		Key:     append([]byte(nil), msg.Key...),
+		Value:   append([]byte(nil), msg.Value...),
+		Headers: headers,
+	}
+}
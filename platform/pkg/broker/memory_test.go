@@ -0,0 +1,57 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryBroker_SubscribeDeliversInOrderAndRetries(t *testing.T) {
+	b := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := b.Publish(ctx, Message{Topic: "events", Value: []byte("first")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	received := make(chan string, 10)
+	failed := false
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Subscribe(ctx, "events", func(_ context.Context, msg Message) error {
+			if string(msg.Value) == "second" && !failed {
+				failed = true
+				return errors.New("temporary failure")
+			}
+			received <- string(msg.Value)
+			return nil
+		})
+	}()
+
+	if err := b.Publish(ctx, Message{Topic: "events", Value: []byte("second")}, Message{Topic: "other", Value: []byte("skipped")}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	for _, want := range []string{"first", "second"} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Fatalf("got message %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message %q was not delivered", want)
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("subscribe returned %v after cancellation", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscribe did not stop after the context was cancelled")
+	}
+}
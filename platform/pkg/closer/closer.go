@@ -0,0 +1,64 @@
+package closer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Func releases a resource, giving up when ctx is done
+type Func func(ctx context.Context) error
+
+type entry struct {
+	name string
+	fn   Func
+}
+
+// Closer collects resources to release on shutdown. They are released in the
+// reverse order they were added, so a resource is released before the ones
+// it was built on.
+type Closer struct {
+	mu      sync.Mutex
+	entries []entry
+}
+
+// New creates an empty closer
+func New() *Closer {
+	return &Closer{}
+}
+
+// Add registers fn to run on Close; name identifies it in errors
+func (c *Closer) Add(name string, fn Func) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, entry{name: name, fn: fn})
+}
+
+// AddCloser registers a resource with a plain Close method, such as a
+// connection or a client
+func (c *Closer) AddCloser(name string, closer interface{ Close() error }) {
+	c.Add(name, func(context.Context) error {
+		return closer.Close()
+	})
+}
+
+// Close releases every registered resource, newest first. All of them are
+// released even if some fail; the failures are joined into the error.
+// Resources are released once, later calls do nothing.
+func (c *Closer) Close(ctx context.Context) error {
+	c.mu.Lock()
+	entries := c.entries
+	c.entries = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(entries) - 1; i >= 0; i-- {
+		if err := entries[i].fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", entries[i].name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package closer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCloser_ClosesInReverseOrderOnce(t *testing.T) {
+	c := New()
+
+	var closed []string
+	for _, name := range []string{"db", "client", "server"} {
+		c.Add(name, func(context.Context) error {
+			closed = append(closed, name)
+			return nil
+		})
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if want := []string{"server", "client", "db"}; !reflect.DeepEqual(closed, want) {
+		t.Fatalf("closed %v, want %v", closed, want)
+	}
+
+	if err := c.Close(context.Background()); err != nil {
+		t.Fatalf("second close: %v", err)
+	}
+	if len(closed) != 3 {
+		t.Fatalf("resources closed again: %v", closed)
+	}
+}
+
+func TestCloser_JoinsErrorsAndKeepsClosing(t *testing.T) {
+	c := New()
+	errDB := errors.New("db busy")
+
+	dbClosed := false
+	c.Add("db", func(context.Context) error {
+		dbClosed = true
+		return errDB
+	})
+	c.Add("client", func(context.Context) error {
+		return errors.New("client gone")
+	})
+
+	err := c.Close(context.Background())
+
+	if !dbClosed {
+		t.Fatal("db was not closed after client failed")
+	}
+	if !errors.Is(err, errDB) {
+		t.Fatalf("error %v does not wrap %v", err, errDB)
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Addr     string        `yaml:"addr" env:"TEST_ADDR"`
+	Timeout  time.Duration `yaml:"timeout" env:"TEST_TIMEOUT"`
+	Workers  int           `yaml:"workers" env:"TEST_WORKERS"`
+	Brokers  []string      `yaml:"brokers" env:"TEST_BROKERS"`
+	Database struct {
+		DSN string `yaml:"dsn" env:"TEST_DSN" secret:"true"`
+	} `yaml:"database"`
+}
+
+func (c *testConfig) Validate() error {
+	return Positive("workers", c.Workers)
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_EnvOverridesFileOverridesDefaults(t *testing.T) {
+	path := writeFile(t, "addr: file:1\ntimeout: 3s\nworkers: 2\n")
+	t.Setenv("TEST_TIMEOUT", "5s")
+	t.Setenv("TEST_BROKERS", "a:9092, b:9092,")
+
+	cfg := &testConfig{Addr: "default:1", Workers: 1}
+	opts, err := Load("test", cfg, []string{"--config", path})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if opts.File != path || opts.PrintConfig {
+		t.Errorf("options = %+v", opts)
+	}
+	if cfg.Addr != "file:1" {
+		t.Errorf("addr = %q, want file value", cfg.Addr)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("timeout = %s, want env value", cfg.Timeout)
+	}
+	if cfg.Workers != 2 {
+		t.Errorf("workers = %d, want file value", cfg.Workers)
+	}
+	if want := []string{"a:9092", "b:9092"}; !reflect.DeepEqual(cfg.Brokers, want) {
+		t.Errorf("brokers = %v, want %v", cfg.Brokers, want)
+	}
+}
+
+func TestLoad_FileFromEnv(t *testing.T) {
+	t.Setenv(FileEnv, writeFile(t, "workers: 7\n"))
+
+	cfg := &testConfig{Workers: 1}
+	if _, err := Load("test", cfg, nil); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if cfg.Workers != 7 {
+		t.Errorf("workers = %d, want 7", cfg.Workers)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "unknown field", file: "workerz: 2\n", wantErr: "workerz"},
+		{name: "bad env value", env: map[string]string{"TEST_TIMEOUT": "soon"}, wantErr: "TEST_TIMEOUT"},
+		{name: "validation", env: map[string]string{"TEST_WORKERS": "0"}, wantErr: "workers must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			var args []string
+			if tt.file != "" {
+				args = []string{"--config", writeFile(t, tt.file)}
+			}
+
+			_, err := Load("test", &testConfig{Workers: 1}, args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg := &testConfig{Addr: "localhost:1", Timeout: time.Second}
+	cfg.Database.DSN = "postgres://order:s3cret@db/order"
+
+	var out strings.Builder
+	if err := Print(&out, cfg); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+
+	if strings.Contains(out.String(), "s3cret") {
+		t.Errorf("secret printed:\n%s", out.String())
+	}
+	for _, want := range []string{"addr: localhost:1", "timeout: 1s", "dsn: '[REDACTED]'"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output misses %q:\n%s", want, out.String())
+		}
+	}
+	if cfg.Database.DSN != "postgres://order:s3cret@db/order" {
+		t.Errorf("print changed the config")
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// Log configures the service logger
+type Log struct {
+	// Level is one of debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+// Validate checks the log level
+func (l Log) Validate() error {
+	if _, err := logger.ParseLevel(l.Level); err != nil {
+		return fmt.Errorf("log.level: %w", err)
+	}
+	return nil
+}
+
+// Required reports an empty value of the named setting
+func Required(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	return nil
+}
+
+// Positive reports a zero or negative value of the named setting
+func Positive[T int | time.Duration](name string, value T) error {
+	if value <= 0 {
+		return fmt.Errorf("%s must be positive, got %v", name, value)
+	}
+	return nil
+}
+
+// OneOf reports a value of the named setting outside allowed
+func OneOf(name, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of %v, got %q", name, allowed, value)
+}
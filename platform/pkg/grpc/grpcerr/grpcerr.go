@@ -0,0 +1,155 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// internalMessage replaces messages of server faults so that internals do not leak
+const internalMessage = "internal server error"
+
+// Coded is an error with a machine-readable code, such as INVALID_AMOUNT.
+// Service errors implement it to be sent over gRPC by a Mapper.
+type Coded interface {
+	error
+	// ErrorCode returns the machine-readable code
+	ErrorCode() string
+	// ErrorMessage returns the message meant for the caller
+	ErrorMessage() string
+}
+
+// FieldError is a Coded error caused by a field of the request
+type FieldError interface {
+	Coded
+	// ErrorField returns the path of the offending field, such as items[0].quantity
+	ErrorField() string
+}
+
+// Mapper turns errors of one service into gRPC status errors
+type Mapper struct {
+	domain string
+	codes  map[string]codes.Code
+}
+
+// NewMapper returns a Mapper for the service named domain. statusCodes maps
+// error codes to the status codes they are sent with; unlisted codes are
+// sent as Internal.
+func NewMapper(domain string, statusCodes map[string]codes.Code) *Mapper {
+	return &Mapper{
+		domain: domain,
+		codes:  statusCodes,
+	}
+}
+
+// Error returns err as a gRPC status error. A Coded error gets the status
+// code of its code with an ErrorInfo detail whose reason is the code, plus a
+// BadRequest detail naming the offending field of a FieldError. Errors that
+// already are statuses are returned as they are, and anything else is
+// logged and sent as Internal.
+func (m *Mapper) Error(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var coded Coded
+	if !errors.As(err, &coded) {
+		logger.FromContext(ctx).Error("Unexpected error", logger.Err(err))
+		return status.Error(codes.Internal, internalMessage)
+	}
+
+	code, ok := m.codes[coded.ErrorCode()]
+	if !ok {
+		code = codes.Internal
+	}
+
+	message := coded.ErrorMessage()
+	if code == codes.Internal {
+		logger.FromContext(ctx).Error("Request failed", slog.String("error_code", coded.ErrorCode()), logger.Err(err))
+		message = internalMessage
+	}
+
+	st, detailsErr := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: coded.ErrorCode(),
+		Domain: m.domain,
+	})
+	if detailsErr != nil {
+		return status.Error(code, message)
+	}
+
+	var fieldErr FieldError
+	if errors.As(err, &fieldErr) && fieldErr.ErrorField() != "" {
+		withField, detailsErr := st.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       fieldErr.ErrorField(),
+				Description: message,
+			}},
+		})
+		if detailsErr == nil {
+			st = withField
+		}
+	}
+
+	return st.Err()
+}
+
+// FieldViolation is a request field the server rejected
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Details is what a gRPC error tells about its cause
+type Details struct {
+	Code    codes.Code
+	Message string
+	// Reason is the machine-readable error code; empty if the server sent none
+	Reason string
+	// Domain is the service that gave the reason
+	Domain          string
+	FieldViolations []FieldViolation
+}
+
+// Decode returns the details of err sent by a server. Errors that are not
+// statuses decode as Unknown.
+func Decode(err error) Details {
+	st := status.Convert(err)
+
+	details := Details{
+		Code:    st.Code(),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			details.Reason, details.Domain = detail.GetReason(), detail.GetDomain()
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				details.FieldViolations = append(details.FieldViolations, FieldViolation{
+					Field:       violation.GetField(),
+					Description: violation.GetDescription(),
+				})
+			}
+		}
+	}
+
+	return details
+}
+
+// Reason returns the machine-readable error code sent with err, or "" if
+// there is none
+func Reason(err error) string {
+	return Decode(err).Reason
+}
@@ -0,0 +1,102 @@
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceError is a service error as services define it
+type serviceError struct {
+	code, message, field string
+	err                  error
+}
+
+func (e *serviceError) Error() string        { return e.code + ": " + e.message }
+func (e *serviceError) Unwrap() error        { return e.err }
+func (e *serviceError) ErrorCode() string    { return e.code }
+func (e *serviceError) ErrorMessage() string { return e.message }
+func (e *serviceError) ErrorField() string   { return e.field }
+
+var mapper = NewMapper("payment", map[string]codes.Code{
+	"INVALID_AMOUNT":    codes.InvalidArgument,
+	"PAYMENT_NOT_FOUND": codes.NotFound,
+	"INTERNAL_ERROR":    codes.Internal,
+})
+
+func TestMapper_SendsCodeAndFieldAsDetails(t *testing.T) {
+	err := mapper.Error(context.Background(), fmt.Errorf("refund: %w", &serviceError{code: "INVALID_AMOUNT", message: "invalid amount: -1", field: "amount"}))
+
+	got := Decode(err)
+	want := Details{
+		Code:            codes.InvalidArgument,
+		Message:         "invalid amount: -1",
+		Reason:          "INVALID_AMOUNT",
+		Domain:          "payment",
+		FieldViolations: []FieldViolation{{Field: "amount", Description: "invalid amount: -1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMapper_OmitsBadRequestWithoutField(t *testing.T) {
+	err := mapper.Error(context.Background(), &serviceError{code: "PAYMENT_NOT_FOUND", message: "payment not found"})
+
+	got := Decode(err)
+	if got.Code != codes.NotFound || got.Reason != "PAYMENT_NOT_FOUND" || got.FieldViolations != nil {
+		t.Fatalf("got %+v, want NotFound with reason and no field violations", got)
+	}
+}
+
+func TestMapper_HidesServerFaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+	}{
+		{name: "internal code", err: &serviceError{code: "INTERNAL_ERROR", message: "db is down", err: errors.New("dial tcp")}, wantReason: "INTERNAL_ERROR"},
+		{name: "unlisted code", err: &serviceError{code: "PAYMENT_FAILED", message: "card declined by acquirer 42"}, wantReason: "PAYMENT_FAILED"},
+		{name: "not coded", err: errors.New("dial tcp: connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Decode(mapper.Error(context.Background(), tt.err))
+
+			if got.Code != codes.Internal || got.Message != internalMessage || got.Reason != tt.wantReason {
+				t.Fatalf("got %+v, want Internal %q with reason %q", got, internalMessage, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMapper_PassesStatusesThrough(t *testing.T) {
+	statusErr := status.Error(codes.Unauthenticated, "session is invalid or expired")
+
+	if err := mapper.Error(context.Background(), statusErr); err != statusErr {
+		t.Fatalf("got %v, want the status error unchanged", err)
+	}
+	if code := status.Code(mapper.Error(context.Background(), context.Canceled)); code != codes.Canceled {
+		t.Fatalf("got %s for a cancelled call, want Canceled", code)
+	}
+	if err := mapper.Error(context.Background(), nil); err != nil {
+		t.Fatalf("got %v for nil, want nil", err)
+	}
+}
+
+func TestDecode_ErrorWithoutDetails(t *testing.T) {
+	got := Decode(status.Error(codes.NotFound, "part not found"))
+
+	if got.Code != codes.NotFound || got.Message != "part not found" || got.Reason != "" || got.FieldViolations != nil {
+		t.Fatalf("got %+v, want NotFound without details", got)
+	}
+	if Reason(errors.New("not a status")) != "" {
+		t.Fatal("expected no reason for an error that is not a status")
+	}
+}
@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+)
+
+// openError rejects a call because of an open breaker. It has the Unavailable
+// code for gRPC and is breaker.ErrOpen for errors.Is.
+type openError struct {
+	target string
+}
+
+func (e *openError) Error() string {
+	return fmt.Sprintf("%s: %v", e.target, breaker.ErrOpen)
+}
+
+func (e *openError) Unwrap() error {
+	return breaker.ErrOpen
+}
+
+func (e *openError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+// UnaryClientBreaker passes calls through b. Only failures telling that the
+// upstream is down or overloaded count against it; errors the upstream
+// answers with deliberately count as successes. Calls the caller gave up on
+// tell nothing about the upstream and leave the breaker as it is.
+func UnaryClientBreaker(b *breaker.Breaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		done, err := b.Allow()
+		if err != nil {
+			return &openError{target: cc.Target()}
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		done(outcome(ctx, err))
+
+		return err
+	}
+}
+
+// outcome tells what err of a call made with ctx says about the upstream
+func outcome(ctx context.Context, err error) breaker.Outcome {
+	if ctx.Err() != nil || status.Code(err) == codes.Canceled {
+		return breaker.Ignored
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return breaker.Failure
+	default:
+		return breaker.Success
+	}
+}
@@ -0,0 +1,103 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	"github.com/nimbodex/microservices-factory/platform/pkg/requestid"
+)
+
+// UnaryClientRequestID passes the request ID of the context on in metadata
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id, ok := requestid.FromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryClientLogging logs failed outgoing calls with the logger of the call
+// context; successful ones are logged at debug level
+func UnaryClientLogging() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		attrs := []slog.Attr{
+			slog.String("method", method),
+			slog.String("target", cc.Target()),
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		level := slog.LevelDebug
+		if err != nil {
+			level = slog.LevelWarn
+			attrs = append(attrs, logger.Err(err))
+		}
+		logger.FromContext(ctx).LogAttrs(ctx, level, "gRPC call made", attrs...)
+
+		return err
+	}
+}
+
+// UnaryClientTimeout bounds every attempt of a call by the timeout of its
+// method in timeouts, or by fallback for other methods. An earlier deadline of
+// the context is kept; a zero timeout leaves the attempt unbounded.
+func UnaryClientTimeout(fallback time.Duration, timeouts map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		timeout, ok := timeouts[method]
+		if !ok {
+			timeout = fallback
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// CallPolicy configures how a client calls its upstream
+type CallPolicy struct {
+	// Timeout bounds every attempt of a call; MethodTimeouts overrides it by
+	// full method name
+	Timeout        time.Duration
+	MethodTimeouts map[string]time.Duration
+	// Retry is applied to the methods it lists
+	Retry RetryPolicy
+	// Breaker rejects calls while the upstream keeps failing; nil disables it.
+	// It must not be shared with clients of other upstreams.
+	Breaker *breaker.Breaker
+}
+
+// DialOptions returns the interceptors every client installs. Calls are traced
+// and pass the trace context on; failed calls are logged once, after retries.
+func DialOptions(policy CallPolicy) []grpc.DialOption {
+	interceptors := []grpc.UnaryClientInterceptor{
+		UnaryClientRequestID(),
+		UnaryClientLogging(),
+		UnaryClientRetry(policy.Retry),
+	}
+	// Every attempt passes the breaker, so retries stop as soon as it opens
+	if policy.Breaker != nil {
+		interceptors = append(interceptors, UnaryClientBreaker(policy.Breaker))
+	}
+	interceptors = append(interceptors, UnaryClientTimeout(policy.Timeout, policy.MethodTimeouts))
+
+	return []grpc.DialOption{
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}
+}
@@ -0,0 +1,179 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+)
+
+const (
+	readMethod  = "/test.v1.Service/Get"
+	writeMethod = "/test.v1.Service/Pay"
+)
+
+// failingInvoker fails the first failures calls with code and counts all calls
+func failingInvoker(failures int, code codes.Code, calls *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(code, "failed")
+		}
+		return nil
+	}
+}
+
+func newConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///upstream", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestUnaryClientTimeout_UsesTimeoutOfMethod(t *testing.T) {
+	interceptor := UnaryClientTimeout(time.Minute, map[string]time.Duration{readMethod: time.Second})
+
+	for method, want := range map[string]time.Duration{readMethod: time.Second, writeMethod: time.Minute} {
+		var got time.Duration
+		invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			deadline, _ := ctx.Deadline()
+			got = time.Until(deadline)
+			return nil
+		}
+
+		if err := interceptor(context.Background(), method, nil, nil, nil, invoker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got > want || got < want-time.Second/2 {
+			t.Errorf("%s: deadline in %s, want about %s", method, got, want)
+		}
+	}
+}
+
+func TestUnaryClientRetry_RetriesListedMethodsOnTransientErrors(t *testing.T) {
+	interceptor := UnaryClientRetry(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Methods:        []string{readMethod},
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		code      codes.Code
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{name: "recovers", method: readMethod, code: codes.Unavailable, wantCalls: 3, wantCode: codes.OK},
+		{name: "not idempotent", method: writeMethod, code: codes.Unavailable, wantCalls: 1, wantCode: codes.Unavailable},
+		{name: "not transient", method: readMethod, code: codes.NotFound, wantCalls: 1, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, failingInvoker(2, tt.code, &calls))
+
+			if calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("got code %s, want %s", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestUnaryClientRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	interceptor := UnaryClientRetry(RetryPolicy{MaxAttempts: 2, Methods: []string{readMethod}})
+
+	calls := 0
+	err := interceptor(context.Background(), readMethod, nil, nil, nil, failingInvoker(5, codes.DeadlineExceeded, &calls))
+
+	if calls != 2 || status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("got %d calls and %v, want 2 calls failing with DeadlineExceeded", calls, err)
+	}
+}
+
+func TestUnaryClientBreaker_RejectsCallsWhileOpen(t *testing.T) {
+	conn := newConn(t)
+	b := breaker.New(breaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute})
+	interceptor := UnaryClientBreaker(b)
+
+	// Errors answered by the upstream do not open the breaker
+	calls := 0
+	for range 2 {
+		_ = interceptor(context.Background(), readMethod, nil, nil, conn, failingInvoker(5, codes.NotFound, &calls))
+	}
+	if b.State() != breaker.StateClosed {
+		t.Fatalf("expected closed breaker, state is %s", b.State())
+	}
+
+	calls = 0
+	for range 2 {
+		_ = interceptor(context.Background(), readMethod, nil, nil, conn, failingInvoker(5, codes.Unavailable, &calls))
+	}
+
+	err := interceptor(context.Background(), readMethod, nil, nil, conn, failingInvoker(5, codes.Unavailable, &calls))
+	if calls != 2 {
+		t.Fatalf("open breaker must not call the upstream, got %d calls", calls)
+	}
+	if !errors.Is(err, breaker.ErrOpen) || status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable breaker.ErrOpen, got %v", err)
+	}
+
+	// A retry would fail the same way, so the open breaker is not retried
+	retry := UnaryClientRetry(RetryPolicy{MaxAttempts: 3, Methods: []string{readMethod}})
+	calls = 0
+	_ = retry(context.Background(), readMethod, nil, nil, conn, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return interceptor(ctx, method, req, reply, cc, failingInvoker(0, codes.OK, new(int)))
+	})
+	if calls != 1 {
+		t.Fatalf("calls rejected by the breaker must not be retried, got %d attempts", calls)
+	}
+}
+
+func TestUnaryClientBreaker_CancelledTrialDoesNotClose(t *testing.T) {
+	conn := newConn(t)
+	b := breaker.New(breaker.Config{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+	interceptor := UnaryClientBreaker(b)
+
+	_ = interceptor(context.Background(), readMethod, nil, nil, conn, failingInvoker(1, codes.Unavailable, new(int)))
+	time.Sleep(2 * time.Millisecond)
+
+	// The caller hangs up while the trial call is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	err := interceptor(ctx, readMethod, nil, nil, conn, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		cancel()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if b.State() != breaker.StateHalfOpen {
+		t.Fatalf("cancelled trial must leave the breaker half-open, state is %s", b.State())
+	}
+
+	// The slot is free again for the next trial
+	calls := 0
+	if err := interceptor(context.Background(), readMethod, nil, nil, conn, failingInvoker(0, codes.OK, &calls)); err != nil || calls != 1 {
+		t.Fatalf("next trial must reach the upstream, got %d calls and %v", calls, err)
+	}
+	if b.State() != breaker.StateClosed {
+		t.Fatalf("successful trial must close the breaker, state is %s", b.State())
+	}
+}
@@ -0,0 +1,98 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/breaker"
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+// RetryPolicy configures retries of calls failed by a transient error
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt too; below 2 nothing is retried
+	MaxAttempts int
+	// InitialBackoff is the longest wait before the first retry; it doubles
+	// with every retry up to MaxBackoff. The actual wait is random up to that.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Methods are the full names of the methods that may be retried. Only
+	// idempotent methods belong here, as a failed attempt may have been applied.
+	Methods []string
+}
+
+// UnaryClientRetry retries calls of the methods of policy that fail because
+// the upstream is unavailable or an attempt timed out, waiting a jittered
+// exponential backoff in between. Calls rejected by an open breaker and calls
+// whose context is done are not retried.
+func UnaryClientRetry(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	methods := make(map[string]struct{}, len(policy.Methods))
+	for _, method := range policy.Methods {
+		methods[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := methods[method]; !ok || policy.MaxAttempts < 2 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt == policy.MaxAttempts || !retryable(ctx, err) {
+				return err
+			}
+
+			wait := backoff(policy, attempt)
+			logger.FromContext(ctx).Debug("Retrying gRPC call",
+				slog.String("method", method),
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", wait),
+				logger.Err(err),
+			)
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// retryable tells whether another attempt may succeed where err failed
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, breaker.ErrOpen) {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns a random wait before the retry following attempt, up to
+// the exponential backoff of the attempt
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	ceiling := policy.InitialBackoff << (attempt - 1)
+	if ceiling > policy.MaxBackoff || ceiling <= 0 {
+		ceiling = policy.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling + 1)
+}
@@ -0,0 +1,154 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	"github.com/nimbodex/microservices-factory/platform/pkg/requestid"
+)
+
+// UnaryServerRequestID puts the request ID received in metadata, or a new
+// one, into the call context together with a logger that adds it to every line
+func UnaryServerRequestID(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		ctx = requestid.NewContext(ctx, id)
+		ctx = logger.WithContext(ctx, log.With(slog.String("request_id", id)))
+
+		return handler(ctx, req)
+	}
+}
+
+// UnaryServerLogging logs every call with its status code and duration using
+// the logger of the call context. Server faults are logged as errors,
+// rejected requests as warnings.
+func UnaryServerLogging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		attrs := []slog.Attr{
+			slog.String("method", info.FullMethod),
+			slog.String("code", code.String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, logger.Err(err))
+		}
+		level := levelOf(code)
+		if code == codes.OK && info.FullMethod == healthpb.Health_Check_FullMethodName {
+			// Probes would flood the log otherwise
+			level = slog.LevelDebug
+		}
+		logger.FromContext(ctx).LogAttrs(ctx, level, "gRPC call handled", attrs...)
+
+		return resp, err
+	}
+}
+
+// UnaryServerMetrics counts calls by method and status code and observes
+// their duration; the collectors are registered with reg
+func UnaryServerMetrics(reg prometheus.Registerer) grpc.UnaryServerInterceptor {
+	handled := promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of gRPC calls handled by the server, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	duration := promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Duration of gRPC calls handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		service, method := splitMethod(info.FullMethod)
+		handled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		duration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
+
+// UnaryServerRecovery turns a panic in a handler into an Internal error
+// instead of crashing the process
+func UnaryServerRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(ctx).ErrorContext(ctx, "gRPC handler panicked",
+					slog.String("method", info.FullMethod),
+					slog.Any("panic", r),
+					slog.String("stack", string(debug.Stack())),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// ServerOptions returns the interceptors every service installs: the request
+// ID comes first so that every line is tagged with it, and metrics and
+// logging wrap recovery so that panics are counted and logged too. log is the
+// base logger of the service and reg the registry of its metrics. Calls are
+// traced as children of the span the client passed on.
+func ServerOptions(log *slog.Logger, reg prometheus.Registerer) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			UnaryServerRequestID(log),
+			UnaryServerMetrics(reg),
+			UnaryServerLogging(),
+			UnaryServerRecovery(),
+		),
+	}
+}
+
+// splitMethod splits a full method name such as /inventory.v1.InventoryService/GetPart
+// into the service and method names
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
+
+// levelOf returns the log level of a call finished with code
+func levelOf(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
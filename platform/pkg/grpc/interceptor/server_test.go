@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+	"github.com/nimbodex/microservices-factory/platform/pkg/requestid"
+)
+
+func TestUnaryServerRecovery_ConvertsPanicToInternal(t *testing.T) {
+	ctx := logger.WithContext(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	_, err := UnaryServerRecovery()(ctx, nil, info, func(context.Context, any) (any, error) {
+		panic("nil map")
+	})
+
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code %v, want %v", status.Code(err), codes.Internal)
+	}
+}
+
+func TestUnaryServerMetrics_CountsCallsByCode(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := UnaryServerMetrics(reg)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	for _, err := range []error{nil, nil, status.Error(codes.NotFound, "missing")} {
+		_, _ = metrics(context.Background(), nil, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+	}
+
+	want := `
+# HELP grpc_server_handled_total Total number of gRPC calls handled by the server, by method and status code.
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="NotFound",grpc_method="Call",grpc_service="test.v1.Service"} 1
+grpc_server_handled_total{grpc_code="OK",grpc_method="Call",grpc_service="test.v1.Service"} 2
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "grpc_server_handled_total"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(reg, "grpc_server_handling_seconds"); got != 1 {
+		t.Errorf("%d duration series, want 1", got)
+	}
+}
+
+func TestUnaryClientTimeout_KeepsEarlierDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	err := UnaryClientTimeout(time.Hour, nil)(ctx, "/test.v1.Service/Call", nil, nil, nil,
+		func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			if got, ok := ctx.Deadline(); !ok || !got.Equal(want) {
+				t.Errorf("deadline %v, want %v", got, want)
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+}
+
+func TestRequestID_PropagatesFromClientToServer(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, nil))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	var serverID string
+	server := func(ctx context.Context, _ any) (any, error) {
+		serverID, _ = requestid.FromContext(ctx)
+		logger.FromContext(ctx).InfoContext(ctx, "handled")
+		return nil, nil
+	}
+
+	// The client metadata is handed to the server as incoming metadata
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := UnaryServerRequestID(log)(metadata.NewIncomingContext(context.Background(), md), nil, info, server)
+		return err
+	}
+
+	ctx := requestid.NewContext(context.Background(), "req-7")
+	if err := UnaryClientRequestID()(ctx, info.FullMethod, nil, nil, nil, invoker); err != nil {
+		t.Fatalf("invoke: %v", err)
+	}
+
+	if serverID != "req-7" {
+		t.Errorf("server request ID %q, want req-7", serverID)
+	}
+	if !strings.Contains(buf.String(), `"request_id":"req-7"`) {
+		t.Errorf("log %s is not tagged with the request ID", buf.String())
+	}
+}
+
+func TestUnaryServerRequestID_GeneratesMissingID(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	_, err := UnaryServerRequestID(log)(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+		if id, ok := requestid.FromContext(ctx); !ok || !requestid.Valid(id) {
+			t.Errorf("request ID %q, want a generated one", id)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// RegisterGRPC registers the standard grpc.health.v1 service on srv and
+// reports every service already registered on it, as well as the server as
+// a whole, as serving. Calling Shutdown on the returned server reports them
+// as not serving.
+func RegisterGRPC(srv *grpc.Server) *health.Server {
+	healthServer := health.NewServer()
+	for name := range srv.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	return healthServer
+}
+
+// CheckGRPC asks the health service behind conn whether service is serving
+func CheckGRPC(ctx context.Context, conn grpc.ClientConnInterface, service string) error {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%s is %s", service, resp.GetStatus())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/logger"
+)
+
+const (
+	// LivenessPath answers as long as the process serves HTTP
+	LivenessPath = "/healthz"
+	// ReadinessPath answers whether the service can handle requests
+	ReadinessPath = "/readyz"
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusStopping    = "shutting down"
+)
+
+// Check returns an error when a dependency cannot be used
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker decides whether a service is ready by running the checks of its
+// dependencies. Once shut down it reports the service as not ready, so that
+// no new traffic is routed to it while in-flight requests finish.
+type Checker struct {
+	timeout  time.Duration
+	checks   []namedCheck
+	stopping atomic.Bool
+}
+
+// Report is the body of readiness responses
+type Report struct {
+	Status string `json:"status"`
+	// Checks tells for every dependency whether it is ok; causes of failures
+	// are logged rather than exposed to whoever probes the service
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// NewChecker creates a checker giving every check up to timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers the check of a dependency; name identifies it in reports
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Shutdown makes the service report as not ready from now on
+func (c *Checker) Shutdown() {
+	c.stopping.Store(true)
+}
+
+// Ready runs every check concurrently and reports whether all passed
+func (c *Checker) Ready(ctx context.Context) (bool, Report) {
+	if c.stopping.Load() {
+		return false, Report{Status: statusStopping}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]error, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check.check(ctx)
+		}()
+	}
+	wg.Wait()
+
+	ready := true
+	report := Report{Status: statusOK, Checks: make(map[string]string, len(c.checks))}
+	for i, check := range c.checks {
+		if err := results[i]; err != nil {
+			logger.FromContext(ctx).Warn("Readiness check failed", slog.String("check", check.name), logger.Err(err))
+			ready = false
+			report.Checks[check.name] = statusUnavailable
+			continue
+		}
+		report.Checks[check.name] = statusOK
+	}
+	if !ready {
+		report.Status = statusUnavailable
+	}
+
+	return ready, report
+}
+
+// Register adds the liveness and readiness endpoints to mux
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+LivenessPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: statusOK})
+	})
+	mux.HandleFunc("GET "+ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		ready, report := c.Ready(r.Context())
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func get(t *testing.T, mux *http.ServeMux, path string) (int, Report) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var report Report
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+	return rec.Code, report
+}
+
+func TestChecker_ReadinessReflectsChecks(t *testing.T) {
+	var storageErr error
+	checker := NewChecker(time.Second)
+	checker.Add("inventory", func(context.Context) error { return nil })
+	checker.Add("storage", func(context.Context) error { return storageErr })
+
+	mux := http.NewServeMux()
+	checker.Register(mux)
+
+	if code, report := get(t, mux, ReadinessPath); code != http.StatusOK || report.Checks["storage"] != statusOK {
+		t.Fatalf("ready: %d %+v, want 200 with storage ok", code, report)
+	}
+
+	storageErr = errors.New("connection refused")
+	code, report := get(t, mux, ReadinessPath)
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d, want 503", code)
+	}
+	if report.Checks["storage"] != statusUnavailable || report.Checks["inventory"] != statusOK {
+		t.Errorf("checks %v, want storage failing only", report.Checks)
+	}
+
+	if code, _ := get(t, mux, LivenessPath); code != http.StatusOK {
+		t.Errorf("liveness %d, want 200 while dependencies fail", code)
+	}
+}
+
+func TestChecker_ShutdownMakesNotReady(t *testing.T) {
+	checker := NewChecker(time.Second)
+	mux := http.NewServeMux()
+	checker.Register(mux)
+
+	checker.Shutdown()
+
+	if code, report := get(t, mux, ReadinessPath); code != http.StatusServiceUnavailable || report.Status != statusStopping {
+		t.Errorf("ready: %d %+v, want 503 while shutting down", code, report)
+	}
+}
+
+func TestChecker_ChecksAreBoundedByTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("payment", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	ready, report := checker.Ready(context.Background())
+
+	if ready || report.Checks["payment"] != statusUnavailable {
+		t.Errorf("ready %v %+v, want payment timed out", ready, report)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("readiness took %v", elapsed)
+	}
+}
+
+func TestRegisterGRPC_ReportsNotServingAfterShutdown(t *testing.T) {
+	srv := grpc.NewServer()
+	reflection.Register(srv)
+	healthServer := RegisterGRPC(srv)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := context.Background()
+	for _, service := range []string{"", "grpc.reflection.v1.ServerReflection"} {
+		if err := CheckGRPC(ctx, conn, service); err != nil {
+			t.Fatalf("check %q before shutdown: %v", service, err)
+		}
+	}
+
+	healthServer.Shutdown()
+
+	if err := CheckGRPC(ctx, conn, ""); err == nil || !strings.Contains(err.Error(), "NOT_SERVING") {
+		t.Errorf("check after shutdown: %v, want NOT_SERVING", err)
+	}
+}
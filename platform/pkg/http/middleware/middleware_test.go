@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/requestid"
+)
+
+func TestChain_RecoveryAndLogging(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	h := Chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("broken handler")
+	}), RequestID(log), Logging(), Recovery())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+	req.Header.Set(requestid.Header, "req-42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get(requestid.Header); got != "req-42" {
+		t.Errorf("response request ID %q, want req-42", got)
+	}
+	if n := strings.Count(buf.String(), `"request_id":"req-42"`); n != 2 {
+		t.Errorf("request ID is on %d log lines, want 2: %s", n, buf.String())
+	}
+	for _, want := range []string{`"msg":"HTTP handler panicked"`, `"status":500`, `"path":"/api/v1/orders"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("log %s does not contain %s", buf.String(), want)
+		}
+	}
+}
+
+func TestRequestID_GeneratesIDForInvalidHeader(t *testing.T) {
+	log := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	var fromContext string
+	h := RequestID(log)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		fromContext, _ = requestid.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestid.Header, "has spaces")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	got := rec.Header().Get(requestid.Header)
+	if !requestid.Valid(got) || got == "has spaces" {
+		t.Fatalf("response request ID %q, want a generated one", got)
+	}
+	if fromContext != got {
+		t.Errorf("context request ID %q, want %q", fromContext, got)
+	}
+}
+
+func TestMetrics_CountsRequestsByOperation(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	operation := func(r *http.Request) string {
+		if strings.HasPrefix(r.URL.Path, "/api/v1/orders/") {
+			return "GetOrder"
+		}
+		return "unknown"
+	}
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}), Metrics(reg, operation))
+
+	for _, path := range []string{"/api/v1/orders/1", "/api/v1/orders/2", "/api/v1/orders/missing"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	want := `
+# HELP http_server_requests_total Total number of HTTP requests handled, by operation, method and status code.
+# TYPE http_server_requests_total counter
+http_server_requests_total{code="200",method="GET",operation="GetOrder"} 2
+http_server_requests_total{code="404",method="GET",operation="GetOrder"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "http_server_requests_total"); err != nil {
+		t.Error(err)
+	}
+}
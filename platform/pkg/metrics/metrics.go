@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where the admin server exposes metrics
+const Path = "/metrics"
+
+// NewRegistry returns a registry already collecting Go runtime and process
+// metrics. Each service keeps its own registry instead of the global one so
+// tests can create services without registering the same metric twice.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// NewAdminServer returns the HTTP server of the admin port exposing metrics
+// gathered from reg. It is kept apart from the API so that metrics are not
+// reachable by API clients.
+func NewAdminServer(addr string, reg *prometheus.Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestNewAdminServer_ExposesMetrics(t *testing.T) {
+	reg := NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_events_total", Help: "Events seen by the test."})
+	reg.MustRegister(counter)
+	counter.Inc()
+
+	rec := httptest.NewRecorder()
+	NewAdminServer(":0", reg).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{"test_events_total 1", "go_goroutines", "process_cpu_seconds_total"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNew_IsValidAndUnique(t *testing.T) {
+	a, b := New(), New()
+
+	if !Valid(a) {
+		t.Errorf("generated ID %q is not valid", a)
+	}
+	if a == b {
+		t.Errorf("two generated IDs are equal: %q", a)
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := map[string]bool{
+		"":                        false,
+		"3f2c-1":                  true,
+		"with space":              false,
+		"line\nbreak":             false,
+		strings.Repeat("a", 128):  true,
+		strings.Repeat("a", 129):  false,
+		"d1e5c0de-4b2a-4c1e-9a7e": true,
+	}
+
+	for id, want := range tests {
+		if got := Valid(id); got != want {
+			t.Errorf("Valid(%q) = %t, want %t", id, got, want)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatal("empty context has a request ID")
+	}
+
+	id, ok := FromContext(NewContext(context.Background(), "req-1"))
+	if !ok || id != "req-1" {
+		t.Errorf("FromContext = %q, %t, want req-1", id, ok)
+	}
+}
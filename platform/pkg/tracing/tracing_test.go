@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/nimbodex/microservices-factory/platform/pkg/grpc/interceptor"
+	"github.com/nimbodex/microservices-factory/platform/pkg/http/middleware"
+)
+
+// tracedHealthServer starts a repository-like span inside the handled call
+type tracedHealthServer struct {
+	*health.Server
+}
+
+func (s tracedHealthServer) Check(ctx context.Context, req *healthv1.HealthCheckRequest) (*healthv1.HealthCheckResponse, error) {
+	_, span := Start(ctx, "test/repository", "Repository.Get")
+	End(span, nil)
+	return s.Server.Check(ctx, req)
+}
+
+func useInMemory(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	provider, exporter := NewInMemory()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	return exporter
+}
+
+func TestTrace_FromHTTPEdgeThroughGRPCToRepository(t *testing.T) {
+	exporter := useInMemory(t)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(interceptor.ServerOptions(slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry())...)
+	healthv1.RegisterHealthServer(srv, tracedHealthServer{health.NewServer()})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	opts := append(interceptor.DialOptions(interceptor.CallPolicy{Timeout: time.Second}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	handler := middleware.Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := Start(r.Context(), "test/http", "GET /parts")
+		defer span.End()
+		if _, err := healthv1.NewHealthClient(conn).Check(ctx, &healthv1.HealthCheckRequest{}); err != nil {
+			t.Errorf("check: %v", err)
+		}
+	}), middleware.Tracing())
+
+	const remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/parts", nil)
+	req.Header.Set("traceparent", "00-"+remoteTraceID+"-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	tree := []struct{ child, parent string }{
+		{child: "grpc.health.v1.Health/Check", parent: "GET /parts"},
+		{child: "Repository.Get", parent: "grpc.health.v1.Health/Check"},
+	}
+
+	edge, ok := byName["GET /parts"]
+	if !ok {
+		t.Fatalf("no edge span among %d spans", len(spans))
+	}
+	if got := edge.SpanContext.TraceID().String(); got != remoteTraceID {
+		t.Errorf("edge trace ID %s, want the caller's %s", got, remoteTraceID)
+	}
+	if got := edge.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("edge parent %s, want the caller's span", got)
+	}
+
+	// The client and server spans of a call share its name; the server one is
+	// the child of the client one
+	var client, server tracetest.SpanStub
+	for _, span := range spans {
+		if span.Name != tree[0].child {
+			continue
+		}
+		if span.SpanKind == trace.SpanKindClient {
+			client = span
+		} else {
+			server = span
+		}
+	}
+	if client.Parent.SpanID() != edge.SpanContext.SpanID() {
+		t.Errorf("client span is not a child of the edge span")
+	}
+	if server.Parent.SpanID() != client.SpanContext.SpanID() {
+		t.Errorf("server span is not a child of the client span")
+	}
+	if repo := byName[tree[1].child]; repo.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Errorf("repository span is not a child of the server span")
+	}
+	for _, span := range spans {
+		if span.SpanContext.TraceID().String() != remoteTraceID {
+			t.Errorf("span %s belongs to trace %s", span.Name, span.SpanContext.TraceID())
+		}
+	}
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	exporter := useInMemory(t)
+
+	_, span := Start(context.Background(), "test", "failing")
+	End(span, errors.New("disk full"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error || spans[0].Status.Description != "disk full" {
+		t.Errorf("status %+v, want error", spans[0].Status)
+	}
+}
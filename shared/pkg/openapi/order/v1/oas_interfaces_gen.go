@@ -13,6 +13,14 @@ type GetOrderRes interface {
 	getOrderRes()
 }
 
+type ListOrdersRes interface {
+	listOrdersRes()
+}
+
 type PayOrderRes interface {
 	payOrderRes()
 }
+
+type RefundOrderRes interface {
+	refundOrderRes()
+}
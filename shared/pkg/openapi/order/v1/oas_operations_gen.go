@@ -9,5 +9,7 @@ const (
 	CancelOrderOperation OperationName = "CancelOrder"
 	CreateOrderOperation OperationName = "CreateOrder"
 	GetOrderOperation    OperationName = "GetOrder"
+	ListOrdersOperation  OperationName = "ListOrders"
 	PayOrderOperation    OperationName = "PayOrder"
+	RefundOrderOperation OperationName = "RefundOrder"
 )
@@ -4,6 +4,7 @@ package orderv1
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
@@ -14,6 +15,38 @@ func (s *InternalServerErrorStatusCode) Error() string {
 	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
 }
 
+// Ref: #/components/schemas/bad_gateway_error
+type BadGatewayError struct {
+	// Error type.
+	Error string `json:"error"`
+	// Error message.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *BadGatewayError) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *BadGatewayError) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *BadGatewayError) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *BadGatewayError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*BadGatewayError) createOrderRes() {}
+func (*BadGatewayError) payOrderRes()    {}
+func (*BadGatewayError) refundOrderRes() {}
+
 // Ref: #/components/schemas/bad_request_error
 type BadRequestError struct {
 	// Error type.
@@ -55,7 +88,9 @@ func (s *BadRequestError) SetDetails(val OptBadRequestErrorDetails) {
 }
 
 func (*BadRequestError) createOrderRes() {}
+func (*BadRequestError) listOrdersRes()  {}
 func (*BadRequestError) payOrderRes()    {}
+func (*BadRequestError) refundOrderRes() {}
 
 // Error details.
 type BadRequestErrorDetails map[string]jx.Raw
@@ -74,12 +109,57 @@ type CancelOrderNoContent struct{}
 
 func (*CancelOrderNoContent) cancelOrderRes() {}
 
+// Why the order was cancelled.
+// Ref: #/components/schemas/cancellation_reason
+type CancellationReason string
+
+const (
+	CancellationReasonUSERCANCELLED CancellationReason = "USER_CANCELLED"
+	CancellationReasonEXPIRED       CancellationReason = "EXPIRED"
+)
+
+// AllValues returns all CancellationReason values.
+func (CancellationReason) AllValues() []CancellationReason {
+	return []CancellationReason{
+		CancellationReasonUSERCANCELLED,
+		CancellationReasonEXPIRED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s CancellationReason) MarshalText() ([]byte, error) {
+	switch s {
+	case CancellationReasonUSERCANCELLED:
+		return []byte(s), nil
+	case CancellationReasonEXPIRED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *CancellationReason) UnmarshalText(data []byte) error {
+	switch CancellationReason(data) {
+	case CancellationReasonUSERCANCELLED:
+		*s = CancellationReasonUSERCANCELLED
+		return nil
+	case CancellationReasonEXPIRED:
+		*s = CancellationReasonEXPIRED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
 // Ref: #/components/schemas/conflict_error
 type ConflictError struct {
 	// Error type.
 	Error string `json:"error"`
 	// Error message.
 	Message string `json:"message"`
+	// Parts without enough stock, set when the order cannot be created.
+	UnavailablePartUuids []uuid.UUID `json:"unavailable_part_uuids"`
 }
 
 // GetError returns the value of Error.
@@ -92,6 +172,11 @@ func (s *ConflictError) GetMessage() string {
 	return s.Message
 }
 
+// GetUnavailablePartUuids returns the value of UnavailablePartUuids.
+func (s *ConflictError) GetUnavailablePartUuids() []uuid.UUID {
+	return s.UnavailablePartUuids
+}
+
 // SetError sets the value of Error.
 func (s *ConflictError) SetError(val string) {
 	s.Error = val
@@ -102,35 +187,58 @@ func (s *ConflictError) SetMessage(val string) {
 	s.Message = val
 }
 
+// SetUnavailablePartUuids sets the value of UnavailablePartUuids.
+func (s *ConflictError) SetUnavailablePartUuids(val []uuid.UUID) {
+	s.UnavailablePartUuids = val
+}
+
 func (*ConflictError) cancelOrderRes() {}
+func (*ConflictError) createOrderRes() {}
 func (*ConflictError) payOrderRes()    {}
+func (*ConflictError) refundOrderRes() {}
 
-// Ref: #/components/schemas/create_order_request
-type CreateOrderRequest struct {
-	// User UUID.
-	UserUUID uuid.UUID `json:"user_uuid"`
-	// List of part UUIDs for the order.
-	PartUuids []uuid.UUID `json:"part_uuids"`
+// Ref: #/components/schemas/create_order_item
+type CreateOrderItem struct {
+	// Part UUID.
+	PartUUID uuid.UUID `json:"part_uuid"`
+	// Number of units to order.
+	Quantity int32 `json:"quantity"`
 }
 
-// GetUserUUID returns the value of UserUUID.
-func (s *CreateOrderRequest) GetUserUUID() uuid.UUID {
-	return s.UserUUID
+// GetPartUUID returns the value of PartUUID.
+func (s *CreateOrderItem) GetPartUUID() uuid.UUID {
+	return s.PartUUID
 }
 
-// GetPartUuids returns the value of PartUuids.
-func (s *CreateOrderRequest) GetPartUuids() []uuid.UUID {
-	return s.PartUuids
+// GetQuantity returns the value of Quantity.
+func (s *CreateOrderItem) GetQuantity() int32 {
+	return s.Quantity
 }
 
-// SetUserUUID sets the value of UserUUID.
-func (s *CreateOrderRequest) SetUserUUID(val uuid.UUID) {
-	s.UserUUID = val
+// SetPartUUID sets the value of PartUUID.
+func (s *CreateOrderItem) SetPartUUID(val uuid.UUID) {
+	s.PartUUID = val
+}
+
+// SetQuantity sets the value of Quantity.
+func (s *CreateOrderItem) SetQuantity(val int32) {
+	s.Quantity = val
+}
+
+// Ref: #/components/schemas/create_order_request
+type CreateOrderRequest struct {
+	// Parts to order with their quantities; each part may appear once.
+	Items []CreateOrderItem `json:"items"`
+}
+
+// GetItems returns the value of Items.
+func (s *CreateOrderRequest) GetItems() []CreateOrderItem {
+	return s.Items
 }
 
-// SetPartUuids sets the value of PartUuids.
-func (s *CreateOrderRequest) SetPartUuids(val []uuid.UUID) {
-	s.PartUuids = val
+// SetItems sets the value of Items.
+func (s *CreateOrderRequest) SetItems(val []CreateOrderItem) {
+	s.Items = val
 }
 
 // Ref: #/components/schemas/create_order_response
@@ -169,14 +277,23 @@ type GetOrderResponse struct {
 	OrderUUID uuid.UUID `json:"order_uuid"`
 	// User UUID.
 	UserUUID uuid.UUID `json:"user_uuid"`
-	// List of part UUIDs in the order.
-	PartUuids []uuid.UUID `json:"part_uuids"`
+	// Line items of the order.
+	Items []OrderItemDto `json:"items"`
 	// Total order price.
 	TotalPrice float64 `json:"total_price"`
 	// Transaction UUID (if paid).
 	TransactionUUID OptNilUUID       `json:"transaction_uuid"`
 	PaymentMethod   OptPaymentMethod `json:"payment_method"`
-	Status          OrderStatus      `json:"status"`
+	// Payment date (if paid).
+	PaidAt OptNilDateTime `json:"paid_at"`
+	// Total amount refunded so far.
+	RefundedAmount float64 `json:"refunded_amount"`
+	// Date of the refund that returned the whole amount (if refunded).
+	RefundedAt         OptNilDateTime        `json:"refunded_at"`
+	CancellationReason OptCancellationReason `json:"cancellation_reason"`
+	// Cancellation date (if cancelled).
+	CancelledAt OptNilDateTime `json:"cancelled_at"`
+	Status      OrderStatus    `json:"status"`
 }
 
 // GetOrderUUID returns the value of OrderUUID.
@@ -189,9 +306,9 @@ func (s *GetOrderResponse) GetUserUUID() uuid.UUID {
 	return s.UserUUID
 }
 
-// GetPartUuids returns the value of PartUuids.
-func (s *GetOrderResponse) GetPartUuids() []uuid.UUID {
-	return s.PartUuids
+// GetItems returns the value of Items.
+func (s *GetOrderResponse) GetItems() []OrderItemDto {
+	return s.Items
 }
 
 // GetTotalPrice returns the value of TotalPrice.
@@ -209,6 +326,31 @@ func (s *GetOrderResponse) GetPaymentMethod() OptPaymentMethod {
 	return s.PaymentMethod
 }
 
+// GetPaidAt returns the value of PaidAt.
+func (s *GetOrderResponse) GetPaidAt() OptNilDateTime {
+	return s.PaidAt
+}
+
+// GetRefundedAmount returns the value of RefundedAmount.
+func (s *GetOrderResponse) GetRefundedAmount() float64 {
+	return s.RefundedAmount
+}
+
+// GetRefundedAt returns the value of RefundedAt.
+func (s *GetOrderResponse) GetRefundedAt() OptNilDateTime {
+	return s.RefundedAt
+}
+
+// GetCancellationReason returns the value of CancellationReason.
+func (s *GetOrderResponse) GetCancellationReason() OptCancellationReason {
+	return s.CancellationReason
+}
+
+// GetCancelledAt returns the value of CancelledAt.
+func (s *GetOrderResponse) GetCancelledAt() OptNilDateTime {
+	return s.CancelledAt
+}
+
 // GetStatus returns the value of Status.
 func (s *GetOrderResponse) GetStatus() OrderStatus {
 	return s.Status
@@ -224,9 +366,9 @@ func (s *GetOrderResponse) SetUserUUID(val uuid.UUID) {
 	s.UserUUID = val
 }
 
-// SetPartUuids sets the value of PartUuids.
-func (s *GetOrderResponse) SetPartUuids(val []uuid.UUID) {
-	s.PartUuids = val
+// SetItems sets the value of Items.
+func (s *GetOrderResponse) SetItems(val []OrderItemDto) {
+	s.Items = val
 }
 
 // SetTotalPrice sets the value of TotalPrice.
@@ -244,6 +386,31 @@ func (s *GetOrderResponse) SetPaymentMethod(val OptPaymentMethod) {
 	s.PaymentMethod = val
 }
 
+// SetPaidAt sets the value of PaidAt.
+func (s *GetOrderResponse) SetPaidAt(val OptNilDateTime) {
+	s.PaidAt = val
+}
+
+// SetRefundedAmount sets the value of RefundedAmount.
+func (s *GetOrderResponse) SetRefundedAmount(val float64) {
+	s.RefundedAmount = val
+}
+
+// SetRefundedAt sets the value of RefundedAt.
+func (s *GetOrderResponse) SetRefundedAt(val OptNilDateTime) {
+	s.RefundedAt = val
+}
+
+// SetCancellationReason sets the value of CancellationReason.
+func (s *GetOrderResponse) SetCancellationReason(val OptCancellationReason) {
+	s.CancellationReason = val
+}
+
+// SetCancelledAt sets the value of CancelledAt.
+func (s *GetOrderResponse) SetCancelledAt(val OptNilDateTime) {
+	s.CancelledAt = val
+}
+
 // SetStatus sets the value of Status.
 func (s *GetOrderResponse) SetStatus(val OrderStatus) {
 	s.Status = val
@@ -282,7 +449,9 @@ func (s *InternalServerError) SetMessage(val string) {
 func (*InternalServerError) cancelOrderRes() {}
 func (*InternalServerError) createOrderRes() {}
 func (*InternalServerError) getOrderRes()    {}
+func (*InternalServerError) listOrdersRes()  {}
 func (*InternalServerError) payOrderRes()    {}
+func (*InternalServerError) refundOrderRes() {}
 
 // InternalServerErrorStatusCode wraps InternalServerError with StatusCode.
 type InternalServerErrorStatusCode struct {
@@ -310,6 +479,36 @@ func (s *InternalServerErrorStatusCode) SetResponse(val InternalServerError) {
 	s.Response = val
 }
 
+// Ref: #/components/schemas/list_orders_response
+type ListOrdersResponse struct {
+	// Orders sorted from newest to oldest.
+	Orders []GetOrderResponse `json:"orders"`
+	// Cursor for the next page, absent on the last page.
+	NextCursor OptString `json:"next_cursor"`
+}
+
+// GetOrders returns the value of Orders.
+func (s *ListOrdersResponse) GetOrders() []GetOrderResponse {
+	return s.Orders
+}
+
+// GetNextCursor returns the value of NextCursor.
+func (s *ListOrdersResponse) GetNextCursor() OptString {
+	return s.NextCursor
+}
+
+// SetOrders sets the value of Orders.
+func (s *ListOrdersResponse) SetOrders(val []GetOrderResponse) {
+	s.Orders = val
+}
+
+// SetNextCursor sets the value of NextCursor.
+func (s *ListOrdersResponse) SetNextCursor(val OptString) {
+	s.NextCursor = val
+}
+
+func (*ListOrdersResponse) listOrdersRes() {}
+
 // Ref: #/components/schemas/not_found_error
 type NotFoundError struct {
 	// Error type.
@@ -341,6 +540,7 @@ func (s *NotFoundError) SetMessage(val string) {
 func (*NotFoundError) cancelOrderRes() {}
 func (*NotFoundError) getOrderRes()    {}
 func (*NotFoundError) payOrderRes()    {}
+func (*NotFoundError) refundOrderRes() {}
 
 // NewOptBadRequestErrorDetails returns new OptBadRequestErrorDetails with value set to v.
 func NewOptBadRequestErrorDetails(v BadRequestErrorDetails) OptBadRequestErrorDetails {
@@ -388,55 +588,38 @@ func (o OptBadRequestErrorDetails) Or(d BadRequestErrorDetails) BadRequestErrorD
 	return d
 }
 
-// NewOptNilUUID returns new OptNilUUID with value set to v.
-func NewOptNilUUID(v uuid.UUID) OptNilUUID {
-	return OptNilUUID{
+// NewOptCancellationReason returns new OptCancellationReason with value set to v.
+func NewOptCancellationReason(v CancellationReason) OptCancellationReason {
+	return OptCancellationReason{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptNilUUID is optional nullable uuid.UUID.
-type OptNilUUID struct {
-	Value uuid.UUID
+// OptCancellationReason is optional CancellationReason.
+type OptCancellationReason struct {
+	Value CancellationReason
 	Set   bool
-	Null  bool
 }
 
-// IsSet returns true if OptNilUUID was set.
-func (o OptNilUUID) IsSet() bool { return o.Set }
+// IsSet returns true if OptCancellationReason was set.
+func (o OptCancellationReason) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptNilUUID) Reset() {
-	var v uuid.UUID
+func (o *OptCancellationReason) Reset() {
+	var v CancellationReason
 	o.Value = v
 	o.Set = false
-	o.Null = false
 }
 
 // SetTo sets value to v.
-func (o *OptNilUUID) SetTo(v uuid.UUID) {
-	o.Set = true
-	o.Null = false
-	o.Value = v
-}
-
-// IsNull returns true if value is Null.
-func (o OptNilUUID) IsNull() bool { return o.Null }
-
-// SetToNull sets value to null.
-func (o *OptNilUUID) SetToNull() {
+func (o *OptCancellationReason) SetTo(v CancellationReason) {
 	o.Set = true
-	o.Null = true
-	var v uuid.UUID
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptNilUUID) Get() (v uuid.UUID, ok bool) {
-	if o.Null {
-		return v, false
-	}
+func (o OptCancellationReason) Get() (v CancellationReason, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -444,45 +627,45 @@ func (o OptNilUUID) Get() (v uuid.UUID, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptNilUUID) Or(d uuid.UUID) uuid.UUID {
+func (o OptCancellationReason) Or(d CancellationReason) CancellationReason {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptPaymentMethod returns new OptPaymentMethod with value set to v.
-func NewOptPaymentMethod(v PaymentMethod) OptPaymentMethod {
-	return OptPaymentMethod{
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptPaymentMethod is optional PaymentMethod.
-type OptPaymentMethod struct {
-	Value PaymentMethod
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
 	Set   bool
 }
 
-// IsSet returns true if OptPaymentMethod was set.
-func (o OptPaymentMethod) IsSet() bool { return o.Set }
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptPaymentMethod) Reset() {
-	var v PaymentMethod
+func (o *OptDateTime) Reset() {
+	var v time.Time
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptPaymentMethod) SetTo(v PaymentMethod) {
+func (o *OptDateTime) SetTo(v time.Time) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
+func (o OptDateTime) Get() (v time.Time, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -490,105 +673,640 @@ func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptPaymentMethod) Or(d PaymentMethod) PaymentMethod {
+func (o OptDateTime) Or(d time.Time) time.Time {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Order status.
-// Ref: #/components/schemas/order_status
-type OrderStatus string
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
 
-const (
-	OrderStatusPENDINGPAYMENT OrderStatus = "PENDING_PAYMENT"
-	OrderStatusPAID           OrderStatus = "PAID"
-	OrderStatusCANCELLED      OrderStatus = "CANCELLED"
-)
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
 
-// AllValues returns all OrderStatus values.
-func (OrderStatus) AllValues() []OrderStatus {
-	return []OrderStatus{
-		OrderStatusPENDINGPAYMENT,
-		OrderStatusPAID,
-		OrderStatusCANCELLED,
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
 	}
+	return o.Value, true
 }
 
-// MarshalText implements encoding.TextMarshaler.
-func (s OrderStatus) MarshalText() ([]byte, error) {
-	switch s {
-	case OrderStatusPENDINGPAYMENT:
-		return []byte(s), nil
-	case OrderStatusPAID:
-		return []byte(s), nil
-	case OrderStatusCANCELLED:
-		return []byte(s), nil
-	default:
-		return nil, errors.Errorf("invalid value: %q", s)
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
 	}
+	return d
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
-func (s *OrderStatus) UnmarshalText(data []byte) error {
-	switch OrderStatus(data) {
-	case OrderStatusPENDINGPAYMENT:
-		*s = OrderStatusPENDINGPAYMENT
-		return nil
-	case OrderStatusPAID:
-		*s = OrderStatusPAID
-		return nil
-	case OrderStatusCANCELLED:
-		*s = OrderStatusCANCELLED
-		return nil
-	default:
-		return errors.Errorf("invalid value: %q", data)
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
 	}
 }
 
-// Ref: #/components/schemas/pay_order_request
-type PayOrderRequest struct {
-	PaymentMethod PaymentMethod `json:"payment_method"`
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
 }
 
-// GetPaymentMethod returns the value of PaymentMethod.
-func (s *PayOrderRequest) GetPaymentMethod() PaymentMethod {
-	return s.PaymentMethod
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
 }
 
-// SetPaymentMethod sets the value of PaymentMethod.
-func (s *PayOrderRequest) SetPaymentMethod(val PaymentMethod) {
-	s.PaymentMethod = val
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
 }
 
-// Ref: #/components/schemas/pay_order_response
-type PayOrderResponse struct {
-	// Transaction UUID.
-	TransactionUUID uuid.UUID `json:"transaction_uuid"`
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetTransactionUUID returns the value of TransactionUUID.
-func (s *PayOrderResponse) GetTransactionUUID() uuid.UUID {
-	return s.TransactionUUID
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetTransactionUUID sets the value of TransactionUUID.
-func (s *PayOrderResponse) SetTransactionUUID(val uuid.UUID) {
-	s.TransactionUUID = val
+// NewOptNilDateTime returns new OptNilDateTime with value set to v.
+func NewOptNilDateTime(v time.Time) OptNilDateTime {
+	return OptNilDateTime{
+		Value: v,
+		Set:   true,
+	}
 }
 
-func (*PayOrderResponse) payOrderRes() {}
+// OptNilDateTime is optional nullable time.Time.
+type OptNilDateTime struct {
+	Value time.Time
+	Set   bool
+	Null  bool
+}
 
-// Payment method.
-// Ref: #/components/schemas/payment_method
-type PaymentMethod string
+// IsSet returns true if OptNilDateTime was set.
+func (o OptNilDateTime) IsSet() bool { return o.Set }
 
-const (
-	PaymentMethodUNKNOWN       PaymentMethod = "UNKNOWN"
-	PaymentMethodCARD          PaymentMethod = "CARD"
-	PaymentMethodSBP           PaymentMethod = "SBP"
-	PaymentMethodCREDITCARD    PaymentMethod = "CREDIT_CARD"
+// Reset unsets value.
+func (o *OptNilDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilDateTime) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilDateTime) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v time.Time
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilDateTime) Get() (v time.Time, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilUUID returns new OptNilUUID with value set to v.
+func NewOptNilUUID(v uuid.UUID) OptNilUUID {
+	return OptNilUUID{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilUUID is optional nullable uuid.UUID.
+type OptNilUUID struct {
+	Value uuid.UUID
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilUUID was set.
+func (o OptNilUUID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilUUID) Reset() {
+	var v uuid.UUID
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilUUID) SetTo(v uuid.UUID) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilUUID) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilUUID) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v uuid.UUID
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilUUID) Get() (v uuid.UUID, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilUUID) Or(d uuid.UUID) uuid.UUID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptOrderStatus returns new OptOrderStatus with value set to v.
+func NewOptOrderStatus(v OrderStatus) OptOrderStatus {
+	return OptOrderStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptOrderStatus is optional OrderStatus.
+type OptOrderStatus struct {
+	Value OrderStatus
+	Set   bool
+}
+
+// IsSet returns true if OptOrderStatus was set.
+func (o OptOrderStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptOrderStatus) Reset() {
+	var v OrderStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptOrderStatus) SetTo(v OrderStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptOrderStatus) Get() (v OrderStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptOrderStatus) Or(d OrderStatus) OrderStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPaymentMethod returns new OptPaymentMethod with value set to v.
+func NewOptPaymentMethod(v PaymentMethod) OptPaymentMethod {
+	return OptPaymentMethod{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPaymentMethod is optional PaymentMethod.
+type OptPaymentMethod struct {
+	Value PaymentMethod
+	Set   bool
+}
+
+// IsSet returns true if OptPaymentMethod was set.
+func (o OptPaymentMethod) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPaymentMethod) Reset() {
+	var v PaymentMethod
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPaymentMethod) SetTo(v PaymentMethod) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPaymentMethod) Or(d PaymentMethod) PaymentMethod {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptRefundOrderRequest returns new OptRefundOrderRequest with value set to v.
+func NewOptRefundOrderRequest(v RefundOrderRequest) OptRefundOrderRequest {
+	return OptRefundOrderRequest{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptRefundOrderRequest is optional RefundOrderRequest.
+type OptRefundOrderRequest struct {
+	Value RefundOrderRequest
+	Set   bool
+}
+
+// IsSet returns true if OptRefundOrderRequest was set.
+func (o OptRefundOrderRequest) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptRefundOrderRequest) Reset() {
+	var v RefundOrderRequest
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptRefundOrderRequest) SetTo(v RefundOrderRequest) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptRefundOrderRequest) Get() (v RefundOrderRequest, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptRefundOrderRequest) Or(d RefundOrderRequest) RefundOrderRequest {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptUUID returns new OptUUID with value set to v.
+func NewOptUUID(v uuid.UUID) OptUUID {
+	return OptUUID{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptUUID is optional uuid.UUID.
+type OptUUID struct {
+	Value uuid.UUID
+	Set   bool
+}
+
+// IsSet returns true if OptUUID was set.
+func (o OptUUID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptUUID) Reset() {
+	var v uuid.UUID
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptUUID) SetTo(v uuid.UUID) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptUUID) Get() (v uuid.UUID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptUUID) Or(d uuid.UUID) uuid.UUID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/order_item_dto
+type OrderItemDto struct {
+	// Part UUID.
+	PartUUID uuid.UUID `json:"part_uuid"`
+	// Item quantity.
+	Quantity int32 `json:"quantity"`
+	// Price per unit at the time the order was created.
+	UnitPrice float64 `json:"unit_price"`
+	// Total item price.
+	TotalPrice float64 `json:"total_price"`
+}
+
+// GetPartUUID returns the value of PartUUID.
+func (s *OrderItemDto) GetPartUUID() uuid.UUID {
+	return s.PartUUID
+}
+
+// GetQuantity returns the value of Quantity.
+func (s *OrderItemDto) GetQuantity() int32 {
+	return s.Quantity
+}
+
+// GetUnitPrice returns the value of UnitPrice.
+func (s *OrderItemDto) GetUnitPrice() float64 {
+	return s.UnitPrice
+}
+
+// GetTotalPrice returns the value of TotalPrice.
+func (s *OrderItemDto) GetTotalPrice() float64 {
+	return s.TotalPrice
+}
+
+// SetPartUUID sets the value of PartUUID.
+func (s *OrderItemDto) SetPartUUID(val uuid.UUID) {
+	s.PartUUID = val
+}
+
+// SetQuantity sets the value of Quantity.
+func (s *OrderItemDto) SetQuantity(val int32) {
+	s.Quantity = val
+}
+
+// SetUnitPrice sets the value of UnitPrice.
+func (s *OrderItemDto) SetUnitPrice(val float64) {
+	s.UnitPrice = val
+}
+
+// SetTotalPrice sets the value of TotalPrice.
+func (s *OrderItemDto) SetTotalPrice(val float64) {
+	s.TotalPrice = val
+}
+
+// Order status.
+// Ref: #/components/schemas/order_status
+type OrderStatus string
+
+const (
+	OrderStatusPENDINGPAYMENT    OrderStatus = "PENDING_PAYMENT"
+	OrderStatusPAYMENTINPROGRESS OrderStatus = "PAYMENT_IN_PROGRESS"
+	OrderStatusPAID              OrderStatus = "PAID"
+	OrderStatusASSEMBLING        OrderStatus = "ASSEMBLING"
+	OrderStatusCOMPLETED         OrderStatus = "COMPLETED"
+	OrderStatusCANCELLED         OrderStatus = "CANCELLED"
+	OrderStatusREFUNDINPROGRESS  OrderStatus = "REFUND_IN_PROGRESS"
+	OrderStatusREFUNDED          OrderStatus = "REFUNDED"
+)
+
+// AllValues returns all OrderStatus values.
+func (OrderStatus) AllValues() []OrderStatus {
+	return []OrderStatus{
+		OrderStatusPENDINGPAYMENT,
+		OrderStatusPAYMENTINPROGRESS,
+		OrderStatusPAID,
+		OrderStatusASSEMBLING,
+		OrderStatusCOMPLETED,
+		OrderStatusCANCELLED,
+		OrderStatusREFUNDINPROGRESS,
+		OrderStatusREFUNDED,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s OrderStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case OrderStatusPENDINGPAYMENT:
+		return []byte(s), nil
+	case OrderStatusPAYMENTINPROGRESS:
+		return []byte(s), nil
+	case OrderStatusPAID:
+		return []byte(s), nil
+	case OrderStatusASSEMBLING:
+		return []byte(s), nil
+	case OrderStatusCOMPLETED:
+		return []byte(s), nil
+	case OrderStatusCANCELLED:
+		return []byte(s), nil
+	case OrderStatusREFUNDINPROGRESS:
+		return []byte(s), nil
+	case OrderStatusREFUNDED:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *OrderStatus) UnmarshalText(data []byte) error {
+	switch OrderStatus(data) {
+	case OrderStatusPENDINGPAYMENT:
+		*s = OrderStatusPENDINGPAYMENT
+		return nil
+	case OrderStatusPAYMENTINPROGRESS:
+		*s = OrderStatusPAYMENTINPROGRESS
+		return nil
+	case OrderStatusPAID:
+		*s = OrderStatusPAID
+		return nil
+	case OrderStatusASSEMBLING:
+		*s = OrderStatusASSEMBLING
+		return nil
+	case OrderStatusCOMPLETED:
+		*s = OrderStatusCOMPLETED
+		return nil
+	case OrderStatusCANCELLED:
+		*s = OrderStatusCANCELLED
+		return nil
+	case OrderStatusREFUNDINPROGRESS:
+		*s = OrderStatusREFUNDINPROGRESS
+		return nil
+	case OrderStatusREFUNDED:
+		*s = OrderStatusREFUNDED
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/pay_order_request
+type PayOrderRequest struct {
+	PaymentMethod PaymentMethod `json:"payment_method"`
+}
+
+// GetPaymentMethod returns the value of PaymentMethod.
+func (s *PayOrderRequest) GetPaymentMethod() PaymentMethod {
+	return s.PaymentMethod
+}
+
+// SetPaymentMethod sets the value of PaymentMethod.
+func (s *PayOrderRequest) SetPaymentMethod(val PaymentMethod) {
+	s.PaymentMethod = val
+}
+
+// Ref: #/components/schemas/pay_order_response
+type PayOrderResponse struct {
+	// Transaction UUID.
+	TransactionUUID uuid.UUID `json:"transaction_uuid"`
+}
+
+// GetTransactionUUID returns the value of TransactionUUID.
+func (s *PayOrderResponse) GetTransactionUUID() uuid.UUID {
+	return s.TransactionUUID
+}
+
+// SetTransactionUUID sets the value of TransactionUUID.
+func (s *PayOrderResponse) SetTransactionUUID(val uuid.UUID) {
+	s.TransactionUUID = val
+}
+
+func (*PayOrderResponse) payOrderRes() {}
+
+// Payment method.
+// Ref: #/components/schemas/payment_method
+type PaymentMethod string
+
+const (
+	PaymentMethodUNKNOWN       PaymentMethod = "UNKNOWN"
+	PaymentMethodCARD          PaymentMethod = "CARD"
+	PaymentMethodSBP           PaymentMethod = "SBP"
+	PaymentMethodCREDITCARD    PaymentMethod = "CREDIT_CARD"
 	PaymentMethodINVESTORMONEY PaymentMethod = "INVESTOR_MONEY"
 )
 
@@ -643,3 +1361,259 @@ func (s *PaymentMethod) UnmarshalText(data []byte) error {
 		return errors.Errorf("invalid value: %q", data)
 	}
 }
+
+// The outcome of the payment is not known yet; the order stays PAYMENT_IN_PROGRESS until it is
+// reconciled with the payment service.
+// Ref: #/components/schemas/payment_pending_response
+type PaymentPendingResponse struct {
+	// Error type.
+	Error string `json:"error"`
+	// What to do next.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *PaymentPendingResponse) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *PaymentPendingResponse) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *PaymentPendingResponse) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *PaymentPendingResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*PaymentPendingResponse) payOrderRes() {}
+
+// Ref: #/components/schemas/refund_order_request
+type RefundOrderRequest struct {
+	// Amount to refund; the whole remaining amount when omitted.
+	Amount OptFloat64 `json:"amount"`
+	// Reason of the refund.
+	Reason OptString `json:"reason"`
+}
+
+// GetAmount returns the value of Amount.
+func (s *RefundOrderRequest) GetAmount() OptFloat64 {
+	return s.Amount
+}
+
+// GetReason returns the value of Reason.
+func (s *RefundOrderRequest) GetReason() OptString {
+	return s.Reason
+}
+
+// SetAmount sets the value of Amount.
+func (s *RefundOrderRequest) SetAmount(val OptFloat64) {
+	s.Amount = val
+}
+
+// SetReason sets the value of Reason.
+func (s *RefundOrderRequest) SetReason(val OptString) {
+	s.Reason = val
+}
+
+// Ref: #/components/schemas/refund_order_response
+type RefundOrderResponse struct {
+	// Refund UUID.
+	RefundUUID uuid.UUID `json:"refund_uuid"`
+	// Total amount refunded so far.
+	RefundedAmount float64     `json:"refunded_amount"`
+	Status         OrderStatus `json:"status"`
+}
+
+// GetRefundUUID returns the value of RefundUUID.
+func (s *RefundOrderResponse) GetRefundUUID() uuid.UUID {
+	return s.RefundUUID
+}
+
+// GetRefundedAmount returns the value of RefundedAmount.
+func (s *RefundOrderResponse) GetRefundedAmount() float64 {
+	return s.RefundedAmount
+}
+
+// GetStatus returns the value of Status.
+func (s *RefundOrderResponse) GetStatus() OrderStatus {
+	return s.Status
+}
+
+// SetRefundUUID sets the value of RefundUUID.
+func (s *RefundOrderResponse) SetRefundUUID(val uuid.UUID) {
+	s.RefundUUID = val
+}
+
+// SetRefundedAmount sets the value of RefundedAmount.
+func (s *RefundOrderResponse) SetRefundedAmount(val float64) {
+	s.RefundedAmount = val
+}
+
+// SetStatus sets the value of Status.
+func (s *RefundOrderResponse) SetStatus(val OrderStatus) {
+	s.Status = val
+}
+
+func (*RefundOrderResponse) refundOrderRes() {}
+
+// The outcome of the refund is not known yet; the order stays REFUND_IN_PROGRESS until it is
+// reconciled with the payment service.
+// Ref: #/components/schemas/refund_pending_response
+type RefundPendingResponse struct {
+	// Error type.
+	Error string `json:"error"`
+	// What to do next.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *RefundPendingResponse) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *RefundPendingResponse) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *RefundPendingResponse) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *RefundPendingResponse) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*RefundPendingResponse) refundOrderRes() {}
+
+// Ref: #/components/schemas/service_unavailable_error
+type ServiceUnavailableError struct {
+	// Error type.
+	Error string `json:"error"`
+	// Error message.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *ServiceUnavailableError) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *ServiceUnavailableError) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *ServiceUnavailableError) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ServiceUnavailableError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*ServiceUnavailableError) createOrderRes() {}
+func (*ServiceUnavailableError) payOrderRes()    {}
+func (*ServiceUnavailableError) refundOrderRes() {}
+
+type SessionAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *SessionAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *SessionAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *SessionAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *SessionAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// Ref: #/components/schemas/unauthorized_error
+type UnauthorizedError struct {
+	// Error type.
+	Error string `json:"error"`
+	// Error message.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *UnauthorizedError) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *UnauthorizedError) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *UnauthorizedError) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *UnauthorizedError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*UnauthorizedError) cancelOrderRes() {}
+func (*UnauthorizedError) createOrderRes() {}
+func (*UnauthorizedError) getOrderRes()    {}
+func (*UnauthorizedError) listOrdersRes()  {}
+func (*UnauthorizedError) payOrderRes()    {}
+func (*UnauthorizedError) refundOrderRes() {}
+
+// Ref: #/components/schemas/unprocessable_entity_error
+type UnprocessableEntityError struct {
+	// Error type.
+	Error string `json:"error"`
+	// Error message.
+	Message string `json:"message"`
+}
+
+// GetError returns the value of Error.
+func (s *UnprocessableEntityError) GetError() string {
+	return s.Error
+}
+
+// GetMessage returns the value of Message.
+func (s *UnprocessableEntityError) GetMessage() string {
+	return s.Message
+}
+
+// SetError sets the value of Error.
+func (s *UnprocessableEntityError) SetError(val string) {
+	s.Error = val
+}
+
+// SetMessage sets the value of Message.
+func (s *UnprocessableEntityError) SetMessage(val string) {
+	s.Message = val
+}
+
+func (*UnprocessableEntityError) createOrderRes() {}
+func (*UnprocessableEntityError) payOrderRes()    {}
@@ -0,0 +1,78 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package orderv1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+)
+
+// SecurityHandler is handler for security parameters.
+type SecurityHandler interface {
+	// HandleSessionAuth handles sessionAuth security.
+	// UUID of a session opened by IAM Login.
+	HandleSessionAuth(ctx context.Context, operationName OperationName, t SessionAuth) (context.Context, error)
+}
+
+func findAuthorization(h http.Header, prefix string) (string, bool) {
+	v, ok := h["Authorization"]
+	if !ok {
+		return "", false
+	}
+	for _, vv := range v {
+		scheme, value, ok := strings.Cut(vv, " ")
+		if !ok || !strings.EqualFold(scheme, prefix) {
+			continue
+		}
+		return value, true
+	}
+	return "", false
+}
+
+var operationRolesSessionAuth = map[string][]string{
+	CancelOrderOperation: []string{},
+	CreateOrderOperation: []string{},
+	GetOrderOperation:    []string{},
+	ListOrdersOperation:  []string{},
+	PayOrderOperation:    []string{},
+	RefundOrderOperation: []string{},
+}
+
+func (s *Server) securitySessionAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t SessionAuth
+	const parameterName = "X-Session-Uuid"
+	value := req.Header.Get(parameterName)
+	if value == "" {
+		return ctx, false, nil
+	}
+	t.APIKey = value
+	t.Roles = operationRolesSessionAuth[operationName]
+	rctx, err := s.sec.HandleSessionAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
+// SecuritySource is provider of security values (tokens, passwords, etc.).
+type SecuritySource interface {
+	// SessionAuth provides sessionAuth security value.
+	// UUID of a session opened by IAM Login.
+	SessionAuth(ctx context.Context, operationName OperationName) (SessionAuth, error)
+}
+
+func (s *Client) securitySessionAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.SessionAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"SessionAuth\"")
+	}
+	req.Header.Set("X-Session-Uuid", t.APIKey)
+	return nil
+}
@@ -27,7 +27,7 @@ func (UnimplementedHandler) CancelOrder(ctx context.Context, params CancelOrderP
 // Create new order.
 //
 // POST /api/v1/orders
-func (UnimplementedHandler) CreateOrder(ctx context.Context, req *CreateOrderRequest) (r CreateOrderRes, _ error) {
+func (UnimplementedHandler) CreateOrder(ctx context.Context, req *CreateOrderRequest, params CreateOrderParams) (r CreateOrderRes, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
@@ -40,6 +40,15 @@ func (UnimplementedHandler) GetOrder(ctx context.Context, params GetOrderParams)
 	return r, ht.ErrNotImplemented
 }
 
+// ListOrders implements listOrders operation.
+//
+// List orders.
+//
+// GET /api/v1/orders
+func (UnimplementedHandler) ListOrders(ctx context.Context, params ListOrdersParams) (r ListOrdersRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // PayOrder implements payOrder operation.
 //
 // Pay order.
@@ -49,6 +58,15 @@ func (UnimplementedHandler) PayOrder(ctx context.Context, req *PayOrderRequest,
 	return r, ht.ErrNotImplemented
 }
 
+// RefundOrder implements refundOrder operation.
+//
+// Refund paid order.
+//
+// POST /api/v1/orders/{order_uuid}/refund
+func (UnimplementedHandler) RefundOrder(ctx context.Context, req OptRefundOrderRequest, params RefundOrderParams) (r RefundOrderRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // NewError creates *InternalServerErrorStatusCode from error returned by handler.
 //
 // Used for common default response.